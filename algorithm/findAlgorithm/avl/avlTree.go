@@ -1,4 +1,4 @@
-package main
+package avl
 
 import (
 	"fmt"
@@ -287,7 +287,7 @@ func (node *AVLTreeNode) MidOreder() {
 	// 先打印左子树
 	node.Left.MidOreder()
 	// 按照次数打印根节点
-	for i := 0; i < int(node.Times); i++ {
+	for i := 0; i <= int(node.Times); i++ {
 		fmt.Println("value:", node.Value, " tree height:", node.BalanceFactor())
 	}
 	// 最后打印右子树
@@ -419,9 +419,10 @@ func (node *AVLTreeNode) Delete(value int64) *AVLTreeNode {
 				node.Height = 1
 				node.Right = nil
 			}
+			// 找到值后，进行替换删除后，直接返回该节点
+			return node
 		}
-		// 找到值后，进行替换删除后，直接返回该节点
-		return node
+		// 有两棵子树时，删除替换节点后该节点也可能失衡，继续往下进行平衡调整
 	}
 	// 左右子树递归删除节点后需要平衡
 	var newNode *AVLTreeNode
@@ -555,62 +556,3 @@ func (node *AVLTreeNode) IsRight() bool {
 	}
 	return true
 }
-
-// 验证测试
-// 程序是递归程序，如果改写为非递归形式，效率和性能会更好，
-// 在此就不实现了，理解AVL树添加和删除的总体思路即可
-// AVL 树作为严格平衡的二叉查找树，在 windows 对进程地址空间的管理被使用到
-func main() {
-	values := []int64{2, 3, 7, 10, 10, 10, 10, 23, 9, 102, 109, 111, 112, 113}
-
-	// 初始化二叉查找树并添加元素
-	tree := NewAVLTree()
-	for _, v := range values {
-		tree.Add(v)
-	}
-
-	// 找到最大值或最小值的节点
-	fmt.Println("find min value:", tree.FindMinValue())
-	fmt.Println("find max value:", tree.FindMaxValue())
-
-	// 查找不存在的99
-	node := tree.Find(99)
-	if node != nil {
-		fmt.Println("find it 99!")
-	} else {
-		fmt.Println("not find it 99!")
-	}
-
-	// 查找存在的9
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	// 删除存在的9后，再查找9
-	tree.Delete(9)
-	tree.Delete(10)
-	tree.Delete(2)
-	tree.Delete(3)
-	tree.Add(4)
-	tree.Add(3)
-	tree.Add(10)
-	tree.Delete(111)
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	// 中序遍历，实现排序
-	tree.MidOrder()
-
-	if tree.IsAVLTree() {
-		fmt.Println("is a avl tree")
-	} else {
-		fmt.Println("is not avl tree")
-	}
-}
\ No newline at end of file
@@ -0,0 +1,76 @@
+package avl_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/avl"
+)
+
+func ExampleAVLTree() {
+	values := []int64{2, 3, 7, 10, 10, 10, 10, 23, 9, 102, 109, 111, 112, 113}
+	tree := avl.NewAVLTree()
+	for _, v := range values {
+		tree.Add(v)
+	}
+
+	// 找到最大值或最小值的节点
+	fmt.Println("find min value:", tree.FindMinValue().Value)
+	fmt.Println("find max value:", tree.FindMaxValue().Value)
+
+	// 查找不存在的99
+	node := tree.Find(99)
+	if node != nil {
+		fmt.Println("find it 99!")
+	} else {
+		fmt.Println("not find it 99!")
+	}
+
+	// 查找存在的9
+	node = tree.Find(9)
+	if node != nil {
+		fmt.Println("find it 9!")
+	} else {
+		fmt.Println("not find it 9!")
+	}
+
+	// 删除存在的9后，再查找9
+	tree.Delete(9)
+	tree.Delete(10)
+	tree.Delete(2)
+	tree.Delete(3)
+	tree.Add(4)
+	tree.Add(3)
+	tree.Add(10)
+	tree.Delete(111)
+	node = tree.Find(9)
+	if node != nil {
+		fmt.Println("find it 9!")
+	} else {
+		fmt.Println("not find it 9!")
+	}
+
+	// 中序遍历，实现排序
+	tree.MidOrder()
+
+	if tree.IsAVLTree() {
+		fmt.Println("is a avl tree")
+	} else {
+		fmt.Println("is not avl tree")
+	}
+	// Output:
+	// find min value: 2
+	// find max value: 113
+	// not find it 99!
+	// find it 9!
+	// not find it 9!
+	// value: 3  tree height: 0
+	// value: 4  tree height: 1
+	// value: 7  tree height: 0
+	// value: 10  tree height: 0
+	// value: 23  tree height: 1
+	// value: 102  tree height: 1
+	// value: 109  tree height: 0
+	// value: 112  tree height: 0
+	// value: 113  tree height: 0
+	// is a avl tree
+}
@@ -0,0 +1,216 @@
+package bloom
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 布隆过滤器
+/*
+布隆过滤器用一个 m 位的位数组和 k 个哈希函数表示一个集合：
+添加元素时将 k 个哈希函数算出的 k 个位置 1，查询时这 k 个位都为 1 才认为元素可能存在。
+只要有一位为 0，元素就一定不存在，所以常放在哈希表、树或者磁盘查找之前，过滤掉一定不存在的键。
+代价是有一定的误判率（假阳性），而且不能删除元素。
+
+k 个哈希函数不需要真的计算 k 次，使用双重哈希（Kirsch-Mitzenmacher）：
+	h1 = XXHash(data)，h2 = Mix64(h1) | 1
+	gi = h1 + i*h2 (mod m)，i = 0..k-1
+误判率与 k 个独立哈希函数几乎相同。
+
+预计添加 n 个元素、目标误判率为 p 时，最优参数为：
+	m = -n*ln(p) / (ln2)^2
+	k = m/n * ln2
+误判率约为 (1 - e^(-kn/m))^k。
+*/
+
+var (
+	// ErrIncompatible 两个过滤器的大小或哈希函数数量不同，不能合并
+	ErrIncompatible = errors.New("bloom: incompatible filters")
+	// ErrInvalidData 序列化数据格式错误
+	ErrInvalidData = errors.New("bloom: invalid data")
+	// ErrChecksum 序列化数据校验和不匹配
+	ErrChecksum = errors.New("bloom: checksum mismatch")
+)
+
+// EstimateParameters 根据预计元素数量 n 和目标误判率 fpr 计算位数组大小 m 和哈希函数数量 k
+func EstimateParameters(n uint64, fpr float64) (m uint64, k int) {
+	if n == 0 {
+		n = 1
+	}
+	if fpr <= 0 || fpr >= 1 {
+		fpr = 0.01
+	}
+	m = uint64(math.Ceil(-float64(n) * math.Log(fpr) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return m, k
+}
+
+// locations 双重哈希，计算数据在大小为 m 的数组中的 k 个位置
+func locations(data []byte, m uint64, k int, dst []uint64) []uint64 {
+	h1 := hashmap.XXHash(data)
+	h2 := hashmap.Mix64(h1) | 1
+	for i := 0; i < k; i++ {
+		dst = append(dst, (h1+uint64(i)*h2)%m)
+	}
+	return dst
+}
+
+// Filter 布隆过滤器
+type Filter struct {
+	bits  []uint64     // 位数组
+	m     uint64       // 位数
+	k     int          // 哈希函数数量
+	count uint64       // 添加的次数，重复添加同一元素也会计数
+	lock  sync.RWMutex // 并发安全，查询只加读锁
+}
+
+// New 创建 m 位、k 个哈希函数的布隆过滤器
+func New(m uint64, k int) *Filter {
+	if m == 0 {
+		m = 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// NewWithEstimates 根据预计元素数量 n 和目标误判率 fpr 创建布隆过滤器
+func NewWithEstimates(n uint64, fpr float64) *Filter {
+	return New(EstimateParameters(n, fpr))
+}
+
+// M 返回位数
+func (f *Filter) M() uint64 {
+	return f.m
+}
+
+// K 返回哈希函数数量
+func (f *Filter) K() int {
+	return f.k
+}
+
+// Count 返回添加的次数
+func (f *Filter) Count() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.count
+}
+
+// Add 添加元素
+func (f *Filter) Add(data []byte) {
+	var buf [16]uint64
+	locs := locations(data, f.m, f.k, buf[:0])
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, loc := range locs {
+		f.bits[loc/64] |= 1 << (loc % 64)
+	}
+	f.count++
+}
+
+// AddString 添加字符串元素
+func (f *Filter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// Test 查询元素，返回 false 表示一定不存在，返回 true 表示可能存在
+func (f *Filter) Test(data []byte) bool {
+	var buf [16]uint64
+	locs := locations(data, f.m, f.k, buf[:0])
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	for _, loc := range locs {
+		if f.bits[loc/64]&(1<<(loc%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestString 查询字符串元素
+func (f *Filter) TestString(s string) bool {
+	return f.Test([]byte(s))
+}
+
+// Clear 清空过滤器
+func (f *Filter) Clear() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	clear(f.bits)
+	f.count = 0
+}
+
+// OnesCount 返回位数组中 1 的个数
+func (f *Filter) OnesCount() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var ones int
+	for _, w := range f.bits {
+		ones += bits.OnesCount64(w)
+	}
+	return uint64(ones)
+}
+
+// EstimatedFPR 根据已添加的次数估算当前的误判率
+func (f *Filter) EstimatedFPR() float64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return math.Pow(1-math.Exp(-float64(f.k)*float64(f.count)/float64(f.m)), float64(f.k))
+}
+
+// compatible 判断两个过滤器能否合并
+func (f *Filter) compatible(other *Filter) bool {
+	return f.m == other.m && f.k == other.k
+}
+
+// snapshot 复制位数组，合并时先复制另一个过滤器，避免同时持有两把锁
+func (f *Filter) snapshot() ([]uint64, uint64) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]uint64(nil), f.bits...), f.count
+}
+
+// Union 并集，合并后 f 包含两个过滤器中的所有元素，误判率与同时添加所有元素相同
+func (f *Filter) Union(other *Filter) error {
+	if !f.compatible(other) {
+		return ErrIncompatible
+	}
+	if f == other {
+		return nil
+	}
+	words, count := other.snapshot()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, w := range words {
+		f.bits[i] |= w
+	}
+	f.count += count
+	return nil
+}
+
+// Intersect 交集，合并后 f 只对两个过滤器中都可能存在的元素返回 true
+// 交集的误判率比分别添加交集元素的过滤器更高，添加次数取两者中较小的值作为估计
+func (f *Filter) Intersect(other *Filter) error {
+	if !f.compatible(other) {
+		return ErrIncompatible
+	}
+	if f == other {
+		return nil
+	}
+	words, count := other.snapshot()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, w := range words {
+		f.bits[i] &= w
+	}
+	f.count = min(f.count, count)
+	return nil
+}
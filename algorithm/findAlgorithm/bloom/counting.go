@@ -0,0 +1,199 @@
+package bloom
+
+import (
+	"math"
+	"sync"
+)
+
+// 计数布隆过滤器
+/*
+普通布隆过滤器的一个位可能被多个元素共用，删除时不能把位清 0。
+计数布隆过滤器把每一位换成一个计数器：添加时 k 个计数器加 1，删除时减 1，查询时 k 个计数器都不为 0 才可能存在。
+这里每个计数器占 1 字节，计数达到 255 后不再变化（饱和），饱和的计数器删除时也不再减少，
+否则可能把其他元素的计数减到 0，产生假阴性。
+
+只能删除确实添加过的元素，删除一个没有添加过、但误判为存在的元素同样会产生假阴性。
+*/
+
+// 计数器的最大值
+const maxCounter = math.MaxUint8
+
+// CountingFilter 计数布隆过滤器
+type CountingFilter struct {
+	counters []uint8      // 计数器数组
+	m        uint64       // 计数器数量
+	k        int          // 哈希函数数量
+	count    uint64       // 元素数量，添加加 1，删除减 1
+	lock     sync.RWMutex // 并发安全，查询只加读锁
+}
+
+// NewCounting 创建 m 个计数器、k 个哈希函数的计数布隆过滤器
+func NewCounting(m uint64, k int) *CountingFilter {
+	if m == 0 {
+		m = 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	return &CountingFilter{counters: make([]uint8, m), m: m, k: k}
+}
+
+// NewCountingWithEstimates 根据预计元素数量 n 和目标误判率 fpr 创建计数布隆过滤器
+func NewCountingWithEstimates(n uint64, fpr float64) *CountingFilter {
+	return NewCounting(EstimateParameters(n, fpr))
+}
+
+// M 返回计数器数量
+func (f *CountingFilter) M() uint64 {
+	return f.m
+}
+
+// K 返回哈希函数数量
+func (f *CountingFilter) K() int {
+	return f.k
+}
+
+// Count 返回元素数量
+func (f *CountingFilter) Count() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.count
+}
+
+// Add 添加元素
+func (f *CountingFilter) Add(data []byte) {
+	var buf [16]uint64
+	locs := locations(data, f.m, f.k, buf[:0])
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, loc := range locs {
+		if f.counters[loc] < maxCounter {
+			f.counters[loc]++
+		}
+	}
+	f.count++
+}
+
+// AddString 添加字符串元素
+func (f *CountingFilter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// Remove 删除元素，元素一定不存在时不做任何修改并返回 false
+func (f *CountingFilter) Remove(data []byte) bool {
+	var buf [16]uint64
+	locs := locations(data, f.m, f.k, buf[:0])
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.test(locs) {
+		return false
+	}
+	for _, loc := range locs {
+		// 饱和的计数器不知道真实的计数，不再减少
+		if f.counters[loc] < maxCounter {
+			f.counters[loc]--
+		}
+	}
+	if f.count > 0 {
+		f.count--
+	}
+	return true
+}
+
+// RemoveString 删除字符串元素
+func (f *CountingFilter) RemoveString(s string) bool {
+	return f.Remove([]byte(s))
+}
+
+// Test 查询元素，返回 false 表示一定不存在，返回 true 表示可能存在
+func (f *CountingFilter) Test(data []byte) bool {
+	var buf [16]uint64
+	locs := locations(data, f.m, f.k, buf[:0])
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.test(locs)
+}
+
+// TestString 查询字符串元素
+func (f *CountingFilter) TestString(s string) bool {
+	return f.Test([]byte(s))
+}
+
+// test 判断 k 个计数器是否都不为 0
+func (f *CountingFilter) test(locs []uint64) bool {
+	for _, loc := range locs {
+		if f.counters[loc] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear 清空过滤器
+func (f *CountingFilter) Clear() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	clear(f.counters)
+	f.count = 0
+}
+
+// EstimatedFPR 根据元素数量估算当前的误判率
+func (f *CountingFilter) EstimatedFPR() float64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return math.Pow(1-math.Exp(-float64(f.k)*float64(f.count)/float64(f.m)), float64(f.k))
+}
+
+// Filter 转换为普通布隆过滤器，计数器不为 0 的位置为 1，可以与参数相同的普通布隆过滤器合并
+func (f *CountingFilter) Filter() *Filter {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	bf := New(f.m, f.k)
+	for loc, c := range f.counters {
+		if c != 0 {
+			bf.bits[loc/64] |= 1 << (loc % 64)
+		}
+	}
+	bf.count = f.count
+	return bf
+}
+
+// snapshot 复制计数器数组
+func (f *CountingFilter) snapshot() ([]uint8, uint64) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]uint8(nil), f.counters...), f.count
+}
+
+// Union 并集，对应的计数器相加（饱和）
+func (f *CountingFilter) Union(other *CountingFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	counters, count := other.snapshot()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, c := range counters {
+		f.counters[i] = uint8(min(int(f.counters[i])+int(c), maxCounter))
+	}
+	f.count += count
+	return nil
+}
+
+// Intersect 交集，对应的计数器取较小值
+func (f *CountingFilter) Intersect(other *CountingFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	if f == other {
+		return nil
+	}
+	counters, count := other.snapshot()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, c := range counters {
+		f.counters[i] = min(f.counters[i], c)
+	}
+	f.count = min(f.count, count)
+	return nil
+}
@@ -0,0 +1,130 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 序列化
+/*
+格式（整数均为小端序，uvarint 为变长无符号整数）：
+	magic     4 字节，普通布隆过滤器为 "BLMF"，计数布隆过滤器为 "BLMC"
+	version   1 字节，当前为 1
+	m         uvarint，位数或计数器数量
+	k         uvarint，哈希函数数量
+	count     uvarint，元素数量
+	payload   普通布隆过滤器为 (m+63)/64 个 8 字节的字，计数布隆过滤器为 m 个 1 字节的计数器
+	checksum  8 字节，前面所有内容的 xxhash64
+
+哈希函数固定为 XXHash 双重哈希，m 和 k 相同的过滤器反序列化后可以直接合并。
+*/
+
+// 魔数和版本
+const (
+	filterMagic   = "BLMF"
+	countingMagic = "BLMC"
+	formatVersion = 1
+)
+
+// appendHeader 写入头部
+func appendHeader(dst []byte, magic string, m uint64, k int, count uint64) []byte {
+	dst = append(dst, magic...)
+	dst = append(dst, formatVersion)
+	dst = binary.AppendUvarint(dst, m)
+	dst = binary.AppendUvarint(dst, uint64(k))
+	return binary.AppendUvarint(dst, count)
+}
+
+// appendChecksum 写入校验和
+func appendChecksum(dst []byte) []byte {
+	return binary.LittleEndian.AppendUint64(dst, hashmap.XXHash(dst))
+}
+
+// decodeHeader 校验魔数、版本和校验和，返回头部字段以及 payload
+func decodeHeader(data []byte, magic string) (m uint64, k int, count uint64, payload []byte, err error) {
+	if len(data) < len(magic)+1+8 || string(data[:len(magic)]) != magic {
+		return 0, 0, 0, nil, ErrInvalidData
+	}
+	body, sum := data[:len(data)-8], binary.LittleEndian.Uint64(data[len(data)-8:])
+	if hashmap.XXHash(body) != sum {
+		return 0, 0, 0, nil, ErrChecksum
+	}
+	if body[len(magic)] != formatVersion {
+		return 0, 0, 0, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, body[len(magic)])
+	}
+	rest := body[len(magic)+1:]
+	var fields [3]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(rest)
+		if n <= 0 {
+			return 0, 0, 0, nil, ErrInvalidData
+		}
+		fields[i], rest = v, rest[n:]
+	}
+	if fields[0] == 0 || fields[1] == 0 || fields[1] > 1<<16 {
+		return 0, 0, 0, nil, ErrInvalidData
+	}
+	return fields[0], int(fields[1]), fields[2], rest, nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	data := appendHeader(make([]byte, 0, 32+8*len(f.bits)), filterMagic, f.m, f.k, f.count)
+	for _, w := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return appendChecksum(data), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，替换 f 原有的内容
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	m, k, count, payload, err := decodeHeader(data, filterMagic)
+	if err != nil {
+		return err
+	}
+	// 先检查长度再分配内存，避免错误的 m 导致分配过大的数组
+	// m 接近 2^64 时 (m+63)/64 会溢出为 0，所以先与 payload 的位数比较
+	if m > uint64(len(payload))*8 {
+		return fmt.Errorf("%w: payload is %d bytes, too short for %d bits", ErrInvalidData, len(payload), m)
+	}
+	words := (m + 63) / 64
+	if uint64(len(payload)) != words*8 {
+		return fmt.Errorf("%w: payload is %d bytes, want %d", ErrInvalidData, len(payload), words*8)
+	}
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.bits, f.m, f.k, f.count = bits, m, k, count
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (f *CountingFilter) MarshalBinary() ([]byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	data := appendHeader(make([]byte, 0, 32+len(f.counters)), countingMagic, f.m, f.k, f.count)
+	data = append(data, f.counters...)
+	return appendChecksum(data), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，替换 f 原有的内容
+func (f *CountingFilter) UnmarshalBinary(data []byte) error {
+	m, k, count, payload, err := decodeHeader(data, countingMagic)
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) != m {
+		return fmt.Errorf("%w: payload is %d bytes, want %d", ErrInvalidData, len(payload), m)
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.counters, f.m, f.k, f.count = append([]uint8(nil), payload...), m, k, count
+	return nil
+}
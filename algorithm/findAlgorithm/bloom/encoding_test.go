@@ -0,0 +1,37 @@
+package bloom
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// 校验和正确，但头部的 m 与 payload 不符的数据
+func TestUnmarshalInvalidSize(t *testing.T) {
+	for _, tt := range []struct {
+		m       uint64
+		payload []byte
+	}{
+		{math.MaxUint64, nil},      // (m+63)/64 溢出为 0
+		{math.MaxUint64 - 62, nil}, // (m+63)/64 溢出为 0
+		{65, make([]byte, 8)},      // 少一个字
+		{64, make([]byte, 16)},     // 多一个字
+		{1 << 40, make([]byte, 8)}, // 不能按 m 分配内存
+	} {
+		data := appendChecksum(append(appendHeader(nil, filterMagic, tt.m, 3, 0), tt.payload...))
+		f := New(64, 3)
+		f.AddString("old")
+		if err := f.UnmarshalBinary(data); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("m = %d: %v", tt.m, err)
+		}
+		// 失败时过滤器保持不变，之后仍然可以正常使用
+		if f.m != 64 || !f.TestString("old") {
+			t.Fatalf("m = %d: filter changed", tt.m)
+		}
+
+		counting := appendChecksum(append(appendHeader(nil, countingMagic, tt.m, 3, 0), tt.payload...))
+		if err := NewCounting(64, 3).UnmarshalBinary(counting); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("counting m = %d: %v", tt.m, err)
+		}
+	}
+}
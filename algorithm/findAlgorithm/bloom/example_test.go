@@ -0,0 +1,85 @@
+package bloom_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/bloom"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+func ExampleFilter() {
+	// 预计 1000 个元素，误判率 1%
+	f := bloom.NewWithEstimates(1000, 0.01)
+	fmt.Println(f.M(), f.K())
+
+	// 查询哈希表之前先用布隆过滤器过滤掉一定不存在的键
+	m := hashmap.NewHashMap[string, int](16)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("user:%d", i)
+		m.Put(key, i)
+		f.AddString(key)
+	}
+	for _, key := range []string{"user:42", "user:4242"} {
+		if !f.TestString(key) {
+			fmt.Println(key, "definitely not present")
+			continue
+		}
+		v, ok := m.Get(key)
+		fmt.Println(key, v, ok)
+	}
+	fmt.Printf("%.4f\n", f.EstimatedFPR())
+	// Output:
+	// 9586 7
+	// user:42 42 true
+	// user:4242 definitely not present
+	// 0.0100
+}
+
+func ExampleFilter_Union() {
+	a := bloom.NewWithEstimates(100, 0.01)
+	b := bloom.NewWithEstimates(100, 0.01)
+	a.AddString("apple")
+	b.AddString("banana")
+	if err := a.Union(b); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(a.TestString("apple"), a.TestString("banana"), a.Count())
+
+	// 参数不同的过滤器不能合并
+	c := bloom.NewWithEstimates(1000, 0.01)
+	fmt.Println(errors.Is(a.Intersect(c), bloom.ErrIncompatible))
+	// Output:
+	// true true 2
+	// true
+}
+
+func ExampleCountingFilter() {
+	f := bloom.NewCountingWithEstimates(100, 0.01)
+	f.AddString("apple")
+	f.AddString("banana")
+	fmt.Println(f.RemoveString("apple"), f.RemoveString("cherry"))
+	fmt.Println(f.TestString("apple"), f.TestString("banana"), f.Count())
+	// Output:
+	// true false
+	// false true 1
+}
+
+func ExampleFilter_MarshalBinary() {
+	f := bloom.NewWithEstimates(100, 0.01)
+	f.AddString("apple")
+	data, _ := f.MarshalBinary()
+
+	g := new(bloom.Filter)
+	if err := g.UnmarshalBinary(data); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(g.M(), g.K(), g.TestString("apple"), g.TestString("banana"))
+
+	// 数据损坏时校验和不匹配
+	data[len(data)-1] ^= 0xff
+	fmt.Println(g.UnmarshalBinary(data))
+	// Output:
+	// 959 7 true false
+	// bloom: checksum mismatch
+}
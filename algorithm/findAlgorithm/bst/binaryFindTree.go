@@ -1,4 +1,4 @@
-package main
+package bst
 
 import "fmt"
 
@@ -247,7 +247,7 @@ func (tree *BinarySearchTree) Delete(value int64) {
 		// 替换后二叉查找树的性质又满足了
 
 		// 找右子树中最小的值，一直往右子树的左边找
-		minNode := parent.Right
+		minNode := node.Right
 		for minNode.Left != nil {
 			minNode = minNode.Left
 		}
@@ -0,0 +1,76 @@
+package consistent_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/consistent"
+)
+
+func ExampleRing() {
+	ring := consistent.NewRing(100)
+	ring.AddNode("cache-a", 1)
+	ring.AddNode("cache-b", 1)
+	ring.AddNode("cache-c", 2)
+
+	node, _ := ring.Locate("user:42")
+	fmt.Println(node)
+	// 多副本：顺时针的 2 个不同节点
+	fmt.Println(ring.LocateN("user:42", 2))
+	// Output:
+	// cache-c
+	// [cache-c cache-b]
+}
+
+func ExampleRing_AddNode() {
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprint("key", i)
+	}
+	ring := consistent.NewRing(100)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		ring.AddNode(name, 1)
+	}
+	before := consistent.Assign(ring, keys)
+
+	// 添加节点时返回的比例是新节点负责的哈希值空间，实际迁移的键的比例与之接近
+	moved, _ := ring.AddNode("e", 1)
+	after := consistent.Assign(ring, keys)
+	fmt.Printf("expected %.3f, actual %.3f\n", moved, consistent.MovementRatio(before, after))
+
+	moved, _ = ring.RemoveNode("b")
+	fmt.Printf("expected %.3f, actual %.3f\n", moved, consistent.MovementRatio(after, consistent.Assign(ring, keys)))
+	// Output:
+	// expected 0.211, actual 0.215
+	// expected 0.189, actual 0.194
+}
+
+func ExampleJumpHash() {
+	// 桶从 4 个增加到 5 个，只有约 1/5 的键换了桶
+	moved := 0
+	for key := uint64(0); key < 10000; key++ {
+		if consistent.JumpHash(key, 4) != consistent.JumpHash(key, 5) {
+			moved++
+		}
+	}
+	fmt.Println(consistent.JumpHash(42, 4), consistent.JumpHash(42, 5), moved)
+	// Output:
+	// 2 2 1996
+}
+
+func ExampleRendezvous() {
+	r := consistent.NewRendezvous()
+	r.AddNode("a", 1)
+	r.AddNode("b", 1)
+	r.AddNode("c", 2)
+
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprint("key", i)
+	}
+	load := consistent.Load(consistent.Assign(r, keys))
+	fmt.Println(load["a"], load["b"], load["c"])
+	fmt.Println(r.LocateN("user:42", 3))
+	// Output:
+	// 2425 2562 5013
+	// [c b a]
+}
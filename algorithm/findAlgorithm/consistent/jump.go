@@ -0,0 +1,84 @@
+package consistent
+
+import (
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// 跳跃一致性哈希（Jump Consistent Hash，Lamping & Veach 2014）
+/*
+不需要保存环，只用几行代码就能把 64 位的键映射到 [0, n) 的桶中：
+桶数量从 n 增加到 n+1 时，每个键以 1/(n+1) 的概率跳到新桶，否则留在原来的桶，
+所以迁移的比例正好是 1/(n+1)，负载也几乎完全均匀，时间复杂度 O(log n)，没有额外内存。
+
+代价是桶只能按编号增减：只能在末尾添加或删除桶，不能删除中间的节点，也不支持权重，
+适合存储分片这种节点编号固定、只会扩容的场景。
+*/
+
+// JumpHash 跳跃一致性哈希，返回键在 buckets 个桶中的编号，buckets 小于等于 0 时返回 -1
+func JumpHash(key uint64, buckets int) int {
+	if buckets <= 0 {
+		return -1
+	}
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		// 线性同余生成器产生下一个伪随机数，j 是下一次跳到的桶
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// Jump 使用跳跃一致性哈希的节点列表，只能在末尾添加或删除节点
+type Jump struct {
+	hasher hash.XXHashHasher // 计算键的哈希值
+	nodes  []string          // 节点，下标就是桶编号
+	lock   sync.RWMutex      // 并发安全
+}
+
+var _ Locator = (*Jump)(nil)
+
+// NewJump 创建跳跃一致性哈希，nodes 依次为 0, 1, 2... 号桶
+func NewJump(nodes ...string) *Jump {
+	return &Jump{hasher: hash.NewXXHash(0), nodes: append([]string(nil), nodes...)}
+}
+
+// Len 返回节点数量
+func (j *Jump) Len() int {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+	return len(j.nodes)
+}
+
+// AddNode 在末尾添加节点，返回需要迁移到新节点的键的比例
+func (j *Jump) AddNode(name string) float64 {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.nodes = append(j.nodes, name)
+	return 1 / float64(len(j.nodes))
+}
+
+// RemoveLast 删除最后一个节点，返回该节点以及需要迁移的键的比例，没有节点时返回 false
+func (j *Jump) RemoveLast() (string, float64, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if len(j.nodes) == 0 {
+		return "", 0, false
+	}
+	moved := 1 / float64(len(j.nodes))
+	name := j.nodes[len(j.nodes)-1]
+	j.nodes = j.nodes[:len(j.nodes)-1]
+	return name, moved, true
+}
+
+// Locate 查找键所属的节点，没有节点时返回 false
+func (j *Jump) Locate(key string) (string, bool) {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+	if len(j.nodes) == 0 {
+		return "", false
+	}
+	return j.nodes[JumpHash(j.hasher.Sum64String(key), len(j.nodes))], true
+}
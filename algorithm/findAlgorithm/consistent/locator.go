@@ -0,0 +1,40 @@
+package consistent
+
+// Locator 把键分配到节点上，Ring、Jump、Rendezvous 都实现了该接口
+type Locator interface {
+	Locate(key string) (string, bool)
+}
+
+// Assign 计算每个键所属的节点，节点为空时为 ""
+func Assign(l Locator, keys []string) []string {
+	nodes := make([]string, len(keys))
+	for i, key := range keys {
+		nodes[i], _ = l.Locate(key)
+	}
+	return nodes
+}
+
+// MovementRatio 比较节点变化前后同一组键的分配结果，返回换了节点的键的比例
+// before 和 after 为 Assign 的结果，长度不同时只比较前面相同长度的部分
+func MovementRatio(before, after []string) float64 {
+	n := min(len(before), len(after))
+	if n == 0 {
+		return 0
+	}
+	moved := 0
+	for i := 0; i < n; i++ {
+		if before[i] != after[i] {
+			moved++
+		}
+	}
+	return float64(moved) / float64(n)
+}
+
+// Load 统计分配结果中每个节点的键的数量
+func Load(nodes []string) map[string]int {
+	load := make(map[string]int)
+	for _, node := range nodes {
+		load[node]++
+	}
+	return load
+}
@@ -0,0 +1,136 @@
+package consistent
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 最高随机权重哈希（Rendezvous Hashing，HRW）
+/*
+对每个键，给每个节点算一个分数 score(key, node)，分数最高的节点就是键所属的节点，
+LocateN 取分数最高的 n 个节点。删除节点时只有原来属于它的键迁移，
+而且会分散到分数第二高的各个节点上，不会都压到一个相邻节点；添加节点时只有新节点分数最高的键迁移。
+
+带权重时分数为 -weight / ln(h)，h 为 (0, 1) 之间均匀分布的哈希值，
+这样每个节点分到的键的比例正好与权重成正比。
+
+不需要虚拟节点，负载天然均匀，代价是每次查找都要计算所有节点的分数，时间复杂度 O(n)，适合节点不多的场景。
+*/
+
+// Rendezvous 最高随机权重哈希
+type Rendezvous struct {
+	hasher hash.XXHashHasher // 计算键和节点的哈希值
+	nodes  map[string]int    // 节点的权重
+	hashes map[string]uint64 // 节点名的哈希值
+	total  int               // 所有节点的权重之和
+	lock   sync.RWMutex      // 并发安全，查找只加读锁
+}
+
+var _ Locator = (*Rendezvous)(nil)
+
+// NewRendezvous 创建最高随机权重哈希
+func NewRendezvous() *Rendezvous {
+	return &Rendezvous{
+		hasher: hash.NewXXHash(0),
+		nodes:  make(map[string]int),
+		hashes: make(map[string]uint64),
+	}
+}
+
+// Len 返回节点数量
+func (r *Rendezvous) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return len(r.nodes)
+}
+
+// AddNode 添加权重为 weight 的节点，返回需要迁移到新节点的键的比例
+func (r *Rendezvous) AddNode(name string, weight int) (float64, error) {
+	if weight <= 0 {
+		return 0, ErrInvalidWeight
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.nodes[name]; ok {
+		return 0, ErrNodeExists
+	}
+	r.nodes[name] = weight
+	r.hashes[name] = r.hasher.Sum64String(name)
+	r.total += weight
+	return float64(weight) / float64(r.total), nil
+}
+
+// RemoveNode 删除节点，返回需要迁移到其他节点的键的比例
+func (r *Rendezvous) RemoveNode(name string) (float64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	weight, ok := r.nodes[name]
+	if !ok {
+		return 0, ErrNodeNotFound
+	}
+	moved := float64(weight) / float64(r.total)
+	delete(r.nodes, name)
+	delete(r.hashes, name)
+	r.total -= weight
+	return moved, nil
+}
+
+// score 键在节点上的分数
+func (r *Rendezvous) score(key uint64, name string) float64 {
+	// 键和节点的哈希值混合后取高 53 位，映射到 (0, 1)
+	h := hashmap.Mix64(key ^ r.hashes[name])
+	u := (float64(h>>11) + 0.5) / (1 << 53)
+	return -float64(r.nodes[name]) / math.Log(u)
+}
+
+// Locate 查找键所属的节点，也就是分数最高的节点，没有节点时返回 false
+func (r *Rendezvous) Locate(key string) (string, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	x := r.hasher.Sum64String(key)
+	var best string
+	bestScore := math.Inf(-1)
+	for name := range r.nodes {
+		s := r.score(x, name)
+		// 分数相同时按节点名比较，保证结果与 map 的遍历顺序无关
+		if s > bestScore || (s == bestScore && name < best) {
+			best, bestScore = name, s
+		}
+	}
+	return best, len(r.nodes) > 0
+}
+
+// LocateN 查找分数最高的 n 个节点，按分数从高到低排列，节点数量不足 n 时返回所有节点
+func (r *Rendezvous) LocateN(key string, n int) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	n = min(n, len(r.nodes))
+	if n <= 0 {
+		return nil
+	}
+	type scored struct {
+		name  string
+		score float64
+	}
+	x := r.hasher.Sum64String(key)
+	list := make([]scored, 0, len(r.nodes))
+	for name := range r.nodes {
+		list = append(list, scored{name, r.score(x, name)})
+	}
+	slices.SortFunc(list, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+	result := make([]string, n)
+	for i := range result {
+		result[i] = list[i].name
+	}
+	return result
+}
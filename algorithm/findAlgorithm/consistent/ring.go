@@ -0,0 +1,224 @@
+package consistent
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/rbtree"
+)
+
+// 一致性哈希环
+/*
+把键分配到 n 个节点上，最简单的做法是 hash(key) % n，但节点数量变化时几乎所有键都要换节点，
+缓存会大面积失效。
+
+一致性哈希把哈希值空间 [0, 2^64) 首尾相连看成一个环，节点也哈希到环上，
+键顺时针找到的第一个节点就是它所属的节点。增加一个节点时只有新节点逆时针方向到上一个节点之间的键会迁移到新节点，
+删除一个节点时只有该节点上的键迁移到顺时针的下一个节点，迁移的比例约为 1/n。
+
+每个节点只放一个点时，各节点分到的弧长差别很大，所以每个节点在环上放多个虚拟节点，
+权重为 w 的节点放 w*replicas 个虚拟节点，虚拟节点越多负载越均匀。
+
+环上的虚拟节点保存在红黑树中，查找键所属的节点就是找大于等于 hash(key) 的最小虚拟节点（Ceiling），
+找不到时回到环的起点（最小的虚拟节点），时间复杂度为 O(log(虚拟节点数量))。
+*/
+
+// 每单位权重默认的虚拟节点数量
+const defaultReplicas = 100
+
+var (
+	// ErrNodeExists 节点已经存在
+	ErrNodeExists = errors.New("consistent: node already exists")
+	// ErrNodeNotFound 节点不存在
+	ErrNodeNotFound = errors.New("consistent: node not found")
+	// ErrInvalidWeight 权重必须大于 0
+	ErrInvalidWeight = errors.New("consistent: weight must be positive")
+)
+
+// Ring 一致性哈希环
+type Ring struct {
+	replicas int                // 每单位权重的虚拟节点数量
+	hasher   hash.XXHashHasher  // 计算键和虚拟节点的哈希值
+	tree     *rbtree.RBTree     // 环上的虚拟节点
+	owners   map[int64]string   // 虚拟节点所属的节点
+	nodes    map[string][]int64 // 节点的虚拟节点
+	weights  map[string]int     // 节点的权重
+	lock     sync.RWMutex       // 并发安全，查找只加读锁
+}
+
+var _ Locator = (*Ring)(nil)
+
+// NewRing 创建一致性哈希环，每单位权重放 replicas 个虚拟节点，replicas 小于等于 0 时使用默认值 100
+func NewRing(replicas int) *Ring {
+	if replicas <= 0 {
+		replicas = defaultReplicas
+	}
+	return &Ring{
+		replicas: replicas,
+		hasher:   hash.NewXXHash(0),
+		tree:     rbtree.NewRBTree(),
+		owners:   make(map[int64]string),
+		nodes:    make(map[string][]int64),
+		weights:  make(map[string]int),
+	}
+}
+
+// point 键在环上的位置，红黑树保存 int64，哈希值按位转换，顺序不影响结果
+func (r *Ring) point(key string) int64 {
+	return int64(r.hasher.Sum64String(key))
+}
+
+// Len 返回节点数量
+func (r *Ring) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return len(r.nodes)
+}
+
+// Nodes 返回所有节点及其权重
+func (r *Ring) Nodes() map[string]int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	nodes := make(map[string]int, len(r.weights))
+	for name, weight := range r.weights {
+		nodes[name] = weight
+	}
+	return nodes
+}
+
+// AddNode 添加权重为 weight 的节点，返回需要迁移到新节点的键的比例
+func (r *Ring) AddNode(name string, weight int) (float64, error) {
+	if weight <= 0 {
+		return 0, ErrInvalidWeight
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.nodes[name]; ok {
+		return 0, ErrNodeExists
+	}
+	points := make([]int64, 0, weight*r.replicas)
+	for i := 0; i < weight*r.replicas; i++ {
+		p := r.point(name + "#" + strconv.Itoa(i))
+		// 与其他虚拟节点的哈希值相同，跳过
+		if _, ok := r.owners[p]; ok {
+			continue
+		}
+		r.tree.Add(p)
+		r.owners[p] = name
+		points = append(points, p)
+	}
+	r.nodes[name] = points
+	r.weights[name] = weight
+	// 迁移的键正好是新节点负责的键
+	return r.share(name), nil
+}
+
+// RemoveNode 删除节点，返回需要迁移到其他节点的键的比例
+func (r *Ring) RemoveNode(name string) (float64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	points, ok := r.nodes[name]
+	if !ok {
+		return 0, ErrNodeNotFound
+	}
+	// 迁移的键正好是该节点原来负责的键
+	moved := r.share(name)
+	for _, p := range points {
+		r.tree.Delete(p)
+		delete(r.owners, p)
+	}
+	delete(r.nodes, name)
+	delete(r.weights, name)
+	return moved, nil
+}
+
+// Locate 查找键所属的节点，环为空时返回 false
+func (r *Ring) Locate(key string) (string, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	node := r.ceiling(r.point(key))
+	if node == nil {
+		return "", false
+	}
+	return r.owners[node.Value], true
+}
+
+// ceiling 顺时针找到的第一个虚拟节点，超过最大的虚拟节点时回到环的起点
+func (r *Ring) ceiling(p int64) *rbtree.RBTNode {
+	if node := r.tree.Ceiling(p); node != nil {
+		return node
+	}
+	return r.tree.FindMinValue()
+}
+
+// LocateN 顺时针查找键所属的 n 个不同节点，用于多副本，第一个与 Locate 相同
+// 节点数量不足 n 时返回所有节点
+func (r *Ring) LocateN(key string, n int) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	n = min(n, len(r.nodes))
+	if n <= 0 {
+		return nil
+	}
+	result := make([]string, 0, n)
+	seen := make(map[string]bool, n)
+	start := r.ceiling(r.point(key))
+	for node := start; len(result) < n; {
+		if owner := r.owners[node.Value]; !seen[owner] {
+			seen[owner] = true
+			result = append(result, owner)
+		}
+		// 顺时针下一个虚拟节点，走到尽头回到起点
+		if node = r.tree.Successor(node); node == nil {
+			node = r.tree.FindMinValue()
+		}
+	}
+	return result
+}
+
+// Shares 返回每个节点负责的哈希值空间的比例，也就是键的期望比例，用于观察负载是否均匀
+func (r *Ring) Shares() map[string]float64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	shares := make(map[string]float64, len(r.nodes))
+	r.walk(func(owner string, arc float64) {
+		shares[owner] += arc
+	})
+	return shares
+}
+
+// share 节点负责的哈希值空间的比例
+func (r *Ring) share(name string) float64 {
+	var share float64
+	r.walk(func(owner string, arc float64) {
+		if owner == name {
+			share += arc
+		}
+	})
+	return share
+}
+
+// walk 遍历环上的每一段弧，弧 (上一个虚拟节点, 虚拟节点] 属于该虚拟节点的节点，arc 为弧长占整个环的比例
+func (r *Ring) walk(fn func(owner string, arc float64)) {
+	first := r.tree.FindMinValue()
+	if first == nil {
+		return
+	}
+	last := r.tree.FindMaxValue()
+	// 第一个虚拟节点负责最后一个虚拟节点之后绕回来的一段
+	// 无符号相减，只有一个虚拟节点时弧长为 0，表示整个环
+	wrap := uint64(first.Value) - uint64(last.Value)
+	if wrap == 0 {
+		fn(r.owners[first.Value], 1)
+		return
+	}
+	fn(r.owners[first.Value], float64(wrap)/math.Exp2(64))
+	prev := first
+	for node := r.tree.Successor(first); node != nil; node = r.tree.Successor(node) {
+		fn(r.owners[node.Value], float64(uint64(node.Value)-uint64(prev.Value))/math.Exp2(64))
+		prev = node
+	}
+}
@@ -1,5 +0,0 @@
-module dataStructAlgorithmOfGo/algorithm/findAlgorithm
-
-go 1.17
-
-require github.com/OneOfOne/xxhash v1.2.8
@@ -0,0 +1,47 @@
+package hash_test
+
+import (
+	"fmt"
+	stdfnv "hash/fnv"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+func ExampleNewSipHash() {
+	// SipHash 论文中的测试向量：密钥为 00 01 02 ... 0f，消息为 00 01 02 ... 0e
+	h := hash.NewSipHash(0x0706050403020100, 0x0f0e0d0c0b0a0908)
+	msg := make([]byte, 15)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	fmt.Printf("%x\n", h.Sum64(nil))
+	fmt.Printf("%x\n", h.Sum64(msg))
+	fmt.Println(h.Sum64(msg) == h.Sum64String(string(msg)))
+	// Output:
+	// 726fdb47dd0e0e31
+	// a129ca6149be45e5
+	// true
+}
+
+func ExampleNew() {
+	// 种子为 0 时与 xxhash、hash/fnv 的结果相同
+	data := []byte("hello")
+	f := stdfnv.New64a()
+	f.Write(data)
+	fmt.Println(hash.New(hash.XXHash, 0).Sum64(data) == xxhash.Checksum64(data))
+	fmt.Println(hash.New(hash.FNV1a, 0).Sum64(data) == f.Sum64())
+
+	// 不同的种子得到不同的哈希值
+	for _, alg := range hash.Algorithms {
+		h1, h2 := hash.New(alg, 1), hash.New(alg, 2)
+		fmt.Println(alg, h1.Sum64String("hello") != h2.Sum64String("hello"))
+	}
+	// Output:
+	// true
+	// true
+	// xxhash true
+	// fnv1a true
+	// maphash true
+	// siphash true
+}
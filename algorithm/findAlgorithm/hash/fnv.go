@@ -0,0 +1,36 @@
+package hash
+
+// FNV-1a：从偏移基数开始，每个字节先异或再乘以 FNV 质数
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// FNV1aHasher 带种子的 FNV-1a 64 位哈希，种子与偏移基数异或，种子为 0 时与 hash/fnv 的 New64a 结果相同
+type FNV1aHasher struct {
+	offset uint64
+}
+
+// NewFNV1a 创建种子为 seed 的 FNV-1a
+func NewFNV1a(seed uint64) FNV1aHasher {
+	return FNV1aHasher{offset: fnvOffset64 ^ seed}
+}
+
+// fnv1a 对字符串和字节切片通用的实现
+func fnv1a[T string | []byte](h uint64, data T) uint64 {
+	for i := 0; i < len(data); i++ {
+		h ^= uint64(data[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// Sum64 计算字节切片的哈希值
+func (h FNV1aHasher) Sum64(data []byte) uint64 {
+	return fnv1a(h.offset, data)
+}
+
+// Sum64String 计算字符串的哈希值
+func (h FNV1aHasher) Sum64String(s string) uint64 {
+	return fnv1a(h.offset, s)
+}
@@ -0,0 +1,76 @@
+// Package hash 提供带种子的 64 位哈希函数：xxhash、FNV-1a、hash/maphash 和 SipHash-2-4
+//
+// 哈希表使用固定的哈希函数时，攻击者可以构造大量哈希冲突的键（哈希洪水攻击），
+// 使哈希表退化为链表。每个哈希表使用随机种子，攻击者无法预先知道哪些键会冲突。
+package hash
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Hasher 带种子的 64 位哈希函数
+// 相同种子的同一个 Hasher 对相同输入总是返回相同的哈希值，Sum64 和 Sum64String 的结果一致
+type Hasher interface {
+	// Sum64 计算字节切片的哈希值
+	Sum64(data []byte) uint64
+	// Sum64String 计算字符串的哈希值，不需要转换为字节切片
+	Sum64String(s string) uint64
+}
+
+// Algorithm 哈希算法
+type Algorithm int
+
+const (
+	XXHash  Algorithm = iota // xxhash64，速度最快
+	FNV1a                    // FNV-1a 64 位，实现简单，分布较差
+	MapHash                  // 标准库 hash/maphash，种子只能随机生成
+	SipHash                  // SipHash-2-4，带密钥的哈希，能抵抗哈希洪水攻击
+)
+
+// Algorithms 所有支持的哈希算法
+var Algorithms = []Algorithm{XXHash, FNV1a, MapHash, SipHash}
+
+// String 算法名称
+func (a Algorithm) String() string {
+	switch a {
+	case XXHash:
+		return "xxhash"
+	case FNV1a:
+		return "fnv1a"
+	case MapHash:
+		return "maphash"
+	case SipHash:
+		return "siphash"
+	}
+	return fmt.Sprintf("Algorithm(%d)", int(a))
+}
+
+// New 使用种子 seed 创建指定算法的 Hasher
+// MapHash 的种子只能由标准库随机生成，seed 会被忽略；SipHash 的 128 位密钥由 seed 扩展得到
+func New(alg Algorithm, seed uint64) Hasher {
+	switch alg {
+	case XXHash:
+		return NewXXHash(seed)
+	case FNV1a:
+		return NewFNV1a(seed)
+	case MapHash:
+		return NewMapHash()
+	case SipHash:
+		return NewSipHash(seed, splitMix64(seed))
+	}
+	panic("hash: unknown algorithm " + alg.String())
+}
+
+// RandomSeed 生成随机种子
+func RandomSeed() uint64 {
+	return rand.Uint64()
+}
+
+// splitMix64 由一个 64 位整数生成另一个不相关的 64 位整数
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,24 @@
+package hash
+
+import "hash/maphash"
+
+// MapHashHasher 标准库 hash/maphash，种子由标准库随机生成，不能指定，
+// 所以同一个键在不同进程中的哈希值不同，不能用于持久化
+type MapHashHasher struct {
+	seed maphash.Seed
+}
+
+// NewMapHash 使用随机种子创建 maphash
+func NewMapHash() MapHashHasher {
+	return MapHashHasher{seed: maphash.MakeSeed()}
+}
+
+// Sum64 计算字节切片的哈希值
+func (h MapHashHasher) Sum64(data []byte) uint64 {
+	return maphash.Bytes(h.seed, data)
+}
+
+// Sum64String 计算字符串的哈希值
+func (h MapHashHasher) Sum64String(s string) uint64 {
+	return maphash.String(h.seed, s)
+}
@@ -0,0 +1,95 @@
+package hash
+
+import "math/bits"
+
+// SipHash-2-4
+/*
+SipHash 是带 128 位密钥的伪随机函数，不知道密钥就无法构造哈希冲突，
+Python、Rust 等语言的哈希表默认使用它来抵抗哈希洪水攻击。
+2-4 表示每 8 个字节的消息块做 2 轮压缩，最后做 4 轮收尾。
+
+初始化：v0..v3 为密钥 k0、k1 与四个常量异或。
+压缩：消息按 8 字节小端序读取为 m，v3 ^= m，做 2 轮 SipRound，v0 ^= m。
+最后一块：剩余字节加上消息长度的低 8 位（放在最高字节）组成 b，同样压缩。
+收尾：v2 ^= 0xff，做 4 轮 SipRound，结果为 v0^v1^v2^v3。
+*/
+
+// SipHasher SipHash-2-4，k0、k1 为 128 位密钥
+type SipHasher struct {
+	k0, k1 uint64
+}
+
+// NewSipHash 使用 128 位密钥（k0 为低 64 位，k1 为高 64 位）创建 SipHash-2-4
+func NewSipHash(k0, k1 uint64) SipHasher {
+	return SipHasher{k0: k0, k1: k1}
+}
+
+// Sum64 计算字节切片的哈希值
+func (h SipHasher) Sum64(data []byte) uint64 {
+	return sipHash24(h.k0, h.k1, data)
+}
+
+// Sum64String 计算字符串的哈希值
+func (h SipHasher) Sum64String(s string) uint64 {
+	return sipHash24(h.k0, h.k1, s)
+}
+
+// load64 小端序读取 8 个字节
+func load64[T string | []byte](p T, i int) uint64 {
+	return uint64(p[i]) | uint64(p[i+1])<<8 | uint64(p[i+2])<<16 | uint64(p[i+3])<<24 |
+		uint64(p[i+4])<<32 | uint64(p[i+5])<<40 | uint64(p[i+6])<<48 | uint64(p[i+7])<<56
+}
+
+// sipRound 一轮 SipRound
+func sipRound(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
+
+// sipHash24 对字符串和字节切片通用的实现
+func sipHash24[T string | []byte](k0, k1 uint64, p T) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	// 压缩完整的 8 字节块
+	n := len(p)
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		m := load64(p, i)
+		v3 ^= m
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+		v0 ^= m
+	}
+	// 最后一块：剩余字节和消息长度
+	b := uint64(n) << 56
+	for j := 0; i+j < n; j++ {
+		b |= uint64(p[i+j]) << (8 * j)
+	}
+	v3 ^= b
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0 ^= b
+
+	// 收尾
+	v2 ^= 0xff
+	for r := 0; r < 4; r++ {
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,23 @@
+package hash
+
+import "github.com/OneOfOne/xxhash"
+
+// XXHashHasher 带种子的 xxhash64，种子为 0 时与 xxhash.Checksum64 结果相同
+type XXHashHasher struct {
+	seed uint64
+}
+
+// NewXXHash 创建种子为 seed 的 xxhash64
+func NewXXHash(seed uint64) XXHashHasher {
+	return XXHashHasher{seed: seed}
+}
+
+// Sum64 计算字节切片的哈希值
+func (h XXHashHasher) Sum64(data []byte) uint64 {
+	return xxhash.Checksum64S(data, h.seed)
+}
+
+// Sum64String 计算字符串的哈希值
+func (h XXHashHasher) Sum64String(s string) uint64 {
+	return xxhash.ChecksumString64S(s, h.seed)
+}
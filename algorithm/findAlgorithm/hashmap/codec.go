@@ -0,0 +1,175 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+)
+
+// Codec 编解码器，用于哈希表快照中键和值的序列化
+type Codec[T any] interface {
+	// Append 将 v 编码后追加到 dst，返回追加后的切片
+	Append(dst []byte, v T) ([]byte, error)
+	// Decode 从 data 解码出一个值，data 是 Append 追加的完整内容
+	Decode(data []byte) (T, error)
+}
+
+// errCodecData 编码数据长度不对
+var errCodecData = errors.New("hashmap: invalid codec data")
+
+// StringCodec 字符串编解码，直接保存字符串的字节
+type StringCodec[T ~string] struct{}
+
+// Append 编码
+func (StringCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	return append(dst, v...), nil
+}
+
+// Decode 解码
+func (StringCodec[T]) Decode(data []byte) (T, error) {
+	return T(data), nil
+}
+
+// BytesCodec 字节切片编解码，解码时会复制一份
+type BytesCodec struct{}
+
+// Append 编码
+func (BytesCodec) Append(dst []byte, v []byte) ([]byte, error) {
+	return append(dst, v...), nil
+}
+
+// Decode 解码
+func (BytesCodec) Decode(data []byte) ([]byte, error) {
+	return bytes.Clone(data), nil
+}
+
+// IntCodec 整数编解码，使用变长编码，有符号整数使用 zigzag 编码
+type IntCodec[T Integer] struct{}
+
+// signed 判断 T 是否为有符号整数
+func signed[T Integer]() bool {
+	var zero T
+	return zero-1 < zero
+}
+
+// Append 编码
+func (IntCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	if signed[T]() {
+		return binary.AppendVarint(dst, int64(v)), nil
+	}
+	return binary.AppendUvarint(dst, uint64(v)), nil
+}
+
+// Decode 解码
+func (IntCodec[T]) Decode(data []byte) (T, error) {
+	if signed[T]() {
+		v, n := binary.Varint(data)
+		if n != len(data) {
+			return 0, errCodecData
+		}
+		return T(v), nil
+	}
+	v, n := binary.Uvarint(data)
+	if n != len(data) {
+		return 0, errCodecData
+	}
+	return T(v), nil
+}
+
+// Float64Codec 浮点数编解码，固定 8 字节
+type Float64Codec struct{}
+
+// Append 编码
+func (Float64Codec) Append(dst []byte, v float64) ([]byte, error) {
+	return binary.LittleEndian.AppendUint64(dst, math.Float64bits(v)), nil
+}
+
+// Decode 解码
+func (Float64Codec) Decode(data []byte) (float64, error) {
+	if len(data) != 8 {
+		return 0, errCodecData
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(data)), nil
+}
+
+// BoolCodec 布尔值编解码，固定 1 字节
+type BoolCodec struct{}
+
+// Append 编码
+func (BoolCodec) Append(dst []byte, v bool) ([]byte, error) {
+	if v {
+		return append(dst, 1), nil
+	}
+	return append(dst, 0), nil
+}
+
+// Decode 解码
+func (BoolCodec) Decode(data []byte) (bool, error) {
+	if len(data) != 1 {
+		return false, errCodecData
+	}
+	return data[0] == 1, nil
+}
+
+// GobCodec 使用 encoding/gob 编解码任意类型
+// 每个值单独编码，都会带上类型信息，体积较大，只作为没有专门编解码器时的默认选择
+type GobCodec[T any] struct{}
+
+// Append 编码
+func (GobCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	if err := gob.NewEncoder(buf).Encode(&v); err != nil {
+		return dst, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode 解码
+func (GobCodec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
+
+// DefaultCodec 根据类型选择默认的编解码器
+// 字符串、字节切片、整数、float64、bool 使用专门的编解码器，其他类型使用 GobCodec
+func DefaultCodec[T any]() Codec[T] {
+	var c any
+	switch any(*new(T)).(type) {
+	case string:
+		c = StringCodec[string]{}
+	case []byte:
+		c = BytesCodec{}
+	case int:
+		c = IntCodec[int]{}
+	case int8:
+		c = IntCodec[int8]{}
+	case int16:
+		c = IntCodec[int16]{}
+	case int32:
+		c = IntCodec[int32]{}
+	case int64:
+		c = IntCodec[int64]{}
+	case uint:
+		c = IntCodec[uint]{}
+	case uint8:
+		c = IntCodec[uint8]{}
+	case uint16:
+		c = IntCodec[uint16]{}
+	case uint32:
+		c = IntCodec[uint32]{}
+	case uint64:
+		c = IntCodec[uint64]{}
+	case uintptr:
+		c = IntCodec[uintptr]{}
+	case float64:
+		c = Float64Codec{}
+	case bool:
+		c = BoolCodec{}
+	default:
+		return GobCodec[T]{}
+	}
+	return c.(Codec[T])
+}
@@ -0,0 +1,180 @@
+package hashmap
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// 分段并发哈希表
+/*
+HashMap 的每个操作（包括 Get）都使用同一把互斥锁，读多写少时所有操作也只能串行执行。
+ConcurrentHashMap 将键按照哈希值的高位分散到 N 个分段（shard）中，每个分段有自己的读写锁：
+	不同分段的操作互不影响，可以并行执行；
+	同一分段的读操作使用读锁，也可以并行执行。
+分段使用哈希值的高位，这样分段内部的哈希表用低位定位下标时不会因为分段而失去均匀性。
+*/
+
+// 默认分段数量
+const defaultShards = 32
+
+// concurrentShard 一个分段
+type concurrentShard[K comparable, V any] struct {
+	items map[K]V      // 分段内的键值对
+	lock  sync.RWMutex // 分段的读写锁
+}
+
+// ConcurrentHashMap 分段并发哈希表
+type ConcurrentHashMap[K comparable, V any] struct {
+	shards    []*concurrentShard[K, V] // 分段数组，长度为 2^shardBits
+	shardBits int                      // 分段数量的二进制位数，取哈希值的高 shardBits 位作为分段下标
+	hasher    Hasher[K]                // 计算键的哈希值，字符串默认使用 xxhash
+}
+
+// NewConcurrentHashMap 创建分段数量为 shards 的并发哈希表，使用 DefaultHasher
+// 分段数量会取整为 2^k，小于等于 0 时使用默认的 32 个分段
+func NewConcurrentHashMap[K comparable, V any](shards int) *ConcurrentHashMap[K, V] {
+	return NewConcurrentHashMapWithHasher[K, V](shards, DefaultHasher[K]())
+}
+
+// NewConcurrentHashMapWithHasher 使用指定的 Hasher 创建并发哈希表
+// 分段内部使用内置 map 存放键值对，hasher 只用来选择分段，所以 hasher.Equal 需要与 == 一致
+func NewConcurrentHashMapWithHasher[K comparable, V any](shards int, hasher Hasher[K]) *ConcurrentHashMap[K, V] {
+	if shards <= 0 {
+		shards = defaultShards
+	}
+	shardBits := bits.Len(uint(shards - 1))
+	m := &ConcurrentHashMap[K, V]{
+		shards:    make([]*concurrentShard[K, V], 1<<shardBits),
+		shardBits: shardBits,
+		hasher:    hasher,
+	}
+	for i := range m.shards {
+		m.shards[i] = &concurrentShard[K, V]{items: make(map[K]V)}
+	}
+	return m
+}
+
+// shard 根据键的哈希值高位选择分段
+func (m *ConcurrentHashMap[K, V]) shard(key K) *concurrentShard[K, V] {
+	if m.shardBits == 0 {
+		return m.shards[0]
+	}
+	return m.shards[m.hasher.Hash(key)>>(64-m.shardBits)]
+}
+
+// Shards 返回分段数量
+func (m *ConcurrentHashMap[K, V]) Shards() int {
+	return len(m.shards)
+}
+
+// Put 添加键值对
+func (m *ConcurrentHashMap[K, V]) Put(key K, value V) {
+	s := m.shard(key)
+	s.lock.Lock()
+	s.items[key] = value
+	s.lock.Unlock()
+}
+
+// Get 获取键值对，只加读锁
+func (m *ConcurrentHashMap[K, V]) Get(key K) (value V, ok bool) {
+	s := m.shard(key)
+	s.lock.RLock()
+	value, ok = s.items[key]
+	s.lock.RUnlock()
+	return
+}
+
+// Delete 删除键值对
+func (m *ConcurrentHashMap[K, V]) Delete(key K) {
+	s := m.shard(key)
+	s.lock.Lock()
+	delete(s.items, key)
+	s.lock.Unlock()
+}
+
+// Len 返回键值对数量，依次统计每个分段，并发修改时只是一个近似值
+func (m *ConcurrentHashMap[K, V]) Len() int {
+	n := 0
+	for _, s := range m.shards {
+		s.lock.RLock()
+		n += len(s.items)
+		s.lock.RUnlock()
+	}
+	return n
+}
+
+// LoadOrStore 键存在时返回已有的值，loaded 为 true；否则存入 value 并返回，loaded 为 false
+// 多个协程同时对同一个键调用时，只有一个能存入成功，其余都会拿到它存入的值
+func (m *ConcurrentHashMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	// 先用读锁查看，键已存在时不用争抢写锁
+	s.lock.RLock()
+	actual, loaded = s.items[key]
+	s.lock.RUnlock()
+	if loaded {
+		return actual, true
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// 获取写锁前可能已经被其他协程存入
+	if actual, loaded = s.items[key]; loaded {
+		return actual, true
+	}
+	s.items[key] = value
+	return value, false
+}
+
+// Compute 在分段的写锁内原子地计算键的新值
+// fn 的参数为旧值以及键是否存在，返回新值以及是否保留，keep 为 false 时删除该键
+// 返回计算后的值以及键是否存在
+// fn 在持有分段写锁时执行，不能再调用该哈希表的方法，否则会死锁
+func (m *ConcurrentHashMap[K, V]) Compute(key K, fn func(old V, exists bool) (value V, keep bool)) (V, bool) {
+	s := m.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	old, exists := s.items[key]
+	value, keep := fn(old, exists)
+	if !keep {
+		delete(s.items, key)
+		var zero V
+		return zero, false
+	}
+	s.items[key] = value
+	return value, true
+}
+
+// Range 遍历所有键值对，fn 返回 false 时停止遍历
+// 按分段依次遍历：在分段的读锁内复制该分段的键值对，释放锁后再调用 fn，
+// 所以每个分段看到的是某一时刻的一致快照，fn 中也可以修改哈希表；
+// 但不同分段的快照时刻不同，整体不是一个一致的快照
+func (m *ConcurrentHashMap[K, V]) Range(fn func(key K, value V) bool) {
+	var keys []K
+	var values []V
+	for _, s := range m.shards {
+		keys, values = keys[:0], values[:0]
+		s.lock.RLock()
+		for k, v := range s.items {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.lock.RUnlock()
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// RangeShard 遍历第 i 个分段，遍历期间持有该分段的读锁，看到的是该分段的一致状态
+// fn 中不能修改哈希表，否则会死锁
+func (m *ConcurrentHashMap[K, V]) RangeShard(i int, fn func(key K, value V) bool) {
+	s := m.shards[i]
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for k, v := range s.items {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
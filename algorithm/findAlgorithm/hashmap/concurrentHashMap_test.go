@@ -0,0 +1,138 @@
+package hashmap
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// 以下测试需要使用 go test -race 运行，检查并发访问是否有数据竞争
+
+func TestConcurrentHashMapParallel(t *testing.T) {
+	m := NewConcurrentHashMap[string, int](16)
+	const goroutines = 32
+	const perGoroutine = 2000
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				key := strconv.Itoa(g) + "-" + strconv.Itoa(i)
+				m.Put(key, i)
+				if v, ok := m.Get(key); !ok || v != i {
+					t.Errorf("Get(%s) = %d, %v", key, v, ok)
+					return
+				}
+				if i%2 == 0 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	// 同时进行遍历和统计
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			m.Range(func(key string, value int) bool { return true })
+			m.Len()
+		}
+	}()
+	wg.Wait()
+	if want := goroutines * perGoroutine / 2; m.Len() != want {
+		t.Fatalf("Len() = %d, want %d", m.Len(), want)
+	}
+}
+
+func TestConcurrentHashMapCompute(t *testing.T) {
+	m := NewConcurrentHashMap[int, int](8)
+	const goroutines = 64
+	const increments = 1000
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				// 10 个计数器，每次原子加 1
+				m.Compute(i%10, func(old int, exists bool) (int, bool) {
+					return old + 1, true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	m.Range(func(key, value int) bool {
+		total += value
+		return true
+	})
+	if total != goroutines*increments {
+		t.Fatalf("total = %d, want %d", total, goroutines*increments)
+	}
+	// keep 为 false 时删除
+	if _, ok := m.Compute(0, func(old int, exists bool) (int, bool) { return 0, false }); ok {
+		t.Fatal("Compute should delete key 0")
+	}
+	if _, ok := m.Get(0); ok {
+		t.Fatal("key 0 should be deleted")
+	}
+}
+
+func TestConcurrentHashMapLoadOrStore(t *testing.T) {
+	m := NewConcurrentHashMap[string, int](4)
+	const goroutines = 64
+	var stored atomic.Int32
+	var wg sync.WaitGroup
+	results := make([]int, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			actual, loaded := m.LoadOrStore("key", g)
+			if !loaded {
+				stored.Add(1)
+			}
+			results[g] = actual
+		}(g)
+	}
+	wg.Wait()
+	// 只有一个协程存入成功，所有协程拿到同一个值
+	if stored.Load() != 1 {
+		t.Fatalf("stored %d times, want 1", stored.Load())
+	}
+	for _, v := range results {
+		if v != results[0] {
+			t.Fatalf("LoadOrStore returned different values: %v", results)
+		}
+	}
+}
+
+func TestConcurrentHashMapRangeStop(t *testing.T) {
+	m := NewConcurrentHashMap[int, int](4)
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	n := 0
+	m.Range(func(key, value int) bool {
+		n++
+		// 遍历时可以修改哈希表
+		m.Delete(key)
+		return n < 10
+	})
+	if n != 10 || m.Len() != 90 {
+		t.Fatalf("visited %d, Len() = %d", n, m.Len())
+	}
+	shardTotal := 0
+	for i := 0; i < m.Shards(); i++ {
+		m.RangeShard(i, func(key, value int) bool {
+			shardTotal++
+			return true
+		})
+	}
+	if shardTotal != 90 {
+		t.Fatalf("RangeShard visited %d, want 90", shardTotal)
+	}
+}
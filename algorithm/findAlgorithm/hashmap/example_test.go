@@ -0,0 +1,306 @@
+package hashmap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+func ExampleXXHash() {
+	keys := []string{"hi", "my", "friend"}
+	for _, key := range keys {
+		fmt.Printf("xxhash('%s') = %d\n", key, hashmap.XXHash([]byte(key)))
+	}
+	// Output:
+	// xxhash('hi') = 16899831174130972922
+	// xxhash('my') = 13223332975333369668
+	// xxhash('friend') = 4642001949237932008
+}
+
+func ExampleHashMap() {
+	// 新建一个哈希表
+	hashMap := hashmap.NewHashMap[string, string](16)
+	// 放35个值
+	for i := 0; i < 35; i++ {
+		hashMap.Put(fmt.Sprintf("%d", i), fmt.Sprintf("v%d", i))
+	}
+	fmt.Println("cap:", hashMap.Cap(), "len:", hashMap.Len())
+
+	key := "4"
+	value, ok := hashMap.Get(key)
+	if ok {
+		fmt.Printf("get '%v'='%v'\n", key, value)
+	} else {
+		fmt.Printf("get %v not found\n", key)
+	}
+	// 删除键
+	hashMap.Delete(key)
+	fmt.Println("after delete cap:", hashMap.Cap(), "len:", hashMap.Len())
+	value, ok = hashMap.Get(key)
+	if ok {
+		fmt.Printf("get '%v'='%v'\n", key, value)
+	} else {
+		fmt.Printf("get %v not found\n", key)
+	}
+	// Output:
+	// cap: 64 len: 35
+	// get '4'='v4'
+	// after delete cap: 64 len: 34
+	// get 4 not found
+}
+
+func ExampleHashMap_int64() {
+	// int64 键默认使用 Mix64 计算哈希值，值为具体类型，不需要类型断言
+	index := hashmap.NewHashMap[int64, []string](16)
+	index.Put(10001, []string{"alice", "bob"})
+	index.Put(10002, []string{"carol"})
+	users, ok := index.Get(10001)
+	fmt.Println(users, ok, len(users))
+	_, ok = index.Get(10003)
+	fmt.Println(ok)
+	// Output:
+	// [alice bob] true 2
+	// false
+}
+
+func ExampleNewHashMapWithHasher() {
+	// 忽略大小写的字符串键
+	hasher := hashmap.NewHasher(
+		func(key string) uint64 { return hashmap.XXHash([]byte(strings.ToLower(key))) },
+		strings.EqualFold,
+	)
+	m := hashmap.NewHashMapWithHasher[string, int](16, hasher)
+	m.Put("Go", 1)
+	m.Put("GO", 2)
+	v, _ := m.Get("go")
+	fmt.Println(m.Len(), v)
+	// Output:
+	// 1 2
+}
+
+func ExampleHashMap_RehashProgress() {
+	m := hashmap.NewHashMap[int, int](16)
+	// 第 12 个键值对使加载因子达到 0.75，开始渐进式扩容
+	for i := 0; i < 12; i++ {
+		m.Put(i, i)
+	}
+	status := m.RehashProgress()
+	fmt.Println(status.Rehashing, status.OldCap, status.NewCap, status.Remaining)
+	// 后台主动迁移，直到扩容完成
+	for m.Rehash(4) {
+	}
+	status = m.RehashProgress()
+	fmt.Println(status.Rehashing, status.OldCap, status.Progress())
+	// Output:
+	// true 16 32 12
+	// false 32 1
+}
+
+func ExampleHashMap_Compact() {
+	m := hashmap.NewHashMapWithOptions[int, int](nil, hashmap.HashMapOptions{
+		MinCapacity:  16,
+		GrowFactor:   0.75,
+		ShrinkFactor: -1, // 不自动缩容
+	})
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	for i := 0; i < 990; i++ {
+		m.Delete(i)
+	}
+	fmt.Println("before:", m.Cap(), m.Len())
+	// 主动缩容
+	m.Compact()
+	fmt.Println("after:", m.Cap(), m.Len())
+	// Output:
+	// before: 2048 10
+	// after: 16 10
+}
+
+func ExampleHashMapOptions() {
+	// 默认缩容因子 0.125，元素减少时容量逐步砍半
+	m := hashmap.NewHashMapWithOptions[int, int](nil, hashmap.HashMapOptions{Capacity: 1024})
+	fmt.Println(m.Cap())
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	for i := 0; i < 96; i++ {
+		m.Delete(i)
+	}
+	// 缩容是渐进式的，主动完成迁移
+	for m.Rehash(100) {
+	}
+	fmt.Println(m.Cap(), m.Len())
+	// Output:
+	// 1024
+	// 16 4
+}
+
+func ExampleConcurrentHashMap() {
+	m := hashmap.NewConcurrentHashMap[string, int](16)
+	// 多个协程同时计数
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Compute("hits", func(old int, exists bool) (int, bool) {
+					return old + 1, true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	hits, _ := m.Get("hits")
+	actual, loaded := m.LoadOrStore("hits", 0)
+	fmt.Println(hits, actual, loaded, m.Shards())
+	// Output:
+	// 800 800 true 16
+}
+
+func ExampleHashMap_All() {
+	m := hashmap.NewHashMap[string, int](16)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	sum := 0
+	for _, v := range m.All() {
+		sum += v
+	}
+	keys := slices.Sorted(m.Keys())
+	fmt.Println(sum, keys)
+	// 提前结束遍历
+	n := 0
+	m.Range(func(key string, value int) bool {
+		n++
+		return false
+	})
+	fmt.Println(n)
+	// Output:
+	// 6 [a b c]
+	// 1
+}
+
+func ExampleHashMap_All_delete() {
+	m := hashmap.NewHashMap[int, int](16)
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	// 遍历时可以删除键值对
+	for k := range m.All() {
+		if k%2 == 0 {
+			m.Delete(k)
+		}
+	}
+	fmt.Println(m.Len())
+	// Output:
+	// 50
+}
+
+func ExampleHashMap_MarshalBinary() {
+	m := hashmap.NewHashMap[string, int](16)
+	for i := 0; i < 1000; i++ {
+		m.Put(fmt.Sprintf("key%d", i), i)
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	// 恢复时直接按保存的容量创建数组
+	restored := hashmap.NewHashMap[string, int](16)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	v, ok := restored.Get("key500")
+	fmt.Println(restored.Len(), restored.Cap(), v, ok)
+
+	// 数据损坏时校验失败，哈希表保持不变
+	data[len(data)-1] ^= 0xFF
+	err = restored.UnmarshalBinary(data)
+	fmt.Println(errors.Is(err, hashmap.ErrSnapshotChecksum), restored.Len())
+	// Output:
+	// 1000 2048 500 true
+	// true 1000
+}
+
+// jsonCodec 使用 JSON 编解码值
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	data, err := json.Marshal(v)
+	return append(dst, data...), err
+}
+
+func (jsonCodec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func ExampleHashMap_WriteTo() {
+	type user struct {
+		Name string
+		Age  int
+	}
+	m := hashmap.NewHashMap[int64, user](16)
+	m.SetCodec(nil, jsonCodec[user]{})
+	m.Put(1, user{"alice", 30})
+	m.Put(2, user{"bob", 25})
+
+	// 流式写入，例如写入文件
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		panic(err)
+	}
+
+	restored := hashmap.NewHashMap[int64, user](16)
+	restored.SetCodec(nil, jsonCodec[user]{})
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		panic(err)
+	}
+	u, _ := restored.Get(2)
+	fmt.Println(restored.Len(), u.Name, u.Age)
+	// Output:
+	// 2 bob 25
+}
+
+func ExampleHashMap_Stats() {
+	// 默认使用随机种子，这里固定种子为 0 使结果可复现
+	m := hashmap.NewHashMapWithHasher[int, int](16, hashmap.IntHasher[int]{})
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	stats := m.Stats()
+	fmt.Println(stats.Len, stats.Buckets, stats.Resizes, stats.Rehashing)
+	fmt.Printf("load=%.3f used=%d\n", stats.LoadFactor, stats.UsedBuckets)
+	// 直方图所有桶之和等于桶的总数
+	total := 0
+	for _, n := range stats.Histogram {
+		total += n
+	}
+	fmt.Println(total == stats.Buckets, len(stats.Histogram) == stats.MaxChain+1)
+	// Output:
+	// 100 384 4 true
+	// load=0.260 used=73
+	// true true
+}
+
+func ExampleNewStringHasher() {
+	// 使用 SipHash 抵抗哈希洪水攻击
+	hasher := hashmap.NewStringHasher[string](hash.New(hash.SipHash, hash.RandomSeed()))
+	m := hashmap.NewHashMapWithHasher[string, int](16, hasher)
+	m.Put("a", 1)
+	v, ok := m.Get("a")
+	fmt.Println(v, ok)
+	// Output:
+	// 1 true
+}
@@ -1,7 +1,6 @@
-package main
+package hashmap
 
 import (
-	"fmt"
 	"github.com/OneOfOne/xxhash"
 )
 
@@ -23,10 +22,3 @@ func XXHash(key []byte) uint64 {
 // 但存在很大哈希值的情况很少发生，大部分哈希值的二进制位数都不会超过 k 位，
 // 因此Golang 使用了这种 2^x 长度作为哈希表的数组长度。
 // 实际上 hash(key) % len 的分布是和 len 有关的，一组均匀分布的 hash(key) 在 len 是素数时才能做到均匀
-
-func main() {
-	keys := []string{"hi", "my", "friend"}
-	for _, key := range keys {
-		fmt.Printf("xxhash('%s') = %d\n", key, XXHash([]byte(key)))
-	}
-}
\ No newline at end of file
@@ -0,0 +1,295 @@
+package hashmap
+
+import (
+	"math"
+	"sync"
+)
+
+// 实现拉链哈希表（链式哈希表）
+// 简单版哈希版本
+/*
+实现拉链哈希表有以下的一些操作：
+
+初始化：新建一个 2^x 个长度的数组，一开始 x 较小。
+添加键值：进行 hash(key) & (2^x-1)，定位到数组下标，查找数组下标对应的链表，如果链表有该键，更新其值，否则追加元素。
+获取键值：进行 hash(key) & (2^x-1)，定位到数组下标，查找数组下标对应的链表，如果链表不存在该键，返回false，否则返回该值以及true。
+删除键值：进行 hash(key) & (2^x-1)，定位到数组下标，查找数组下标对应的链表，如果链表不存在该键，直接返回，否则删除该键。
+
+# 进行键值增删时如果数组容量太大或者太小，需要相应缩容或扩容。
+哈希查找的速度快，主要是利用空间换时间的优点。如果哈希表的数组特别大特别大，那么哈希冲突的几率就会降低。
+然而哈希表中的数组太大或太小都不行，太大浪费了空间，太小则哈希冲突太严重，所以需要对哈希表中的数组进行缩容和扩容。
+
+如何伸缩主要根据哈希表的大小和已添加的元素数量来决定。假设哈希表的大小为 16，已添加到哈希表中的键值对数量是 8，我们称 8/16=0.5 为加载因子 factor。
+可以设定加载因子 factor <= 0.125 时进行数组缩容，每次将容量砍半，当加载因子 factor >= 0.75 进行数组扩容，每次将容量翻倍。
+
+大部分编程语言实现的哈希表只会扩容，不会缩容，因为对于一个经常访问的哈希表来说，缩容后会很快扩容，造成的哈希搬迁成本巨大，
+这个成本比起存储空间的浪费还大。
+但对于长期存在、元素数量先暴涨后回落的哈希表，不缩容会一直占用着扩容后的内存，
+所以这里通过 HashMapOptions 配置扩容和缩容的加载因子以及最小容量，也可以调用 Compact 主动缩容。
+*/
+
+// 扩容因子 0.75 作为扩容因子，只是它刚刚好，其它也可以
+const expandFactor = 0.75
+
+// 缩容因子 0.125，缩容后加载因子为 0.25，离扩容因子足够远，不会马上又扩容
+const shrinkFactor = 0.125
+
+// 默认最小容量 16
+const minCapacity = 1 << 4
+
+// HashMap hash表结构体，K 为键的类型，V 为值的类型
+// 扩容采用渐进式迁移：扩容时新建一个两倍大小的数组，与老数组同时存在，
+// 之后每次增删查都只迁移少量的桶，直到老数组的键值对全部迁移到新数组
+type HashMap[K comparable, V any] struct {
+	tables [2]*hashTable[K, V] // tables[0] 为当前数组，扩容时 tables[1] 为新数组
+	rehashIndex int   // 老数组下一个要迁移的桶下标，-1 表示没有在扩容
+	iterators int     // 正在进行的迭代数量，大于 0 时暂停迁移
+	resizes int       // 扩容和缩容的次数
+	len int			  // 已添加键值对元素数量
+	hasher Hasher[K]  // 计算键的哈希值以及判断键是否相等
+	growFactor float64   // 扩容因子
+	shrinkFactor float64 // 缩容因子，为 0 表示不缩容
+	minCapacity int      // 最小容量
+	keyCodec Codec[K]    // 快照中键的编解码器，为 nil 时使用 DefaultCodec
+	valueCodec Codec[V]  // 快照中值的编解码器，为 nil 时使用 DefaultCodec
+	lock sync.Mutex   // 增删键值对时，需要考虑并发安全
+}
+
+// hashTable 哈希表数组
+type hashTable[K comparable, V any] struct {
+	array []*KeyPairs[K, V] // 哈希表数组，每个元素是一个键值对
+	capacity int	  // 数组容量
+	capacityMask int  // 容量掩码，等于 capacity-1，用来计算数组下标
+	used int          // 该数组中的键值对数量
+}
+
+// KeyPairs 键值对，连成一个链表
+type KeyPairs[K comparable, V any] struct {
+	key K
+	value V
+	netx *KeyPairs[K, V]
+}
+
+// newHashTable 新建容量为 cap 的数组，cap 必须是 2^k
+func newHashTable[K comparable, V any](cap int) *hashTable[K, V] {
+	return &hashTable[K, V]{
+		array:        make([]*KeyPairs[K, V], cap, cap),
+		capacity:     cap,
+		capacityMask: cap - 1,
+	}
+}
+
+//传入 capacity 初始化哈希表数组容量
+//容量掩码 capacityMask = capacity-1 主要用来计算数组下标。
+//传入容量小于默认容量 16，那么将 16 作为哈希表的初始数组大小。
+//否则将第一个大于 capacity 的 2 ^ k 值作为数组的初始大小
+
+// NewHashMap 初始化hash链表，创建大小为capacity的哈希链表
+// 使用 DefaultHasher 计算键的哈希值，每个哈希表使用不同的随机种子
+func NewHashMap[K comparable, V any](cap int) *HashMap[K, V] {
+	return NewHashMapWithHasher[K, V](cap, DefaultHasher[K]())
+}
+
+// NewHashMapWithHasher 使用指定的 Hasher 创建大小为capacity的哈希链表
+func NewHashMapWithHasher[K comparable, V any](cap int, hasher Hasher[K]) *HashMap[K, V] {
+	return NewHashMapWithOptions[K, V](hasher, HashMapOptions{Capacity: cap})
+}
+
+// NewHashMapWithOptions 使用指定的 Hasher 和伸缩策略创建哈希表，hasher 为 nil 时使用 DefaultHasher
+// 伸缩策略不合法时会 panic，见 HashMapOptions
+func NewHashMapWithOptions[K comparable, V any](hasher Hasher[K], opts HashMapOptions) *HashMap[K, V] {
+	if hasher == nil {
+		hasher = DefaultHasher[K]()
+	}
+	opts = opts.normalize()
+	// 初始容量不小于最小容量
+	cap := opts.Capacity
+	if cap < opts.MinCapacity {
+		cap = opts.MinCapacity
+	}
+	// 新建一个哈希表
+	hash := new(HashMap[K, V])
+	hash.tables[0] = newHashTable[K, V](roundCapacity(cap))
+	hash.rehashIndex = -1
+	hash.hasher = hasher
+	hash.growFactor = opts.GrowFactor
+	hash.shrinkFactor = opts.ShrinkFactor
+	hash.minCapacity = roundCapacity(opts.MinCapacity)
+	return hash
+}
+
+// roundCapacity 容量取整，实际大小为不小于 capacity 的第一个 2^k
+func roundCapacity(cap int) int {
+	if cap <= 1 {
+		return 1
+	}
+	return 1 << (int(math.Ceil(math.Log2(float64(cap)))))
+}
+
+// Len 返回哈希表已添加元素的数量
+func (h *HashMap[K, V]) Len() int {
+	return h.len
+}
+
+// Cap 返回哈希表数组的容量，扩容中返回新数组的容量
+func (h *HashMap[K, V]) Cap() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.isRehashing() {
+		return h.tables[1].capacity
+	}
+	return h.tables[0].capacity
+}
+
+//根据公式 hash(key) & (2^x-1)，使用 hasher（字符串默认为 xxhash）来计算键 key 的哈希值，
+//并且和容量掩码 mask 进行 & 求得数组的下标，用来定位键值对该放在数组的哪个下标下
+
+// HashIndex 计算键的哈希值并求出数组下标
+func (h *HashMap[K, V]) HashIndex(key K, mask int) int {
+	// 求hash值
+	hashKey := h.hasher.Hash(key)
+	// 求下标
+	index  := hashKey & uint64(mask)
+	return int(index)
+}
+
+// find 在新老数组中查找键值对，找不到返回 nil
+func (h *HashMap[K, V]) find(key K) *KeyPairs[K, V] {
+	for i := 0; i <= 1; i++ {
+		table := h.tables[i]
+		if table == nil {
+			break
+		}
+		// 遍历链表是否存在元素，存在则返回
+		element := table.array[h.HashIndex(key, table.capacityMask)]
+		for element != nil {
+			if h.hasher.Equal(element.key, key) {
+				return element
+			}
+			element = element.netx
+		}
+		// 没有在扩容，不用查找新数组
+		if !h.isRehashing() {
+			break
+		}
+	}
+	return nil
+}
+
+// Put 添加键值对
+// 哈希表添加键值对,主要操作还是，链表的追加，和遍历，以及扩容
+// 扩容中新的键值对只放到新数组，这样老数组的键值对只会减少
+func (h *HashMap[K, V]) Put(key K, value V) {
+	// 实现并发安全
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	// 扩容中，顺便迁移一部分桶
+	if h.isRehashing() {
+		h.rehash(rehashStepBuckets)
+	}
+	// 键值对存在，更新值并返回
+	if element := h.find(key); element != nil {
+		element.value = value
+		return
+	}
+	// 找不到键值对，将新键值对添加到链表头部
+	table := h.tables[0]
+	if h.isRehashing() {
+		table = h.tables[1]
+	}
+	// 键值对要放的哈希表数组下标
+	index := h.HashIndex(key, table.capacityMask)
+	table.array[index] = &KeyPairs[K, V]{
+		key,
+		value,
+		table.array[index],
+	}
+	table.used++
+	h.len++
+	// 如果超出扩容因子，需要扩容，扩容中不会再次扩容
+	if !h.isRehashing() && float64(h.len) / float64(h.tables[0].capacity) >= h.growFactor {
+		// 新建一个原来2倍大小的数组，之后逐步迁移
+		h.resize(2 * h.tables[0].capacity)
+	}
+}
+
+// Get 获取哈希表键值对
+func (h *HashMap[K, V]) Get(key K) (value V, ok bool) {
+	// 实现并发安全
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	// 扩容中，顺便迁移一部分桶
+	if h.isRehashing() {
+		h.rehash(rehashStepBuckets)
+	}
+	if element := h.find(key); element != nil {
+		return element.value, true
+	}
+	return
+}
+
+// Delete 哈希表删除键值对
+// 删除后加载因子小于等于缩容因子时，将容量砍半，同样逐步迁移
+func (h *HashMap[K, V]) Delete(key K) {
+	// 实现并发安全
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	// 扩容中，顺便迁移一部分桶
+	if h.isRehashing() {
+		h.rehash(rehashStepBuckets)
+	}
+	for i := 0; i <= 1; i++ {
+		table := h.tables[i]
+		if table == nil {
+			return
+		}
+		if h.deleteFrom(table, key) {
+			h.shrinkIfNeeded()
+			return
+		}
+		// 没有在扩容，不用查找新数组
+		if !h.isRehashing() {
+			return
+		}
+	}
+}
+
+// deleteFrom 从数组 table 中删除键值对，删除成功返回 true
+func (h *HashMap[K, V]) deleteFrom(table *hashTable[K, V], key K) bool {
+	// 键值对要存放哈希表数组下标
+	index := h.HashIndex(key, table.capacityMask)
+	// 哈希表数组的下标元素
+	element := table.array[index]
+	// 空链表，不用删除，直接返回
+	if element == nil {
+		return false
+	}
+	// 链表的第一个元素就是需要删除的元素
+	if h.hasher.Equal(element.key, key) {
+		// 将第一个元素后的键值对链上
+		table.array[index] = element.netx
+		table.used--
+		h.len = h.len - 1
+		return true
+	}
+	// 下一个键值对
+	nextElement := element.netx
+	for nextElement != nil {
+		if h.hasher.Equal(nextElement.key, key) {
+			// 键值对匹配到，将该键值对从链中去掉,将下一跳的下一跳往前推一位连接上
+			element.netx = nextElement.netx
+			table.used--
+			h.len = h.len -1
+			return true
+		}
+		element = nextElement
+		nextElement = nextElement.netx
+	}
+	return false
+}
+
+// 总结
+// 哈希表查找，是一种用空间换时间的查找算法，时间复杂度能达到：O(1)，
+// 最坏情况下退化到查找链表：O(n)。但均匀性很好的哈希算法以及合适空间大小的数组，
+// 在很大概率避免了最坏情况。
+// 哈希表在添加元素时会进行伸缩，会造成较大的性能消耗，所以有时候会用到其他的查找算法：树查找算法
\ No newline at end of file
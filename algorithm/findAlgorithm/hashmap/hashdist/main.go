@@ -0,0 +1,128 @@
+// hashdist 比较不同哈希函数在哈希表中的分布情况
+//
+// 用法：
+//
+//	hashdist [-buckets n] [-zero-seed] [keys.txt]
+//
+// 从文件（省略时从标准输入）按行读取键，分别使用 xxhash、FNV-1a、maphash、SipHash 作为哈希函数
+// 放入桶数固定的 HashMap，输出加载因子、非空桶比例、链表长度以及卡方检验结果。
+// 分布均匀时非空桶比例接近 1-e^(-λ)，卡方值除以自由度接近 1，λ 为加载因子。
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+func main() {
+	buckets := flag.Int("buckets", 0, "桶的数量，取整为 2^k，默认为不小于键数量的 2^k")
+	fixedSeed := flag.Bool("zero-seed", false, "使用种子 0，结果可复现（maphash 除外）")
+	flag.Parse()
+
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+	keys, err := readKeys(r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(keys) == 0 {
+		fmt.Fprintln(os.Stderr, "no keys")
+		os.Exit(1)
+	}
+	if *buckets <= 0 {
+		*buckets = len(keys)
+	}
+
+	seed := hash.RandomSeed()
+	if *fixedSeed {
+		seed = 0
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "hash\tkeys\tbuckets\tload\toccupancy\texpected\tmax chain\tmean chain\tchi2/df\tns/key\thistogram")
+	for _, alg := range hash.Algorithms {
+		hasher := hashmap.NewStringHasher[string](hash.New(alg, seed))
+		stats, elapsed := fill(keys, *buckets, hasher)
+		lambda := stats.LoadFactor
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.3f\t%.4f\t%.4f\t%d\t%.3f\t%.3f\t%.1f\t%s\n",
+			alg, stats.Len, stats.Buckets, lambda, stats.Occupancy, 1-math.Exp(-lambda),
+			stats.MaxChain, stats.MeanChain, chiSquare(stats), float64(elapsed.Nanoseconds())/float64(len(keys)),
+			formatHistogram(stats.Histogram))
+	}
+	w.Flush()
+}
+
+// readKeys 按行读取键，忽略空行，重复的键只保留一个
+func readKeys(r io.Reader) ([]string, error) {
+	seen := make(map[string]struct{})
+	var keys []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		key := scanner.Text()
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, scanner.Err()
+}
+
+// fill 将所有键放入桶数固定的哈希表，返回统计信息和耗时
+func fill(keys []string, buckets int, hasher hashmap.Hasher[string]) (hashmap.Stats, time.Duration) {
+	m := hashmap.NewHashMapWithOptions[string, struct{}](hasher, hashmap.HashMapOptions{
+		Capacity:     buckets,
+		MinCapacity:  buckets,
+		GrowFactor:   math.MaxFloat64, // 不扩容，桶数固定
+		ShrinkFactor: -1,
+	})
+	start := time.Now()
+	for _, key := range keys {
+		m.Put(key, struct{}{})
+	}
+	return m.Stats(), time.Since(start)
+}
+
+// chiSquare 卡方检验，每个桶的期望键数为加载因子 λ，返回卡方值除以自由度
+func chiSquare(stats hashmap.Stats) float64 {
+	lambda := stats.LoadFactor
+	chi := 0.0
+	for chain, count := range stats.Histogram {
+		d := float64(chain) - lambda
+		chi += float64(count) * d * d / lambda
+	}
+	return chi / float64(stats.Buckets-1)
+}
+
+// formatHistogram 输出链表长度分布，例如 0:368 1:368 2:184
+func formatHistogram(histogram []int) string {
+	parts := make([]string, 0, len(histogram))
+	for chain, count := range histogram {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("%d:%d", chain, count))
+		}
+	}
+	return strings.Join(parts, " ")
+}
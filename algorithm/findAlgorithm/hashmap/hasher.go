@@ -0,0 +1,171 @@
+package hashmap
+
+import (
+	"hash/maphash"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// Hasher 哈希函数接口，负责计算键的哈希值以及判断两个键是否相等
+// 哈希值相等的两个键不一定相等，所以还需要 Equal 做最终判断
+// 自定义 Hasher 时必须保证 Equal(a, b) 为 true 时 Hash(a) == Hash(b)
+type Hasher[K comparable] interface {
+	Hash(key K) uint64
+	Equal(a, b K) bool
+}
+
+// funcHasher 由函数构造的 Hasher
+type funcHasher[K comparable] struct {
+	hash  func(key K) uint64
+	equal func(a, b K) bool
+}
+
+func (f funcHasher[K]) Hash(key K) uint64 {
+	return f.hash(key)
+}
+
+func (f funcHasher[K]) Equal(a, b K) bool {
+	return f.equal(a, b)
+}
+
+// NewHasher 使用哈希函数和相等函数构造 Hasher，equal 为 nil 时使用 == 比较
+func NewHasher[K comparable](hash func(key K) uint64, equal func(a, b K) bool) Hasher[K] {
+	if equal == nil {
+		equal = func(a, b K) bool { return a == b }
+	}
+	return funcHasher[K]{hash: hash, equal: equal}
+}
+
+// StringHasher 字符串键使用带种子的 xxhash 计算哈希值
+// []byte 不能作为键，可以转为 string 后使用
+type StringHasher[K ~string] struct {
+	Seed uint64 // 种子，为 0 时结果与 XXHash([]byte(key)) 相同
+}
+
+// Hash 计算字符串的 xxhash 值
+func (h StringHasher[K]) Hash(key K) uint64 {
+	return xxhash.ChecksumString64S(string(key), h.Seed)
+}
+
+// Equal 判断两个键是否相等
+func (StringHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+// Integer 整数类型约束
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// IntHasher 整数键使用位混合函数计算哈希值，不需要转为字节切片
+// 连续的整数直接取低位作为下标会集中在一起，混合后每一位都受所有输入位影响
+type IntHasher[K Integer] struct {
+	Seed uint64 // 种子，与键异或后再混合
+}
+
+// Hash 计算整数的哈希值
+func (h IntHasher[K]) Hash(key K) uint64 {
+	return Mix64(uint64(key) ^ h.Seed)
+}
+
+// Equal 判断两个键是否相等
+func (IntHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+// Mix64 64 位整数混合函数（MurmurHash3 的 fmix64）
+// 两次乘法和三次异或移位，使输入的每一位都会影响输出的每一位
+func Mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// stringHasher 使用 hash 包中的哈希算法计算字符串键的哈希值
+type stringHasher[K ~string] struct {
+	h hash.Hasher
+}
+
+func (s stringHasher[K]) Hash(key K) uint64 {
+	return s.h.Sum64String(string(key))
+}
+
+func (stringHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+// NewStringHasher 使用 hash 包中的哈希算法（xxhash、FNV-1a、maphash、SipHash）作为字符串键的 Hasher
+// 例如 NewStringHasher[string](hash.New(hash.SipHash, hash.RandomSeed()))
+func NewStringHasher[K ~string](h hash.Hasher) Hasher[K] {
+	return stringHasher[K]{h: h}
+}
+
+// comparableSeed 其他可比较类型共用的 maphash 种子，不同的哈希函数由 mix 区分
+var comparableSeed = maphash.MakeSeed()
+
+// comparableHasher 其他可比较类型使用标准库 hash/maphash 计算哈希值
+// mix 不为 0 时再与 maphash 的结果混合，使同一个 maphash.Seed 也能派生出不同的哈希函数
+type comparableHasher[K comparable] struct {
+	seed maphash.Seed
+	mix  uint64
+}
+
+func (c comparableHasher[K]) Hash(key K) uint64 {
+	if c.mix == 0 {
+		return maphash.Comparable(c.seed, key)
+	}
+	return Mix64(maphash.Comparable(c.seed, key) ^ c.mix)
+}
+
+func (comparableHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+// DefaultHasher 根据键的类型选择默认的 Hasher，每次调用都使用新的随机种子
+// 字符串使用 xxhash，整数使用 Mix64，其他可比较类型使用 hash/maphash
+// 只在创建时判断一次类型，之后的哈希计算不会有装箱
+// 每个哈希表的种子不同，无法预先构造出大量冲突的键；需要可复现的结果时使用种子为 0 的 StringHasher、IntHasher
+func DefaultHasher[K comparable]() Hasher[K] {
+	return SeededHasher[K](hash.RandomSeed())
+}
+
+// SeededHasher 与 DefaultHasher 一样根据键的类型选择 Hasher，但使用指定的种子
+// 同一个种子得到的哈希函数相同，不同的种子得到相互独立的哈希函数，用于需要多个哈希函数的结构，比如布谷鸟哈希
+// 其他可比较类型的 maphash.Seed 仍然是随机的，同一个 Hasher 只在当前进程内可复现
+func SeededHasher[K comparable](seed uint64) Hasher[K] {
+	var h any
+	switch any(*new(K)).(type) {
+	case string:
+		h = StringHasher[string]{Seed: seed}
+	case int:
+		h = IntHasher[int]{Seed: seed}
+	case int8:
+		h = IntHasher[int8]{Seed: seed}
+	case int16:
+		h = IntHasher[int16]{Seed: seed}
+	case int32:
+		h = IntHasher[int32]{Seed: seed}
+	case int64:
+		h = IntHasher[int64]{Seed: seed}
+	case uint:
+		h = IntHasher[uint]{Seed: seed}
+	case uint8:
+		h = IntHasher[uint8]{Seed: seed}
+	case uint16:
+		h = IntHasher[uint16]{Seed: seed}
+	case uint32:
+		h = IntHasher[uint32]{Seed: seed}
+	case uint64:
+		h = IntHasher[uint64]{Seed: seed}
+	case uintptr:
+		h = IntHasher[uintptr]{Seed: seed}
+	default:
+		return comparableHasher[K]{seed: comparableSeed, mix: seed}
+	}
+	return h.(Hasher[K])
+}
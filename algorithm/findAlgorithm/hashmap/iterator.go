@@ -0,0 +1,88 @@
+package hashmap
+
+import "iter"
+
+// 哈希表迭代
+/*
+迭代按桶进行：每次加锁复制一个桶的键值对，释放锁后再交给调用方，
+所以迭代过程中可以调用 Put/Get/Delete 修改哈希表，不会死锁。
+
+迭代期间暂停渐进式迁移（参考 Redis 的安全迭代器），键值对不会在新老数组之间移动，
+正在扩容时先遍历老数组再遍历新数组。迭代期间修改哈希表的语义如下：
+	迭代期间一直存在的键恰好访问一次，值为访问到该键所在的桶时的值；
+	迭代期间删除的键，如果所在的桶还没有访问到，就不会被访问；
+	迭代期间新增的键，可能访问也可能不访问；
+	迭代期间触发的扩容或缩容只会新建数组，迁移等到迭代结束后再进行。
+迭代结束（包括提前 break）后恢复迁移。
+*/
+
+// All 返回遍历所有键值对的迭代器，可以使用 for k, v := range h.All() 遍历
+func (h *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h.lock.Lock()
+		h.iterators++
+		h.lock.Unlock()
+		defer func() {
+			h.lock.Lock()
+			h.iterators--
+			h.lock.Unlock()
+		}()
+
+		var keys []K
+		var values []V
+		for t := 0; t < len(h.tables); t++ {
+			for i := 0; ; i++ {
+				keys, values = keys[:0], values[:0]
+				h.lock.Lock()
+				table := h.tables[t]
+				// 该数组遍历完毕，或者没有在扩容
+				if table == nil || i >= table.capacity {
+					h.lock.Unlock()
+					break
+				}
+				// 复制一个桶的键值对
+				for pairs := table.array[i]; pairs != nil; pairs = pairs.netx {
+					keys = append(keys, pairs.key)
+					values = append(values, pairs.value)
+				}
+				h.lock.Unlock()
+				for j := range keys {
+					if !yield(keys[j], values[j]) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Keys 返回遍历所有键的迭代器
+func (h *HashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range h.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values 返回遍历所有值的迭代器
+func (h *HashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range h.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Range 遍历所有键值对，fn 返回 false 时停止遍历，语义与 All 相同
+func (h *HashMap[K, V]) Range(fn func(key K, value V) bool) {
+	for k, v := range h.All() {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package hashmap
+
+// Map 哈希表的通用接口
+// 链式哈希表 HashMap 以及 openaddr 包中的开放寻址哈希表都实现了该接口，可以互相替换
+type Map[K comparable, V any] interface {
+	// Put 添加键值对，键已存在时更新值
+	Put(key K, value V)
+	// Get 获取键对应的值，不存在时 ok 为 false
+	Get(key K) (value V, ok bool)
+	// Delete 删除键值对，不存在时不做处理
+	Delete(key K)
+	// Len 已添加键值对的数量
+	Len() int
+	// Cap 哈希表数组的容量
+	Cap() int
+}
+
+var _ Map[string, int] = (*HashMap[string, int])(nil)
@@ -0,0 +1,85 @@
+package hashmap
+
+// HashMapOptions 哈希表的伸缩策略，字段为 0 时使用默认值
+/*
+加载因子 factor = len/capacity
+factor >= GrowFactor 时扩容，容量翻倍
+factor <= ShrinkFactor 时缩容，容量砍半，但不会小于 MinCapacity
+缩容后加载因子变为原来的两倍，所以要求 ShrinkFactor*2 < GrowFactor，否则缩容后马上又会扩容
+*/
+type HashMapOptions struct {
+	Capacity     int     // 初始容量，默认为 MinCapacity
+	MinCapacity  int     // 最小容量，默认 16，会取整为 2^k
+	GrowFactor   float64 // 扩容因子，默认 0.75
+	ShrinkFactor float64 // 缩容因子，默认 0.125，小于 0 表示不缩容
+}
+
+// normalize 填充默认值并检查是否合法，不合法时 panic
+func (o HashMapOptions) normalize() HashMapOptions {
+	if o.MinCapacity <= 0 {
+		o.MinCapacity = minCapacity
+	}
+	if o.GrowFactor == 0 {
+		o.GrowFactor = expandFactor
+	}
+	if o.ShrinkFactor == 0 {
+		o.ShrinkFactor = shrinkFactor
+	} else if o.ShrinkFactor < 0 {
+		o.ShrinkFactor = 0
+	}
+	if o.GrowFactor < 0 {
+		panic("hashmap: GrowFactor must be positive")
+	}
+	if o.ShrinkFactor*2 >= o.GrowFactor {
+		panic("hashmap: ShrinkFactor must be less than half of GrowFactor")
+	}
+	return o
+}
+
+// resize 开始渐进式迁移到容量为 cap 的新数组，正在迁移时不做处理
+func (h *HashMap[K, V]) resize(cap int) {
+	if h.isRehashing() || cap == h.tables[0].capacity {
+		return
+	}
+	h.tables[1] = newHashTable[K, V](cap)
+	h.rehashIndex = 0
+	h.resizes++
+}
+
+// shrinkIfNeeded 加载因子小于等于缩容因子时，容量砍半
+func (h *HashMap[K, V]) shrinkIfNeeded() {
+	if h.shrinkFactor == 0 || h.isRehashing() {
+		return
+	}
+	capacity := h.tables[0].capacity
+	if capacity <= h.minCapacity {
+		return
+	}
+	if float64(h.len)/float64(capacity) <= h.shrinkFactor {
+		h.resize(capacity / 2)
+	}
+}
+
+// Compact 主动缩容，将数组容量调整为能容纳当前元素而不触发扩容的最小 2^k，且不小于最小容量
+// 会先完成正在进行的迁移，然后一次性迁移到新数组，元素很多时耗时较长
+// 有迭代正在进行时不能迁移，直接返回
+func (h *HashMap[K, V]) Compact() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.iterators > 0 {
+		return
+	}
+	// 完成正在进行的迁移
+	for h.rehash(rehashBatchBuckets) {
+	}
+	capacity := h.minCapacity
+	for float64(h.len)/float64(capacity) >= h.growFactor {
+		capacity *= 2
+	}
+	if capacity >= h.tables[0].capacity {
+		return
+	}
+	h.resize(capacity)
+	for h.rehash(rehashBatchBuckets) {
+	}
+}
@@ -0,0 +1,135 @@
+package hashmap
+
+import "time"
+
+// 渐进式扩容（参考 Redis 的 dict）
+/*
+一次性扩容需要把所有键值对重新哈希到新数组，哈希表很大时会造成明显的停顿。
+渐进式扩容把迁移工作分摊到之后的每次操作中：
+
+扩容时新建一个两倍大小的数组 tables[1]，rehashIndex 从 0 开始。
+每次 Put/Get/Delete 时，从 rehashIndex 开始迁移 rehashStepBuckets 个非空桶到新数组。
+扩容期间查找和删除需要同时查看新老两个数组，新增的键值对只放入新数组。
+老数组的键值对全部迁移完后，新数组替换老数组，rehashIndex 置为 -1。
+
+迁移时遇到空桶不计入迁移数量，但最多只访问 10 倍迁移数量的空桶，避免一次操作耗时过长。
+也可以由后台协程调用 Rehash 或 RehashFor 主动迁移，加快扩容的完成。
+有迭代正在进行时暂停迁移（参考 Redis 的安全迭代器），见 iterator.go。
+*/
+
+// 每次增删查时迁移的桶数量
+const rehashStepBuckets = 1
+
+// 后台迁移时每批迁移的桶数量
+const rehashBatchBuckets = 100
+
+// isRehashing 是否正在扩容
+func (h *HashMap[K, V]) isRehashing() bool {
+	return h.rehashIndex != -1
+}
+
+// rehash 迁移最多 n 个非空桶，返回是否还在扩容中
+// 缩容同样使用 rehash 迁移，只是新数组比老数组小
+func (h *HashMap[K, V]) rehash(n int) bool {
+	// 有正在进行的迭代时暂停迁移，保证迭代期间键值对不会在新老数组之间移动
+	if !h.isRehashing() || h.iterators > 0 {
+		return h.isRehashing()
+	}
+	// 最多访问的空桶数量
+	emptyVisits := n * 10
+	oldTable, newTable := h.tables[0], h.tables[1]
+	for ; n > 0 && oldTable.used > 0; n-- {
+		// 跳过空桶
+		for oldTable.array[h.rehashIndex] == nil {
+			h.rehashIndex++
+			emptyVisits--
+			if emptyVisits == 0 {
+				return true
+			}
+		}
+		// 将整条链表迁移到新数组
+		element := oldTable.array[h.rehashIndex]
+		for element != nil {
+			next := element.netx
+			index := h.HashIndex(element.key, newTable.capacityMask)
+			element.netx = newTable.array[index]
+			newTable.array[index] = element
+			oldTable.used--
+			newTable.used++
+			element = next
+		}
+		oldTable.array[h.rehashIndex] = nil
+		h.rehashIndex++
+	}
+	// 老数组已经迁移完，新数组替换老数组
+	if oldTable.used == 0 {
+		h.tables[0] = newTable
+		h.tables[1] = nil
+		h.rehashIndex = -1
+		// 元素数量下降很多时，继续缩容
+		h.shrinkIfNeeded()
+		return h.isRehashing()
+	}
+	return true
+}
+
+// Rehash 主动迁移最多 n 个非空桶，返回是否还在扩容中
+// 可以由后台协程周期性调用，不在扩容中时直接返回 false
+func (h *HashMap[K, V]) Rehash(n int) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.rehash(n)
+}
+
+// RehashFor 在 d 时间内持续迁移，每批迁移 rehashBatchBuckets 个桶，返回迁移的批数
+// 每批之间会释放锁，不会长时间阻塞其他操作；有迭代正在进行时迁移暂停，直接返回
+func (h *HashMap[K, V]) RehashFor(d time.Duration) int {
+	start := time.Now()
+	batches := 0
+	for {
+		h.lock.Lock()
+		if !h.isRehashing() || h.iterators > 0 {
+			h.lock.Unlock()
+			return batches
+		}
+		rehashing := h.rehash(rehashBatchBuckets)
+		h.lock.Unlock()
+		batches++
+		if !rehashing || time.Since(start) > d {
+			return batches
+		}
+	}
+}
+
+// RehashStatus 扩容进度
+type RehashStatus struct {
+	Rehashing bool // 是否正在扩容
+	OldCap    int  // 老数组容量
+	NewCap    int  // 新数组容量，没有扩容时为 0
+	Migrated  int  // 老数组已经迁移的桶数量
+	Remaining int  // 老数组中还未迁移的键值对数量
+}
+
+// Progress 扩容完成的比例，按已迁移的桶计算，没有扩容时为 1
+func (s RehashStatus) Progress() float64 {
+	if !s.Rehashing || s.OldCap == 0 {
+		return 1
+	}
+	return float64(s.Migrated) / float64(s.OldCap)
+}
+
+// RehashProgress 获取当前的扩容进度，用于监控
+func (h *HashMap[K, V]) RehashProgress() RehashStatus {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	status := RehashStatus{
+		Rehashing: h.isRehashing(),
+		OldCap:    h.tables[0].capacity,
+	}
+	if status.Rehashing {
+		status.NewCap = h.tables[1].capacity
+		status.Migrated = h.rehashIndex
+		status.Remaining = h.tables[0].used
+	}
+	return status
+}
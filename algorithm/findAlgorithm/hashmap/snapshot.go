@@ -0,0 +1,360 @@
+package hashmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/OneOfOne/xxhash"
+)
+
+// 哈希表快照
+/*
+快照格式（整数均为小端序，uvarint 为变长无符号整数）：
+	magic     4 字节 "GHMP"
+	version   1 字节，当前为 1
+	capacity  uvarint，保存时的数组容量，恢复时按它一次性创建数组
+	count     uvarint，键值对数量
+	entries   count 个键值对，每个为：键长度 uvarint、键、值长度 uvarint、值
+	checksum  8 字节，前面所有内容的 xxhash64
+
+键和值的编码由 Codec 决定，可以通过 SetCodec 替换。
+
+校验和在最后才能检查，读取时不能相信头部中的数字：损坏或伪造的快照可以声明 2^40 个键值对、1GB 的键。
+所以读取时先把键值对放在切片中，切片和键、值都随着实际读到的数据增长；
+全部读完后再按保存的容量创建数组，容量不小于容纳这些键值对需要的容量，也不超过快照的字节数，
+伪造的容量最多让数组占用快照大小 8 倍的内存（每个桶一个指针）。
+*/
+
+// 快照魔数和版本
+const (
+	snapshotMagic   = "GHMP"
+	snapshotVersion = 1
+)
+
+var (
+	// ErrInvalidSnapshot 快照格式错误
+	ErrInvalidSnapshot = errors.New("hashmap: invalid snapshot")
+	// ErrSnapshotVersion 不支持的快照版本
+	ErrSnapshotVersion = errors.New("hashmap: unsupported snapshot version")
+	// ErrSnapshotChecksum 快照校验和不匹配，数据已损坏
+	ErrSnapshotChecksum = errors.New("hashmap: snapshot checksum mismatch")
+	// ErrSnapshotIterating 有迭代正在进行，不能恢复快照
+	ErrSnapshotIterating = errors.New("hashmap: cannot restore snapshot during iteration")
+)
+
+// SetCodec 设置快照中键和值的编解码器，为 nil 时使用 DefaultCodec
+func (h *HashMap[K, V]) SetCodec(keyCodec Codec[K], valueCodec Codec[V]) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.keyCodec = keyCodec
+	h.valueCodec = valueCodec
+}
+
+// codecs 获取键和值的编解码器
+func (h *HashMap[K, V]) codecs() (Codec[K], Codec[V]) {
+	keyCodec, valueCodec := h.keyCodec, h.valueCodec
+	if keyCodec == nil {
+		keyCodec = DefaultCodec[K]()
+	}
+	if valueCodec == nil {
+		valueCodec = DefaultCodec[V]()
+	}
+	return keyCodec, valueCodec
+}
+
+// MarshalBinary 将哈希表编码为快照，实现 encoding.BinaryMarshaler
+func (h *HashMap[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := h.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary 从快照恢复哈希表，实现 encoding.BinaryUnmarshaler
+// 快照之后不能有多余的数据
+func (h *HashMap[K, V]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := h.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidSnapshot, r.Len())
+	}
+	return nil
+}
+
+// countingWriter 统计写入的字节数并计算校验和
+type countingWriter struct {
+	w   io.Writer
+	sum hash.Hash64
+	n   int64
+	buf []byte
+}
+
+// write 写入数据，同时更新校验和
+func (c *countingWriter) write(p []byte) error {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.sum.Write(p[:n])
+	return err
+}
+
+// writeUvarint 写入变长整数
+func (c *countingWriter) writeUvarint(v uint64) error {
+	c.buf = binary.AppendUvarint(c.buf[:0], v)
+	return c.write(c.buf)
+}
+
+// WriteTo 将快照写入 w，实现 io.WriterTo
+// 写入期间持有哈希表的锁，保证快照的一致性
+func (h *HashMap[K, V]) WriteTo(w io.Writer) (int64, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	keyCodec, valueCodec := h.codecs()
+
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw, sum: xxhash.New64()}
+	// 头部
+	header := append([]byte(snapshotMagic), snapshotVersion)
+	if err := cw.write(header); err != nil {
+		return cw.n, err
+	}
+	capacity := h.tables[0].capacity
+	if h.isRehashing() {
+		capacity = h.tables[1].capacity
+	}
+	if err := cw.writeUvarint(uint64(capacity)); err != nil {
+		return cw.n, err
+	}
+	if err := cw.writeUvarint(uint64(h.len)); err != nil {
+		return cw.n, err
+	}
+	// 键值对，扩容中新老数组都要写入
+	var data []byte
+	for _, table := range h.tables {
+		if table == nil {
+			continue
+		}
+		for _, pairs := range table.array {
+			for ; pairs != nil; pairs = pairs.netx {
+				var err error
+				if data, err = keyCodec.Append(data[:0], pairs.key); err != nil {
+					return cw.n, fmt.Errorf("hashmap: encode key: %w", err)
+				}
+				if err = cw.writeUvarint(uint64(len(data))); err != nil {
+					return cw.n, err
+				}
+				if err = cw.write(data); err != nil {
+					return cw.n, err
+				}
+				if data, err = valueCodec.Append(data[:0], pairs.value); err != nil {
+					return cw.n, fmt.Errorf("hashmap: encode value: %w", err)
+				}
+				if err = cw.writeUvarint(uint64(len(data))); err != nil {
+					return cw.n, err
+				}
+				if err = cw.write(data); err != nil {
+					return cw.n, err
+				}
+			}
+		}
+	}
+	// 校验和不计入校验
+	sum := binary.LittleEndian.AppendUint64(nil, cw.sum.Sum64())
+	n, err := bw.Write(sum)
+	cw.n += int64(n)
+	if err != nil {
+		return cw.n, err
+	}
+	return cw.n, bw.Flush()
+}
+
+// countingReader 统计读取的字节数并计算校验和
+type countingReader struct {
+	r   io.ByteReader
+	rd  io.Reader
+	sum hash.Hash64
+	n   int64
+}
+
+// ReadByte 实现 io.ByteReader，供 binary.ReadUvarint 使用
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+		c.sum.Write([]byte{b})
+	}
+	return b, err
+}
+
+// read 读取 len(p) 个字节
+func (c *countingReader) read(p []byte) error {
+	n, err := io.ReadFull(c.rd, p)
+	c.n += int64(n)
+	c.sum.Write(p[:n])
+	return err
+}
+
+// readLen 读取长度，长度不能超过 limit
+func (c *countingReader) readLen(limit uint64) (int, error) {
+	v, err := binary.ReadUvarint(c)
+	if err != nil {
+		return 0, err
+	}
+	if v > limit {
+		return 0, fmt.Errorf("%w: length %d too large", ErrInvalidSnapshot, v)
+	}
+	return int(v), nil
+}
+
+// 读取快照的限制
+const (
+	maxEntrySize     = 1 << 30 // 单个键或值编码后的最大长度
+	snapshotPrealloc = 1 << 16 // 预先分配的切片最多容纳的键值对数量
+	snapshotChunk    = 1 << 16 // 读取键和值时每次分配的字节数
+)
+
+// readBytes 读取 n 个字节，复用 b 的空间
+// n 较大时按块读取，数据不足时不会分配 n 个字节
+func (c *countingReader) readBytes(b []byte, n int) ([]byte, error) {
+	b = b[:0]
+	for len(b) < n {
+		m := min(n-len(b), max(snapshotChunk, len(b)))
+		b = growBytes(b, len(b)+m)
+		if err := c.read(b[len(b)-m:]); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+// ReadFrom 从 r 读取快照并替换哈希表的内容，实现 io.ReaderFrom
+// 按照快照中保存的容量一次性创建数组，恢复过程中不会扩容；读取失败或校验和不匹配时哈希表保持不变
+// 有迭代正在进行时不能替换数组，返回 ErrSnapshotIterating
+// r 没有实现 io.ByteReader 时会使用 bufio.Reader，可能会多读取快照之后的数据
+func (h *HashMap[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.iterators > 0 {
+		return 0, ErrSnapshotIterating
+	}
+	keyCodec, valueCodec := h.codecs()
+
+	cr := &countingReader{sum: xxhash.New64()}
+	if br, ok := r.(interface {
+		io.Reader
+		io.ByteReader
+	}); ok {
+		cr.r, cr.rd = br, br
+	} else {
+		br := bufio.NewReader(r)
+		cr.r, cr.rd = br, br
+	}
+	// 头部
+	header := make([]byte, len(snapshotMagic)+1)
+	if err := cr.read(header); err != nil {
+		return cr.n, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return cr.n, fmt.Errorf("%w: bad magic", ErrInvalidSnapshot)
+	}
+	if header[len(snapshotMagic)] != snapshotVersion {
+		return cr.n, fmt.Errorf("%w: %d", ErrSnapshotVersion, header[len(snapshotMagic)])
+	}
+	capacity, err := cr.readLen(1 << 40)
+	if err != nil {
+		return cr.n, fmt.Errorf("%w: capacity: %v", ErrInvalidSnapshot, err)
+	}
+	if capacity == 0 || capacity&(capacity-1) != 0 {
+		return cr.n, fmt.Errorf("%w: capacity %d is not a power of 2", ErrInvalidSnapshot, capacity)
+	}
+	count, err := cr.readLen(1 << 40)
+	if err != nil {
+		return cr.n, fmt.Errorf("%w: count: %v", ErrInvalidSnapshot, err)
+	}
+	// 键值对，数量不可信，切片随着读到的键值对增长
+	entries := make([]*KeyPairs[K, V], 0, min(count, snapshotPrealloc))
+	var data []byte
+	for i := 0; i < count; i++ {
+		size, err := cr.readLen(maxEntrySize)
+		if err != nil {
+			return cr.n, fmt.Errorf("%w: entry %d: %v", ErrInvalidSnapshot, i, err)
+		}
+		if data, err = cr.readBytes(data, size); err != nil {
+			return cr.n, fmt.Errorf("%w: entry %d: %v", ErrInvalidSnapshot, i, err)
+		}
+		key, err := keyCodec.Decode(data)
+		if err != nil {
+			return cr.n, fmt.Errorf("hashmap: decode key %d: %w", i, err)
+		}
+		if size, err = cr.readLen(maxEntrySize); err != nil {
+			return cr.n, fmt.Errorf("%w: entry %d: %v", ErrInvalidSnapshot, i, err)
+		}
+		if data, err = cr.readBytes(data, size); err != nil {
+			return cr.n, fmt.Errorf("%w: entry %d: %v", ErrInvalidSnapshot, i, err)
+		}
+		value, err := valueCodec.Decode(data)
+		if err != nil {
+			return cr.n, fmt.Errorf("hashmap: decode value %d: %w", i, err)
+		}
+		entries = append(entries, &KeyPairs[K, V]{key: key, value: value})
+	}
+	// 校验和
+	want := cr.sum.Sum64()
+	sum := make([]byte, 8)
+	if _, err := io.ReadFull(cr.rd, sum); err != nil {
+		return cr.n, fmt.Errorf("%w: checksum: %v", ErrInvalidSnapshot, err)
+	}
+	cr.n += 8
+	if binary.LittleEndian.Uint64(sum) != want {
+		return cr.n, ErrSnapshotChecksum
+	}
+	// 按保存的容量一次性创建数组，插入时不会扩容
+	table := newHashTable[K, V](h.snapshotCapacity(capacity, count, cr.n))
+	for i, pairs := range entries {
+		index := h.HashIndex(pairs.key, table.capacityMask)
+		for p := table.array[index]; p != nil; p = p.netx {
+			if h.hasher.Equal(p.key, pairs.key) {
+				return cr.n, fmt.Errorf("%w: entry %d: duplicate key", ErrInvalidSnapshot, i)
+			}
+		}
+		pairs.netx = table.array[index]
+		table.array[index] = pairs
+		table.used++
+	}
+	// 全部读取成功，替换哈希表内容，没有迭代正在进行，直接丢弃迁移状态
+	h.tables[0] = table
+	h.tables[1] = nil
+	h.rehashIndex = -1
+	h.len = len(entries)
+	return cr.n, nil
+}
+
+// snapshotCapacity 返回恢复快照时数组的容量，saved 为快照中保存的容量，size 为快照的字节数
+// 不小于最小容量和容纳 count 个键值对而不触发扩容的容量；
+// 也不超过 size，保存的容量更大时（比如不缩容的哈希表删除了大部分键值对）取不超过 size 的最大 2^k
+func (h *HashMap[K, V]) snapshotCapacity(saved, count int, size int64) int {
+	need := h.minCapacity
+	for float64(count)/float64(need) >= h.growFactor {
+		need *= 2
+	}
+	capacity := saved
+	for capacity > need && int64(capacity) > size {
+		capacity /= 2
+	}
+	return max(capacity, need)
+}
+
+// growBytes 返回长度为 n 的切片，保留 b 原有的数据，容量足够时复用 b
+func growBytes(b []byte, n int) []byte {
+	if cap(b) < n {
+		return append(b[:cap(b)], make([]byte, n-cap(b))...)[:n]
+	}
+	return b[:n]
+}
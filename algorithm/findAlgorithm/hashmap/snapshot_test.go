@@ -0,0 +1,164 @@
+package hashmap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"testing"
+
+	"github.com/OneOfOne/xxhash"
+)
+
+// snapshotOf 创建包含 n 个键值对的哈希表和它的快照
+func snapshotOf(t *testing.T, n int) (*HashMap[string, int], []byte) {
+	t.Helper()
+	h := NewHashMap[string, int](0)
+	for i := 0; i < n; i++ {
+		h.Put(fmt.Sprint("key", i), i)
+	}
+	data, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h, data
+}
+
+// craftSnapshot 按快照格式拼出校验和正确的快照，第 i 个键的值为 i
+func craftSnapshot(capacity int, keys ...string) []byte {
+	data := append([]byte(snapshotMagic), snapshotVersion)
+	data = binary.AppendUvarint(data, uint64(capacity))
+	data = binary.AppendUvarint(data, uint64(len(keys)))
+	for i, k := range keys {
+		data = binary.AppendUvarint(data, uint64(len(k)))
+		data = append(data, k...)
+		v, _ := DefaultCodec[int]().Append(nil, i)
+		data = binary.AppendUvarint(data, uint64(len(v)))
+		data = append(data, v...)
+	}
+	return binary.LittleEndian.AppendUint64(data, xxhash.Checksum64(data))
+}
+
+// 键值对数量超过预先分配的数量，恢复后数组容量与保存时相同
+func TestSnapshotRoundTrip(t *testing.T) {
+	h, data := snapshotOf(t, snapshotPrealloc+1000)
+	restored := NewHashMap[string, int](0)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Len() != h.Len() {
+		t.Fatalf("Len = %d, want %d", restored.Len(), h.Len())
+	}
+	for k, v := range h.All() {
+		if got, ok := restored.Get(k); !ok || got != v {
+			t.Fatalf("Get(%q) = %d, %v, want %d", k, got, ok, v)
+		}
+	}
+	if restored.Cap() != h.Cap() {
+		t.Fatalf("Cap = %d, want %d", restored.Cap(), h.Cap())
+	}
+}
+
+// 保存的容量必须是 2^k，不小于键值对需要的容量，也不超过快照的字节数
+func TestSnapshotCapacity(t *testing.T) {
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprint("key", i)
+	}
+	large := craftSnapshot(1<<40, keys...)
+	tests := []struct {
+		name     string
+		data     []byte
+		capacity int
+	}{
+		{"saved", craftSnapshot(256, keys...), 256},
+		{"too small for count", craftSnapshot(16, keys...), 256},
+		{"larger than snapshot", large, 1 << (bits.Len(uint(len(large))) - 1)},
+		{"empty", craftSnapshot(1 << 40), minCapacity},
+	}
+	for _, tt := range tests {
+		h := NewHashMap[string, int](0)
+		if err := h.UnmarshalBinary(tt.data); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if h.Cap() != tt.capacity {
+			t.Errorf("%s: Cap = %d, want %d", tt.name, h.Cap(), tt.capacity)
+		}
+	}
+	h := NewHashMap[string, int](0)
+	if err := h.UnmarshalBinary(craftSnapshot(1000, keys...)); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("capacity 1000: %v", err)
+	}
+}
+
+// 重复的键不能让 Len 与实际的键值对数量不一致
+func TestSnapshotDuplicateKey(t *testing.T) {
+	h := NewHashMap[string, int](0)
+	h.Put("old", 1)
+	if err := h.UnmarshalBinary(craftSnapshot(16, "a", "b", "a")); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("duplicate key: %v", err)
+	}
+	if v, ok := h.Get("old"); h.Len() != 1 || !ok || v != 1 {
+		t.Fatal("hash map changed")
+	}
+}
+
+// 迭代期间不能替换数组
+func TestSnapshotDuringIteration(t *testing.T) {
+	h, data := snapshotOf(t, 10)
+	for range h.All() {
+		if err := h.UnmarshalBinary(data); !errors.Is(err, ErrSnapshotIterating) {
+			t.Fatalf("restore during iteration: %v", err)
+		}
+	}
+	if err := h.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 截断、伪造的头部和损坏的数据都返回错误，哈希表保持不变
+func TestSnapshotCorrupt(t *testing.T) {
+	_, data := snapshotOf(t, 50)
+	h := NewHashMap[string, int](0)
+	h.Put("old", 1)
+	unchanged := func(what string) {
+		t.Helper()
+		if v, ok := h.Get("old"); h.Len() != 1 || !ok || v != 1 {
+			t.Fatalf("%s: hash map changed", what)
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		if err := h.UnmarshalBinary(data[:i]); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Fatalf("truncated at %d: %v", i, err)
+		}
+		unchanged("truncated")
+	}
+
+	// 头部声明了巨大的容量和数量，或者巨大的键，但后面没有数据，不能按声明的大小分配内存
+	header := append([]byte(snapshotMagic), snapshotVersion)
+	huge := binary.AppendUvarint(binary.AppendUvarint(header, 1<<40), 1<<40)
+	for _, bad := range [][]byte{huge, binary.AppendUvarint(huge, maxEntrySize)} {
+		if err := h.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Fatalf("huge header: %v", err)
+		}
+		unchanged("huge header")
+	}
+
+	// 修改头部之后的任意一个字节，都不能被当作合法的快照
+	for i := len(header); i < len(data); i++ {
+		corrupt := append([]byte(nil), data...)
+		corrupt[i] ^= 0x40
+		if err := h.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("flipped byte %d accepted", i)
+		}
+		unchanged("flipped byte")
+	}
+	// 只修改值的内容时，长度都没有变，由校验和发现
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-9] ^= 0x01
+	if err := h.UnmarshalBinary(corrupt); !errors.Is(err, ErrSnapshotChecksum) {
+		t.Fatalf("flipped payload byte: %v", err)
+	}
+	unchanged("flipped payload byte")
+}
@@ -0,0 +1,58 @@
+package hashmap
+
+// Stats 哈希表的统计信息，用来观察哈希函数的分布是否均匀
+// 正在扩容时新老两个数组的桶一起统计
+type Stats struct {
+	Len         int     // 键值对数量
+	Buckets     int     // 桶的总数，即数组容量之和
+	LoadFactor  float64 // 加载因子 Len/Buckets
+	UsedBuckets int     // 非空桶数量
+	Occupancy   float64 // 非空桶比例 UsedBuckets/Buckets
+	MaxChain    int     // 最长链表长度
+	MeanChain   float64 // 非空桶的平均链表长度
+	Histogram   []int   // 链表长度分布，Histogram[i] 为链表长度为 i 的桶数量
+	Resizes     int     // 扩容和缩容的次数
+	Rehashing   bool    // 是否正在扩容
+}
+
+// Stats 统计哈希表的桶使用情况，需要遍历整个数组，时间复杂度为：O(n)
+// 哈希函数分布均匀时，链表长度近似服从均值为加载因子的泊松分布
+func (h *HashMap[K, V]) Stats() Stats {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	stats := Stats{
+		Len:       h.len,
+		Resizes:   h.resizes,
+		Rehashing: h.isRehashing(),
+	}
+	for _, table := range h.tables {
+		if table == nil {
+			continue
+		}
+		stats.Buckets += table.capacity
+		for _, pairs := range table.array {
+			chain := 0
+			for ; pairs != nil; pairs = pairs.netx {
+				chain++
+			}
+			for len(stats.Histogram) <= chain {
+				stats.Histogram = append(stats.Histogram, 0)
+			}
+			stats.Histogram[chain]++
+			if chain > 0 {
+				stats.UsedBuckets++
+			}
+			if chain > stats.MaxChain {
+				stats.MaxChain = chain
+			}
+		}
+	}
+	if stats.Buckets > 0 {
+		stats.LoadFactor = float64(stats.Len) / float64(stats.Buckets)
+		stats.Occupancy = float64(stats.UsedBuckets) / float64(stats.Buckets)
+	}
+	if stats.UsedBuckets > 0 {
+		stats.MeanChain = float64(stats.Len) / float64(stats.UsedBuckets)
+	}
+	return stats
+}
@@ -1,4 +1,4 @@
-package main
+package llrb
 
 /*
 数与左倾红黑树
@@ -1,4 +1,4 @@
-package main
+package llrb
 
 import (
 	"fmt"
@@ -459,7 +459,7 @@ func (node *LLRBTNode) MidOrder() {
 	// 先打印左子树
 	node.Left.MidOrder()
 	// 按次数打印根节点
-	for i := 0; i < int(node.Times); i++ {
+	for i := 0; i <= int(node.Times); i++ {
 		fmt.Println(node.Value)
 	}
 	// 打印右子树
@@ -573,59 +573,6 @@ func (node *LLRBTNode) IsBalanced(blackNum int) bool {
 	return true
 }
 
-// 验证ces
-func main() {
-	tree := NewLLRBTree()
-	values := []int64{2, 3, 7, 10, 10, 10, 10, 23, 9, 102, 109, 111, 112, 113}
-	for _, v := range values {
-		tree.Add(v)
-	}
-
-	// 找到最大值或最小值的节点
-	fmt.Println("find min value:", tree.FindMinValue())
-	fmt.Println("find max value:", tree.FindMaxValue())
-
-	// 查找不存在的99
-	node := tree.Find(99)
-	if node != nil {
-		fmt.Println("find it 99!")
-	} else {
-		fmt.Println("not find it 99!")
-	}
-
-	// 查找存在的9
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	tree.MidOrder()
-
-	// 删除存在的9后，再查找9
-	tree.Delete(9)
-	tree.Delete(10)
-	tree.Delete(2)
-	tree.Delete(3)
-	tree.Add(4)
-	tree.Add(3)
-	tree.Add(10)
-	tree.Delete(111)
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	if tree.IsLLRBTree() {
-		fmt.Println("is a llrb tree")
-	} else {
-		fmt.Println("is not llrb tree")
-	}
-}
-
 /*
 程序是递归程序，如果改写为非递归形式，效率和性能会更好，
 在此就不实现了，理解左倾红黑树添加和删除的总体思路即可
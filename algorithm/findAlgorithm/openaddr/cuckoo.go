@@ -0,0 +1,361 @@
+package openaddr
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 布谷鸟哈希表
+/*
+布谷鸟哈希使用 d 个相互独立的哈希函数（默认 2 个，由不同的种子派生），每个键只能存放在这 d 个候选桶中，
+每个桶有 4 个槽位。查找时最多查看 d 个桶以及一个很小的储藏区（stash），最坏情况也是 O(1)，
+适合读多写少、对读延迟敏感的场景。
+
+插入时如果候选桶都满了，就像布谷鸟占巢一样随机踢出候选桶中的一个键值对，
+被踢出的键值对再放到它自己的其他候选桶中，如此反复，直到找到空位或者踢出次数达到上限。
+达到上限说明很可能出现了环，最后无家可归的键值对放到储藏区；储藏区也满了就换一组新的种子重建哈希表，
+连续重建失败时容量翻倍。
+
+4 槽位的桶让 2 个哈希函数的布谷鸟哈希加载因子可以达到 90% 以上，而单槽位只能达到 50% 左右。
+*/
+
+// 每个桶的槽位数量
+const cuckooSlots = 4
+
+// 布谷鸟哈希表的扩容因子
+const cuckooFactor = 0.9
+
+// 默认配置
+const (
+	defaultCuckooHashes    = 2   // 哈希函数数量
+	defaultCuckooStashSize = 4   // 储藏区大小
+	defaultCuckooMaxKicks  = 500 // 每次插入最多踢出的次数
+)
+
+// 同一容量下换种子重建的次数，超过后容量翻倍
+const cuckooRetries = 2
+
+// CuckooOptions 布谷鸟哈希表的配置，零值字段使用默认值
+type CuckooOptions struct {
+	Capacity  int // 初始容量（槽位数量）
+	Hashes    int // 哈希函数数量，至少为 2，默认 2
+	StashSize int // 储藏区大小，默认 4
+	MaxKicks  int // 每次插入最多踢出的次数，默认 500
+}
+
+// normalize 填充默认值
+func (o CuckooOptions) normalize() CuckooOptions {
+	if o.Hashes < 2 {
+		o.Hashes = defaultCuckooHashes
+	}
+	if o.StashSize <= 0 {
+		o.StashSize = defaultCuckooStashSize
+	}
+	if o.MaxKicks <= 0 {
+		o.MaxKicks = defaultCuckooMaxKicks
+	}
+	return o
+}
+
+// cuckooEntry 键值对
+type cuckooEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// cuckooBucket 桶，used 的第 i 位表示第 i 个槽位是否存放了键值对
+type cuckooBucket[K comparable, V any] struct {
+	entries [cuckooSlots]cuckooEntry[K, V]
+	used    uint8
+}
+
+// free 返回第一个空槽位，桶满时返回 -1
+func (b *cuckooBucket[K, V]) free() int {
+	for i := 0; i < cuckooSlots; i++ {
+		if b.used&(1<<i) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// CuckooMap 布谷鸟哈希表
+type CuckooMap[K comparable, V any] struct {
+	buckets    []cuckooBucket[K, V]                // 桶数组
+	bucketMask int                                 // 桶数量掩码，等于 len(buckets)-1
+	hashers    []hashmap.Hasher[K]                 // d 个哈希函数
+	newHasher  func(seed uint64) hashmap.Hasher[K] // 根据种子派生哈希函数
+	stash      []cuckooEntry[K, V]                 // 储藏区，存放插入失败的键值对
+	stashSize  int                                 // 储藏区大小
+	stashLimit int                                 // 储藏区当前上限，重建失败时会临时放宽
+	maxKicks   int                                 // 每次插入最多踢出的次数
+	len        int                                 // 已添加键值对数量
+	rehashes   int                                 // 换种子重建的次数
+	rng        *rand.Rand                          // 生成种子以及选择踢出的槽位
+	lock       sync.RWMutex                        // 读多写少，查找只加读锁
+}
+
+var _ hashmap.Map[string, int] = (*CuckooMap[string, int])(nil)
+
+// NewCuckooMap 创建容量为 cap 的布谷鸟哈希表
+// 哈希函数由 hashmap.SeededHasher 使用不同的种子派生，字符串键即为不同种子的 xxhash
+func NewCuckooMap[K comparable, V any](cap int) *CuckooMap[K, V] {
+	return NewCuckooMapWithOptions[K, V](nil, CuckooOptions{Capacity: cap})
+}
+
+// NewCuckooMapWithHasher 使用指定的 Hasher 创建布谷鸟哈希表
+// 各个哈希函数为 Mix64(hasher.Hash(key) ^ seed)，hasher 完全冲突的键无法靠换种子分开，只能放在储藏区
+func NewCuckooMapWithHasher[K comparable, V any](cap int, hasher hashmap.Hasher[K]) *CuckooMap[K, V] {
+	newHasher := func(seed uint64) hashmap.Hasher[K] {
+		return hashmap.NewHasher(func(key K) uint64 {
+			return hashmap.Mix64(hasher.Hash(key) ^ seed)
+		}, hasher.Equal)
+	}
+	return NewCuckooMapWithOptions[K, V](newHasher, CuckooOptions{Capacity: cap})
+}
+
+// NewCuckooMapWithOptions 使用指定的配置创建布谷鸟哈希表
+// newHasher 根据种子返回哈希函数，为 nil 时使用 hashmap.SeededHasher
+func NewCuckooMapWithOptions[K comparable, V any](newHasher func(seed uint64) hashmap.Hasher[K], opts CuckooOptions) *CuckooMap[K, V] {
+	if newHasher == nil {
+		newHasher = hashmap.SeededHasher[K]
+	}
+	opts = opts.normalize()
+	m := &CuckooMap[K, V]{
+		hashers:   make([]hashmap.Hasher[K], opts.Hashes),
+		newHasher: newHasher,
+		stashSize: opts.StashSize,
+		maxKicks:  opts.MaxKicks,
+		rng:       rand.New(rand.NewPCG(hash.RandomSeed(), hash.RandomSeed())),
+	}
+	m.init(roundCapacity(opts.Capacity) / cuckooSlots)
+	return m
+}
+
+// init 初始化 n 个空桶，并换一组新的种子
+func (m *CuckooMap[K, V]) init(n int) {
+	m.buckets = make([]cuckooBucket[K, V], n)
+	m.bucketMask = n - 1
+	for i := range m.hashers {
+		m.hashers[i] = m.newHasher(m.rng.Uint64())
+	}
+	m.stash = m.stash[:0]
+	m.stashLimit = m.stashSize
+	m.len = 0
+}
+
+// Len 返回已添加键值对数量
+func (m *CuckooMap[K, V]) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.len
+}
+
+// Cap 返回槽位数量，不包括储藏区
+func (m *CuckooMap[K, V]) Cap() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.buckets) * cuckooSlots
+}
+
+// StashLen 返回储藏区中键值对的数量
+func (m *CuckooMap[K, V]) StashLen() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.stash)
+}
+
+// Rehashes 返回换种子重建的次数，不包括正常的扩容
+func (m *CuckooMap[K, V]) Rehashes() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.rehashes
+}
+
+// index 第 i 个哈希函数对应的桶下标
+func (m *CuckooMap[K, V]) index(i int, key K) int {
+	return int(m.hashers[i].Hash(key)) & m.bucketMask
+}
+
+// find 查找键值对，最多查看 d 个桶以及储藏区，找不到返回 nil
+func (m *CuckooMap[K, V]) find(key K) *cuckooEntry[K, V] {
+	equal := m.hashers[0].Equal
+	for i := range m.hashers {
+		b := &m.buckets[m.index(i, key)]
+		for slot := 0; slot < cuckooSlots; slot++ {
+			if b.used&(1<<slot) != 0 && equal(b.entries[slot].key, key) {
+				return &b.entries[slot]
+			}
+		}
+	}
+	for i := range m.stash {
+		if equal(m.stash[i].key, key) {
+			return &m.stash[i]
+		}
+	}
+	return nil
+}
+
+// Put 添加键值对
+func (m *CuckooMap[K, V]) Put(key K, value V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	// 键值对存在，更新值并返回
+	if entry := m.find(key); entry != nil {
+		entry.value = value
+		return
+	}
+	// 超出扩容因子，容量翻倍
+	if float64(m.len+1) > cuckooFactor*float64(len(m.buckets)*cuckooSlots) {
+		m.resize(2*len(m.buckets), false)
+	}
+	m.add(cuckooEntry[K, V]{key, value})
+}
+
+// add 添加一个不存在的键值对，插入失败时放到储藏区，储藏区满了则重建
+func (m *CuckooMap[K, V]) add(entry cuckooEntry[K, V]) {
+	homeless, ok := m.place(entry)
+	m.len++
+	if ok {
+		return
+	}
+	if len(m.stash) < m.stashLimit {
+		m.stash = append(m.stash, homeless)
+		return
+	}
+	// 储藏区满了，说明当前种子下出现了环，换种子重建
+	m.stash = append(m.stash, homeless)
+	m.resize(len(m.buckets), true)
+}
+
+// place 将键值对放入候选桶，候选桶都满时随机踢出一个键值对，
+// 被踢出的键值对再放到它的候选桶中，成功返回 true，失败返回最后无家可归的键值对
+func (m *CuckooMap[K, V]) place(entry cuckooEntry[K, V]) (cuckooEntry[K, V], bool) {
+	for kick := 0; kick <= m.maxKicks; kick++ {
+		// 候选桶中有空槽位，直接放入
+		for i := range m.hashers {
+			b := &m.buckets[m.index(i, entry.key)]
+			if slot := b.free(); slot >= 0 {
+				b.entries[slot] = entry
+				b.used |= 1 << slot
+				return entry, true
+			}
+		}
+		// 候选桶都满了，随机踢出一个键值对，占据它的位置
+		b := &m.buckets[m.index(m.rng.IntN(len(m.hashers)), entry.key)]
+		slot := m.rng.IntN(cuckooSlots)
+		entry, b.entries[slot] = b.entries[slot], entry
+	}
+	return entry, false
+}
+
+// resize 重建为 n 个桶，rehash 为 true 表示容量不够以外的原因（出现了环）
+// 重建时换一组新的种子，储藏区放不下时再次换种子，同一容量失败 cuckooRetries 次后容量翻倍
+func (m *CuckooMap[K, V]) resize(n int, rehash bool) {
+	if rehash {
+		m.rehashes++
+	}
+	// 取出所有键值对
+	entries := make([]cuckooEntry[K, V], 0, m.len)
+	for i := range m.buckets {
+		b := &m.buckets[i]
+		for slot := 0; slot < cuckooSlots; slot++ {
+			if b.used&(1<<slot) != 0 {
+				entries = append(entries, b.entries[slot])
+			}
+		}
+	}
+	entries = append(entries, m.stash...)
+	for attempt := 1; attempt <= 2*cuckooRetries; attempt++ {
+		m.init(n << ((attempt - 1) / cuckooRetries))
+		if m.rebuild(entries) {
+			return
+		}
+		m.rehashes++
+	}
+	// hasher 完全冲突的键换种子、扩容都分不开，多次失败后按原来的容量重建，并放宽储藏区的上限
+	m.init(n)
+	m.rebuild(entries)
+	m.stashLimit = len(m.stash) * 2
+}
+
+// rebuild 将键值对重新放入空哈希表，储藏区放不下时返回 false，此时哈希表中仍有全部键值对
+func (m *CuckooMap[K, V]) rebuild(entries []cuckooEntry[K, V]) bool {
+	ok := true
+	for _, entry := range entries {
+		homeless, placed := m.place(entry)
+		m.len++
+		if !placed {
+			m.stash = append(m.stash, homeless)
+			if len(m.stash) > m.stashSize {
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// Get 获取键值对，最多查看 d 个桶以及储藏区
+func (m *CuckooMap[K, V]) Get(key K) (value V, ok bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if entry := m.find(key); entry != nil {
+		return entry.value, true
+	}
+	return
+}
+
+// Delete 删除键值对，删除后尝试把储藏区的键值对放回桶中
+func (m *CuckooMap[K, V]) Delete(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	equal := m.hashers[0].Equal
+	for i := range m.hashers {
+		b := &m.buckets[m.index(i, key)]
+		for slot := 0; slot < cuckooSlots; slot++ {
+			if b.used&(1<<slot) != 0 && equal(b.entries[slot].key, key) {
+				// 清空槽位，避免键值对中的指针无法被回收
+				b.entries[slot] = cuckooEntry[K, V]{}
+				b.used &^= 1 << slot
+				m.len--
+				m.drainStash()
+				return
+			}
+		}
+	}
+	for i := range m.stash {
+		if equal(m.stash[i].key, key) {
+			m.removeStash(i)
+			m.len--
+			return
+		}
+	}
+}
+
+// drainStash 把储藏区中候选桶有空槽位的键值对放回桶中，不踢出其他键值对
+func (m *CuckooMap[K, V]) drainStash() {
+	for i := 0; i < len(m.stash); i++ {
+		entry := m.stash[i]
+		for h := range m.hashers {
+			b := &m.buckets[m.index(h, entry.key)]
+			if slot := b.free(); slot >= 0 {
+				b.entries[slot] = entry
+				b.used |= 1 << slot
+				m.removeStash(i)
+				i--
+				break
+			}
+		}
+	}
+}
+
+// removeStash 删除储藏区的第 i 个键值对
+func (m *CuckooMap[K, V]) removeStash(i int) {
+	last := len(m.stash) - 1
+	m.stash[i] = m.stash[last]
+	m.stash[last] = cuckooEntry[K, V]{}
+	m.stash = m.stash[:last]
+}
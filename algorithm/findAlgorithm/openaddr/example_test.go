@@ -0,0 +1,61 @@
+package openaddr_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/openaddr"
+)
+
+func Example() {
+	// 几种哈希表都实现了 hashmap.Map，可以互相替换
+	maps := []hashmap.Map[string, int]{
+		hashmap.NewHashMap[string, int](16),
+		openaddr.NewLinearProbingMap[string, int](16),
+		openaddr.NewRobinHoodMap[string, int](16),
+		openaddr.NewSwissMap[string, int](16),
+		openaddr.NewCuckooMap[string, int](16),
+	}
+	for _, m := range maps {
+		for i := 0; i < 100; i++ {
+			m.Put(fmt.Sprintf("key%d", i), i)
+		}
+		m.Delete("key7")
+		v, ok := m.Get("key42")
+		_, ok7 := m.Get("key7")
+		fmt.Println(m.Len(), v, ok, ok7)
+	}
+	// Output:
+	// 99 42 true false
+	// 99 42 true false
+	// 99 42 true false
+	// 99 42 true false
+	// 99 42 true false
+}
+
+func ExampleRobinHoodMap() {
+	m := openaddr.NewRobinHoodMap[int, string](16)
+	m.Put(1, "one")
+	m.Put(2, "two")
+	m.Put(1, "uno")
+	m.Delete(2)
+	v, ok := m.Get(1)
+	fmt.Println(m.Len(), m.Cap(), v, ok)
+	// Output:
+	// 1 16 uno true
+}
+
+func ExampleCuckooMap() {
+	// 3 个哈希函数，加载因子可以更高
+	m := openaddr.NewCuckooMapWithOptions[int, string](nil, openaddr.CuckooOptions{Capacity: 16, Hashes: 3})
+	for i := 0; i < 10; i++ {
+		m.Put(i, fmt.Sprint("v", i))
+	}
+	m.Put(3, "three")
+	m.Delete(4)
+	v, ok := m.Get(3)
+	_, ok4 := m.Get(4)
+	fmt.Println(m.Len(), v, ok, ok4, m.Cap())
+	// Output:
+	// 9 three true false 16
+}
@@ -0,0 +1,192 @@
+package openaddr
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 开放寻址哈希表
+/*
+链式哈希表每个键值对都是一个单独分配的链表节点，指针多，遍历链表时缓存不友好。
+开放寻址法把键值对直接存放在数组中，发生冲突时按照一定的规则在数组中寻找下一个空位：
+
+线性探测：冲突时依次查看下一个位置 index+1, index+2 ...，直到找到空位。
+删除时不能直接置空，否则会切断后面元素的探测路径，所以要标记为墓碑（已删除），
+查找时跳过墓碑继续探测，插入时可以复用墓碑的位置。
+墓碑越来越多时探测会变慢，所以计算加载因子时墓碑也算在内，扩容时会清除墓碑。
+*/
+
+// 默认最小容量 16
+const minCapacity = 1 << 4
+
+// 线性探测的扩容因子，墓碑也计算在内
+const linearProbingFactor = 0.75
+
+// 槽位状态
+const (
+	slotEmpty   uint8 = iota // 空槽位
+	slotFull                 // 存放了键值对
+	slotDeleted              // 墓碑，键值对已被删除
+)
+
+// linearSlot 线性探测的槽位
+type linearSlot[K comparable, V any] struct {
+	key   K
+	value V
+	state uint8
+}
+
+// LinearProbingMap 线性探测哈希表
+type LinearProbingMap[K comparable, V any] struct {
+	slots        []linearSlot[K, V] // 哈希表数组
+	capacity     int                // 数组容量
+	capacityMask int                // 容量掩码，等于 capacity-1
+	len          int                // 已添加键值对数量
+	tombstones   int                // 墓碑数量
+	hasher       hashmap.Hasher[K]  // 计算键的哈希值以及判断键是否相等
+	lock         sync.Mutex         // 并发安全
+}
+
+var _ hashmap.Map[string, int] = (*LinearProbingMap[string, int])(nil)
+
+// roundCapacity 实际大小为不小于 capacity 的第一个 2^k，且不小于最小容量
+func roundCapacity(cap int) int {
+	if cap <= minCapacity {
+		return minCapacity
+	}
+	return 1 << bits.Len(uint(cap-1))
+}
+
+// NewLinearProbingMap 创建容量为 cap 的线性探测哈希表，使用 hashmap.DefaultHasher
+func NewLinearProbingMap[K comparable, V any](cap int) *LinearProbingMap[K, V] {
+	return NewLinearProbingMapWithHasher[K, V](cap, hashmap.DefaultHasher[K]())
+}
+
+// NewLinearProbingMapWithHasher 使用指定的 Hasher 创建线性探测哈希表
+func NewLinearProbingMapWithHasher[K comparable, V any](cap int, hasher hashmap.Hasher[K]) *LinearProbingMap[K, V] {
+	m := &LinearProbingMap[K, V]{hasher: hasher}
+	m.init(roundCapacity(cap))
+	return m
+}
+
+// init 初始化容量为 cap 的空数组
+func (m *LinearProbingMap[K, V]) init(cap int) {
+	m.slots = make([]linearSlot[K, V], cap)
+	m.capacity = cap
+	m.capacityMask = cap - 1
+	m.len = 0
+	m.tombstones = 0
+}
+
+// Len 返回已添加键值对的数量
+func (m *LinearProbingMap[K, V]) Len() int {
+	return m.len
+}
+
+// Cap 返回哈希表数组的容量
+func (m *LinearProbingMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// find 查找键所在的下标，找不到返回 -1
+func (m *LinearProbingMap[K, V]) find(key K) int {
+	index := int(m.hasher.Hash(key) & uint64(m.capacityMask))
+	// 数组中一定有空槽位，探测一定会结束
+	for {
+		slot := &m.slots[index]
+		switch slot.state {
+		case slotEmpty:
+			// 遇到空槽位，说明键不存在
+			return -1
+		case slotFull:
+			if m.hasher.Equal(slot.key, key) {
+				return index
+			}
+		}
+		// 墓碑或者其他键，继续探测下一个位置
+		index = (index + 1) & m.capacityMask
+	}
+}
+
+// Put 添加键值对
+// 探测过程中记录遇到的第一个墓碑，键不存在时优先复用墓碑的位置
+func (m *LinearProbingMap[K, V]) Put(key K, value V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	index := int(m.hasher.Hash(key) & uint64(m.capacityMask))
+	tombstone := -1
+	for {
+		slot := &m.slots[index]
+		if slot.state == slotEmpty {
+			break
+		}
+		if slot.state == slotDeleted {
+			if tombstone == -1 {
+				tombstone = index
+			}
+		} else if m.hasher.Equal(slot.key, key) {
+			// 键值对存在，更新值并返回
+			slot.value = value
+			return
+		}
+		index = (index + 1) & m.capacityMask
+	}
+	if tombstone != -1 {
+		// 复用墓碑
+		index = tombstone
+		m.tombstones--
+	}
+	m.slots[index] = linearSlot[K, V]{key: key, value: value, state: slotFull}
+	m.len++
+	// 墓碑也会拉长探测路径，所以一起计算加载因子
+	if float64(m.len+m.tombstones)/float64(m.capacity) >= linearProbingFactor {
+		m.resize()
+	}
+}
+
+// resize 扩容，墓碑较多时只清除墓碑不扩大容量
+func (m *LinearProbingMap[K, V]) resize() {
+	cap := m.capacity
+	if float64(m.len)/float64(m.capacity) >= linearProbingFactor/2 {
+		cap *= 2
+	}
+	old := m.slots
+	m.init(cap)
+	for i := range old {
+		if old[i].state != slotFull {
+			continue
+		}
+		index := int(m.hasher.Hash(old[i].key) & uint64(m.capacityMask))
+		for m.slots[index].state != slotEmpty {
+			index = (index + 1) & m.capacityMask
+		}
+		m.slots[index] = old[i]
+		m.len++
+	}
+}
+
+// Get 获取键值对
+func (m *LinearProbingMap[K, V]) Get(key K) (value V, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if index := m.find(key); index != -1 {
+		return m.slots[index].value, true
+	}
+	return
+}
+
+// Delete 删除键值对，将槽位标记为墓碑
+func (m *LinearProbingMap[K, V]) Delete(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	index := m.find(key)
+	if index == -1 {
+		return
+	}
+	// 清空键值，方便垃圾回收
+	m.slots[index] = linearSlot[K, V]{state: slotDeleted}
+	m.len--
+	m.tombstones++
+}
@@ -0,0 +1,181 @@
+package openaddr
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 与内置 map 对比随机增删查的结果
+func testRandomOps(t *testing.T, m hashmap.Map[int, int]) {
+	ref := make(map[int]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		key := r.Intn(3000)
+		switch r.Intn(4) {
+		case 0, 1:
+			m.Put(key, i)
+			ref[key] = i
+		case 2:
+			m.Delete(key)
+			delete(ref, key)
+		case 3:
+			v, ok := m.Get(key)
+			want, wantOk := ref[key]
+			if ok != wantOk || v != want {
+				t.Fatalf("Get(%d) = %d, %v, want %d, %v", key, v, ok, want, wantOk)
+			}
+		}
+		if m.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", m.Len(), len(ref))
+		}
+	}
+	for key, want := range ref {
+		if v, ok := m.Get(key); !ok || v != want {
+			t.Fatalf("Get(%d) = %d, %v, want %d", key, v, ok, want)
+		}
+	}
+}
+
+func TestRandomOps(t *testing.T) {
+	t.Run("LinearProbing", func(t *testing.T) { testRandomOps(t, NewLinearProbingMap[int, int](0)) })
+	t.Run("RobinHood", func(t *testing.T) { testRandomOps(t, NewRobinHoodMap[int, int](0)) })
+	t.Run("Swiss", func(t *testing.T) { testRandomOps(t, NewSwissMap[int, int](0)) })
+	t.Run("Cuckoo", func(t *testing.T) { testRandomOps(t, NewCuckooMap[int, int](0)) })
+	t.Run("Cuckoo3", func(t *testing.T) {
+		testRandomOps(t, NewCuckooMapWithOptions[int, int](nil, CuckooOptions{Hashes: 3, StashSize: 1, MaxKicks: 8}))
+	})
+}
+
+// 所有键都冲突到同一个位置，测试墓碑以及后移删除
+func TestCollisions(t *testing.T) {
+	hasher := hashmap.NewHasher(func(key int) uint64 { return 7 }, nil)
+	maps := map[string]hashmap.Map[int, int]{
+		"LinearProbing": NewLinearProbingMapWithHasher[int, int](0, hasher),
+		"RobinHood":     NewRobinHoodMapWithHasher[int, int](0, hasher),
+		"Swiss":         NewSwissMapWithHasher[int, int](0, hasher),
+		"Cuckoo":        NewCuckooMapWithHasher[int, int](0, hasher),
+	}
+	for name, m := range maps {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				m.Put(i, i)
+			}
+			for i := 0; i < 100; i += 2 {
+				m.Delete(i)
+			}
+			for i := 0; i < 100; i++ {
+				_, ok := m.Get(i)
+				if ok != (i%2 == 1) {
+					t.Fatalf("Get(%d) ok = %v", i, ok)
+				}
+			}
+			if m.Len() != 50 {
+				t.Fatalf("Len() = %d, want 50", m.Len())
+			}
+		})
+	}
+}
+
+// builtinMap 内置 map，用于基准测试对比
+type builtinMap[K comparable, V any] map[K]V
+
+func (m builtinMap[K, V]) Put(key K, value V) { m[key] = value }
+func (m builtinMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+func (m builtinMap[K, V]) Delete(key K) { delete(m, key) }
+func (m builtinMap[K, V]) Len() int     { return len(m) }
+func (m builtinMap[K, V]) Cap() int     { return len(m) }
+
+var benchMaps = []struct {
+	name string
+	new  func() hashmap.Map[string, int]
+}{
+	{"Chaining", func() hashmap.Map[string, int] { return hashmap.NewHashMap[string, int](0) }},
+	{"LinearProbing", func() hashmap.Map[string, int] { return NewLinearProbingMap[string, int](0) }},
+	{"RobinHood", func() hashmap.Map[string, int] { return NewRobinHoodMap[string, int](0) }},
+	{"Swiss", func() hashmap.Map[string, int] { return NewSwissMap[string, int](0) }},
+	{"Cuckoo", func() hashmap.Map[string, int] { return NewCuckooMap[string, int](0) }},
+	{"Builtin", func() hashmap.Map[string, int] { return make(builtinMap[string, int]) }},
+}
+
+func benchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+func BenchmarkPut(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bm := range benchMaps {
+		b.Run(bm.name, func(b *testing.B) {
+			m := bm.new()
+			for i := 0; i < b.N; i++ {
+				if i%len(keys) == 0 {
+					m = bm.new()
+				}
+				m.Put(keys[i%len(keys)], i)
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bm := range benchMaps {
+		b.Run(bm.name, func(b *testing.B) {
+			m := bm.new()
+			for i, key := range keys {
+				m.Put(key, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Get(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+func BenchmarkGetMiss(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	misses := make([]string, len(keys))
+	for i := range misses {
+		misses[i] = "miss-" + strconv.Itoa(i)
+	}
+	for _, bm := range benchMaps {
+		b.Run(bm.name, func(b *testing.B) {
+			m := bm.new()
+			for i, key := range keys {
+				m.Put(key, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Get(misses[i%len(misses)])
+			}
+		})
+	}
+}
+
+func BenchmarkPutDelete(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bm := range benchMaps {
+		b.Run(bm.name, func(b *testing.B) {
+			m := bm.new()
+			for i := 0; i < len(keys)/2; i++ {
+				m.Put(keys[i], i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// 保持元素数量不变，不断产生墓碑
+				m.Put(keys[(i+len(keys)/2)%len(keys)], i)
+				m.Delete(keys[i%len(keys)])
+			}
+		})
+	}
+}
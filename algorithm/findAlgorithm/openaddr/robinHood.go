@@ -0,0 +1,170 @@
+package openaddr
+
+import (
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 罗宾汉哈希（Robin Hood Hashing）
+/*
+同样是线性探测，但每个元素记录自己离理想位置的距离 dist（探测序列长度）。
+插入时如果遇到的元素 dist 比自己小（比自己"富有"），就抢占它的位置，让它继续往后找位置，
+即"劫富济贫"，这样所有元素的 dist 都比较接近，最长探测长度大大缩短。
+
+查找时如果遇到的元素 dist 比当前探测距离还小，说明要找的键不可能在后面，可以提前结束。
+
+删除时不使用墓碑，而是后移删除（backward shift）：
+将后面 dist > 0 的元素依次往前挪一位并将 dist 减 1，直到遇到空槽位或者 dist 为 0 的元素。
+*/
+
+// 罗宾汉哈希的扩容因子，探测长度短，可以用更高的加载因子
+const robinHoodFactor = 0.9
+
+// robinSlot 罗宾汉哈希的槽位
+type robinSlot[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64 // 键的哈希值，扩容和比较时不用重新计算
+	dist  uint32 // 离理想位置的距离加 1，0 表示空槽位
+}
+
+// RobinHoodMap 罗宾汉哈希表
+type RobinHoodMap[K comparable, V any] struct {
+	slots        []robinSlot[K, V] // 哈希表数组
+	capacity     int               // 数组容量
+	capacityMask int               // 容量掩码，等于 capacity-1
+	len          int               // 已添加键值对数量
+	hasher       hashmap.Hasher[K] // 计算键的哈希值以及判断键是否相等
+	lock         sync.Mutex        // 并发安全
+}
+
+var _ hashmap.Map[string, int] = (*RobinHoodMap[string, int])(nil)
+
+// NewRobinHoodMap 创建容量为 cap 的罗宾汉哈希表，使用 hashmap.DefaultHasher
+func NewRobinHoodMap[K comparable, V any](cap int) *RobinHoodMap[K, V] {
+	return NewRobinHoodMapWithHasher[K, V](cap, hashmap.DefaultHasher[K]())
+}
+
+// NewRobinHoodMapWithHasher 使用指定的 Hasher 创建罗宾汉哈希表
+func NewRobinHoodMapWithHasher[K comparable, V any](cap int, hasher hashmap.Hasher[K]) *RobinHoodMap[K, V] {
+	m := &RobinHoodMap[K, V]{hasher: hasher}
+	m.init(roundCapacity(cap))
+	return m
+}
+
+// init 初始化容量为 cap 的空数组
+func (m *RobinHoodMap[K, V]) init(cap int) {
+	m.slots = make([]robinSlot[K, V], cap)
+	m.capacity = cap
+	m.capacityMask = cap - 1
+	m.len = 0
+}
+
+// Len 返回已添加键值对的数量
+func (m *RobinHoodMap[K, V]) Len() int {
+	return m.len
+}
+
+// Cap 返回哈希表数组的容量
+func (m *RobinHoodMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// find 查找键所在的下标，找不到返回 -1
+func (m *RobinHoodMap[K, V]) find(key K) int {
+	hash := m.hasher.Hash(key)
+	index := int(hash & uint64(m.capacityMask))
+	for dist := uint32(1); ; dist++ {
+		slot := &m.slots[index]
+		// 空槽位，或者遇到比当前探测距离还近的元素，键不可能在后面
+		if slot.dist < dist {
+			return -1
+		}
+		if slot.hash == hash && m.hasher.Equal(slot.key, key) {
+			return index
+		}
+		index = (index + 1) & m.capacityMask
+	}
+}
+
+// Put 添加键值对
+func (m *RobinHoodMap[K, V]) Put(key K, value V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if index := m.find(key); index != -1 {
+		// 键值对存在，更新值并返回
+		m.slots[index].value = value
+		return
+	}
+	if float64(m.len+1)/float64(m.capacity) >= robinHoodFactor {
+		m.resize(2 * m.capacity)
+	}
+	m.insert(robinSlot[K, V]{key: key, value: value, hash: m.hasher.Hash(key)})
+	m.len++
+}
+
+// insert 插入一个不存在的键值对，遇到 dist 比自己小的元素时交换位置
+func (m *RobinHoodMap[K, V]) insert(entry robinSlot[K, V]) {
+	index := int(entry.hash & uint64(m.capacityMask))
+	entry.dist = 1
+	for {
+		slot := &m.slots[index]
+		if slot.dist == 0 {
+			// 空槽位，直接放入
+			*slot = entry
+			return
+		}
+		if slot.dist < entry.dist {
+			// 劫富济贫，抢占位置，被抢占的元素继续往后找位置
+			*slot, entry = entry, *slot
+		}
+		index = (index + 1) & m.capacityMask
+		entry.dist++
+	}
+}
+
+// resize 扩容到容量 cap，键的哈希值已经保存，不需要重新计算
+func (m *RobinHoodMap[K, V]) resize(cap int) {
+	old := m.slots
+	m.init(cap)
+	for i := range old {
+		if old[i].dist != 0 {
+			m.insert(old[i])
+			m.len++
+		}
+	}
+}
+
+// Get 获取键值对
+func (m *RobinHoodMap[K, V]) Get(key K) (value V, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if index := m.find(key); index != -1 {
+		return m.slots[index].value, true
+	}
+	return
+}
+
+// Delete 删除键值对，后移删除，不需要墓碑
+func (m *RobinHoodMap[K, V]) Delete(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	index := m.find(key)
+	if index == -1 {
+		return
+	}
+	for {
+		next := (index + 1) & m.capacityMask
+		// 后一个元素为空或者就在理想位置，停止挪动
+		if m.slots[next].dist <= 1 {
+			break
+		}
+		m.slots[index] = m.slots[next]
+		m.slots[index].dist--
+		index = next
+	}
+	// 清空最后一个槽位
+	m.slots[index] = robinSlot[K, V]{}
+	m.len--
+}
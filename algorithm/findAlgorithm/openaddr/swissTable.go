@@ -0,0 +1,234 @@
+package openaddr
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 瑞士表（Swiss Table）风格的哈希表，不使用 SIMD
+/*
+将数组按 8 个槽位分为一组，每个槽位有一个字节的控制字节：
+	空槽位   0x80 (1000 0000)
+	墓碑     0xFE (1111 1110)
+	已存放   0x00 ~ 0x7F，存放哈希值的低 7 位 h2
+
+哈希值的高 57 位 h1 用来定位组，低 7 位 h2 存放在控制字节中。
+查找时一次读取一组 8 个控制字节作为一个 uint64，用位运算（SWAR）同时和 h2 比较，
+只有控制字节匹配的槽位才需要比较键，大部分不相等的键不会被访问。
+组内没有找到并且组内有空槽位时查找结束，否则按照 1, 2, 3 ... 的步长探测下一组（三角数探测），
+组数为 2^k 时三角数探测能访问到所有的组。
+*/
+
+const (
+	groupSize = 8 // 每组的槽位数量
+
+	ctrlEmpty   uint8 = 0x80 // 空槽位
+	ctrlDeleted uint8 = 0xFE // 墓碑
+
+	lsb uint64 = 0x0101010101010101 // 每个字节的最低位
+	msb uint64 = 0x8080808080808080 // 每个字节的最高位
+)
+
+// 瑞士表的扩容因子 7/8，墓碑也计算在内
+const swissFactor = 7.0 / 8.0
+
+// swissGroup 一组 8 个槽位
+type swissGroup[K comparable, V any] struct {
+	ctrl   [groupSize]uint8
+	keys   [groupSize]K
+	values [groupSize]V
+}
+
+// SwissMap 瑞士表风格的哈希表
+type SwissMap[K comparable, V any] struct {
+	groups     []swissGroup[K, V] // 组数组
+	groupMask  int                // 组数掩码，等于组数-1
+	capacity   int                // 槽位总数
+	len        int                // 已添加键值对数量
+	tombstones int                // 墓碑数量
+	hasher     hashmap.Hasher[K]  // 计算键的哈希值以及判断键是否相等
+	lock       sync.Mutex         // 并发安全
+}
+
+var _ hashmap.Map[string, int] = (*SwissMap[string, int])(nil)
+
+// NewSwissMap 创建容量为 cap 的瑞士表，使用 hashmap.DefaultHasher
+func NewSwissMap[K comparable, V any](cap int) *SwissMap[K, V] {
+	return NewSwissMapWithHasher[K, V](cap, hashmap.DefaultHasher[K]())
+}
+
+// NewSwissMapWithHasher 使用指定的 Hasher 创建瑞士表
+func NewSwissMapWithHasher[K comparable, V any](cap int, hasher hashmap.Hasher[K]) *SwissMap[K, V] {
+	m := &SwissMap[K, V]{hasher: hasher}
+	m.init(roundCapacity(cap))
+	return m
+}
+
+// init 初始化容量为 cap 的空数组，cap 为 8 的倍数
+func (m *SwissMap[K, V]) init(cap int) {
+	m.groups = make([]swissGroup[K, V], cap/groupSize)
+	for i := range m.groups {
+		for j := range m.groups[i].ctrl {
+			m.groups[i].ctrl[j] = ctrlEmpty
+		}
+	}
+	m.groupMask = len(m.groups) - 1
+	m.capacity = cap
+	m.len = 0
+	m.tombstones = 0
+}
+
+// Len 返回已添加键值对的数量
+func (m *SwissMap[K, V]) Len() int {
+	return m.len
+}
+
+// Cap 返回哈希表的槽位总数
+func (m *SwissMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// splitHash 将哈希值分为定位组的 h1 和存放在控制字节中的 h2
+func splitHash(hash uint64) (h1 uint64, h2 uint8) {
+	return hash >> 7, uint8(hash & 0x7F)
+}
+
+// ctrlWord 将一组控制字节读取为 uint64
+func ctrlWord(ctrl *[groupSize]uint8) uint64 {
+	return binary.LittleEndian.Uint64(ctrl[:])
+}
+
+// matchH2 返回控制字节等于 h2 的槽位，每个匹配的槽位对应字节的最高位为 1
+// 可能有误报（不会漏报），所以匹配后还需要比较键
+func matchH2(word uint64, h2 uint8) uint64 {
+	x := word ^ (lsb * uint64(h2))
+	return (x - lsb) &^ x & msb
+}
+
+// matchEmpty 返回空槽位，空槽位最高位为 1 且第 1 位为 0
+func matchEmpty(word uint64) uint64 {
+	return word &^ (word << 6) & msb
+}
+
+// matchEmptyOrDeleted 返回空槽位和墓碑，二者最高位都为 1
+func matchEmptyOrDeleted(word uint64) uint64 {
+	return word & msb
+}
+
+// nextMatch 取出最低的匹配槽位下标，并清除该位
+func nextMatch(match *uint64) int {
+	index := bits.TrailingZeros64(*match) / 8
+	*match &= *match - 1
+	return index
+}
+
+// find 查找键所在的组和槽位，找不到 ok 为 false
+func (m *SwissMap[K, V]) find(key K) (group, slot int, ok bool) {
+	h1, h2 := splitHash(m.hasher.Hash(key))
+	group = int(h1) & m.groupMask
+	for step := 1; ; step++ {
+		g := &m.groups[group]
+		word := ctrlWord(&g.ctrl)
+		for match := matchH2(word, h2); match != 0; {
+			slot = nextMatch(&match)
+			if m.hasher.Equal(g.keys[slot], key) {
+				return group, slot, true
+			}
+		}
+		// 组内有空槽位，说明键不存在
+		if matchEmpty(word) != 0 {
+			return 0, 0, false
+		}
+		group = (group + step) & m.groupMask
+	}
+}
+
+// Put 添加键值对
+func (m *SwissMap[K, V]) Put(key K, value V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if group, slot, ok := m.find(key); ok {
+		// 键值对存在，更新值并返回
+		m.groups[group].values[slot] = value
+		return
+	}
+	if float64(m.len+m.tombstones+1)/float64(m.capacity) > swissFactor {
+		m.resize()
+	}
+	m.insert(key, value, m.hasher.Hash(key))
+}
+
+// insert 插入一个不存在的键值对，放在探测路径上第一个空槽位或墓碑
+func (m *SwissMap[K, V]) insert(key K, value V, hash uint64) {
+	h1, h2 := splitHash(hash)
+	group := int(h1) & m.groupMask
+	for step := 1; ; step++ {
+		g := &m.groups[group]
+		if match := matchEmptyOrDeleted(ctrlWord(&g.ctrl)); match != 0 {
+			slot := nextMatch(&match)
+			if g.ctrl[slot] == ctrlDeleted {
+				m.tombstones--
+			}
+			g.ctrl[slot] = h2
+			g.keys[slot] = key
+			g.values[slot] = value
+			m.len++
+			return
+		}
+		group = (group + step) & m.groupMask
+	}
+}
+
+// resize 扩容，墓碑较多时只清除墓碑不扩大容量
+func (m *SwissMap[K, V]) resize() {
+	cap := m.capacity
+	if float64(m.len)/float64(m.capacity) >= swissFactor/2 {
+		cap *= 2
+	}
+	old := m.groups
+	m.init(cap)
+	for i := range old {
+		for j := 0; j < groupSize; j++ {
+			if old[i].ctrl[j]&0x80 == 0 {
+				m.insert(old[i].keys[j], old[i].values[j], m.hasher.Hash(old[i].keys[j]))
+			}
+		}
+	}
+}
+
+// Get 获取键值对
+func (m *SwissMap[K, V]) Get(key K) (value V, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if group, slot, found := m.find(key); found {
+		return m.groups[group].values[slot], true
+	}
+	return
+}
+
+// Delete 删除键值对
+// 组内还有空槽位时，经过该组的探测一定会在该组结束，可以直接置为空槽位，否则置为墓碑
+func (m *SwissMap[K, V]) Delete(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	group, slot, ok := m.find(key)
+	if !ok {
+		return
+	}
+	g := &m.groups[group]
+	if matchEmpty(ctrlWord(&g.ctrl)) != 0 {
+		g.ctrl[slot] = ctrlEmpty
+	} else {
+		g.ctrl[slot] = ctrlDeleted
+		m.tombstones++
+	}
+	// 清空键值，方便垃圾回收
+	var k K
+	var v V
+	g.keys[slot] = k
+	g.values[slot] = v
+	m.len--
+}
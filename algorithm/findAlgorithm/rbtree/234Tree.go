@@ -1,4 +1,4 @@
-package main
+package rbtree
 
 /*
 234树
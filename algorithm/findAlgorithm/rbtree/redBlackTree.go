@@ -1,4 +1,4 @@
-package main
+package rbtree
 
 import (
 	"fmt"
@@ -53,7 +53,7 @@ type RBTNode struct {
 // IsRed 节点颜色
 func IsRed(node *RBTNode) bool {
 	if node == nil {
-		return true
+		return false
 	}
 	return node.Color == RED
 }
@@ -183,10 +183,11 @@ func (tree *RBTree) Add(value int64) {
 	// 插入元素后， 插入元素的父亲节点
 	var parent *RBTNode
 	// 辅助变量，为了知道元素最后要插到左边还是右边
-	var cmp int64 = 0
+	var cmp int = 0
 	for {
 		parent = t
-		cmp = value - t.Value
+		// 不能用 value - t.Value 判断大小，两个相差很大的数相减会溢出
+		cmp = compare(value, t.Value)
 		if cmp < 0 {
 			// 比当前节点小，往左子树插入
 			t = t.Left
@@ -216,6 +217,16 @@ func (tree *RBTree) Add(value int64) {
 	tree.fixAfterInsertion(newNode)
 }
 
+// compare 比较两个值，a < b 返回 -1，a > b 返回 1，相等返回 0
+func compare(a, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
 // 调整新插入的节点，自底而上
 func (tree *RBTree) fixAfterInsertion(node *RBTNode) {
 	// 插入的新节点一定要是红色
@@ -409,85 +420,6 @@ func (tree *RBTree) delete(node *RBTNode) {
 }
 
 // 调整删除的叶子节点，自底向上,核心函数
-func (tree *RBTree) fixAfterDeletions(node *RBTNode) {
-	// 如果不是递归到根节点，且节点是黑节点，那么继续递归
-	for tree.Root != node && !IsRed(node) {
-		// 要删除的节点在父亲左边
-		if node == LeftOf(ParentOf(node)) {
-			// 找出兄弟
-			brother := RightOf(ParentOf(node))
-
-			// 兄弟是红色的，对应图例1，那么兄弟变黑，父亲变红，然后对父亲左旋，进入图例21,22,23
-			if IsRed(brother) {
-				SetColor(brother, BLACK)
-				SetColor(ParentOf(node), RED)
-				tree.RotationLeft(ParentOf(node))
-				brother = RightOf(ParentOf(node)) //兄弟此时变了
-			}
-
-			// 兄弟是黑色的
-			// 兄弟的左右儿子都是黑色，进入图例23，将兄弟设为红色，
-			// 父亲所在的子树作为整体，当作删除的节点，继续向上递归
-			if !IsRed(LeftOf(brother)) && !IsRed(RightOf(brother)) {
-				SetColor(brother, RED)
-				node = ParentOf(node)
-			}else {
-				// 兄弟的右儿子是黑色，将兄弟设为红色，兄弟的左儿子设为黑色，对兄弟右旋
-				if !IsRed(RightOf(brother)) {
-					SetColor(LeftOf(brother), BLACK)
-					SetColor(brother, RED)
-					tree.RotationRight(brother)
-					brother = RightOf(ParentOf(node)) //兄弟此时变了
-				}
-
-				// 兄弟的右儿子是红色，将兄弟设置为父亲的颜色，兄弟的右儿子以及父亲变黑，对父亲左旋
-				SetColor(brother, ParentOf(node).Color)
-				SetColor(ParentOf(node), BLACK)
-				SetColor(RightOf(brother), BLACK)
-				tree.RotationLeft(ParentOf(node))
-				node = tree.Root
-			}
-		}else {
-			// 要删除的节点在父亲右边
-			// 找出兄弟
-			brother := RightOf(ParentOf(node))
-
-			// 兄弟是红色的，那么兄弟变黑，父亲变红，然后对父亲右旋
-			if IsRed(brother) {
-				SetColor(brother, BLACK)
-				SetColor(ParentOf(node), RED)
-				tree.RotationRight(ParentOf(node))
-				brother = LeftOf(ParentOf(node)) // 兄弟此时变了,变为父节点的左子树节点
-			}
-
-			// 兄弟是黑色的
-			// 兄弟的左右儿子都是黑色，将兄弟设为红色，父亲所在的子树作为整体，
-			// 当作删除的节点，继续向上递归
-			if !IsRed(LeftOf(brother)) && !IsRed(RightOf(brother)) {
-				SetColor(brother, RED)
-				node = ParentOf(node)
-			}else {
-				// 兄弟的左儿子是黑色，将兄弟设为红色，兄弟的右儿子设为黑色，对兄弟左旋
-				if !IsRed(LeftOf(brother)) {
-					SetColor(RightOf(brother), BLACK)
-					SetColor(brother, RED)
-					tree.RotationLeft(brother)
-					brother = LeftOf(ParentOf(node)) // 兄弟此时变了
-				}
-
-				// 兄弟的左儿子是红色，将兄弟设置为父亲的颜色，兄弟的左儿子以及父亲变黑，对父亲右旋
-				SetColor(brother, ParentOf(node).Color)
-				SetColor(ParentOf(node), BLACK)
-				SetColor(LeftOf(brother), BLACK)
-				tree.RotationRight(ParentOf(node))
-				node = tree.Root
-			}
-		}
-	}
-	// 根节点总是黑色
-	SetColor(node, BLACK)
-}
-
 func (tree *RBTree) fixAfterDeletion(node *RBTNode) {
 	// 如果不是递归到根节点，且节点是黑节点，那么继续递归
 	for tree.Root != node && !IsRed(node) {
@@ -529,7 +461,7 @@ func (tree *RBTree) fixAfterDeletion(node *RBTNode) {
 		} else {
 			// 要删除的节点在父亲右边，对应图例3，4
 			// 找出兄弟
-			brother := RightOf(ParentOf(node))
+			brother := LeftOf(ParentOf(node))
 
 			// 兄弟是红色的，对应图例3，那么兄弟变黑，父亲变红，然后对父亲右旋，进入图例41,42,43
 			if IsRed(brother) {
@@ -617,6 +549,43 @@ func (node *RBTNode) FindMaxValue() *RBTNode {
 	return node.Right.FindMaxValue()
 }
 
+// Ceiling 找出大于等于 value 的最小节点，不存在返回 nil
+func (tree *RBTree) Ceiling(value int64) *RBTNode {
+	var ceiling *RBTNode
+	node := tree.Root
+	for node != nil {
+		if value == node.Value {
+			return node
+		} else if value < node.Value {
+			// 该节点大于 value，可能是结果，继续往左子树找更小的
+			ceiling = node
+			node = node.Left
+		} else {
+			// 该节点小于 value，结果只可能在右子树
+			node = node.Right
+		}
+	}
+	return ceiling
+}
+
+// Successor 找出节点的后继节点，也就是中序遍历的下一个节点，不存在返回 nil
+func (tree *RBTree) Successor(node *RBTNode) *RBTNode {
+	if node == nil {
+		return nil
+	}
+	// 有右子树，后继节点是右子树中最小的节点
+	if node.Right != nil {
+		return node.Right.FindMinValue()
+	}
+	// 否则一直往上找，直到该节点在某个祖先的左子树中，这个祖先就是后继节点
+	parent := node.Parent
+	for parent != nil && node == parent.Right {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
+
 // Find 查找指定节点
 func (tree *RBTree) Find(value int64) *RBTNode {
 	if tree.Root == nil {
@@ -756,7 +725,7 @@ func (node *RBTNode) Is234() bool {
 // IsBalanced 节点所在的子树是否平衡，是否有 blackNum 个黑链接
 func (node *RBTNode) IsBalanced(blackNum int) bool {
 	if node == nil {
-		return true
+		return blackNum == 0
 	}
 
 	if !IsRed(node) {
@@ -773,69 +742,6 @@ func (node *RBTNode) IsBalanced(blackNum int) bool {
 	return true
 }
 
-// 测试
-func main() {
-	tree := NewRBTree()
-	values := []int64{2, 3, 7, 10, 10, 10, 10, 23, 9, 102, 109, 111, 112, 113}
-	for _, v := range values {
-		tree.Add(v)
-	}
-
-	// 找到最大值或最小值的节点
-	fmt.Println("find min value:", tree.FindMinValue())
-	fmt.Println("find max value:", tree.FindMaxValue())
-
-	// 查找不存在的99
-	node := tree.Find(99)
-	if node != nil {
-		fmt.Println("find it 99!")
-	} else {
-		fmt.Println("not find it 99!")
-	}
-
-	// 查找存在的9
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	tree.MidOrder()
-
-	// 删除存在的9后，再查找9
-	tree.Delete(9)
-	tree.Delete(10)
-	tree.Delete(2)
-	tree.Delete(3)
-	tree.Add(4)
-	tree.Add(3)
-	tree.Add(10)
-	tree.Delete(111)
-	node = tree.Find(9)
-	if node != nil {
-		fmt.Println("find it 9!")
-	} else {
-		fmt.Println("not find it 9!")
-	}
-
-	if tree.IsRBTree() {
-		fmt.Println("is a rb tree")
-	} else {
-		fmt.Println("is not rb tree")
-	}
-
-	tree.Delete(3)
-	tree.Delete(4)
-	tree.Delete(7)
-	tree.Delete(10)
-	tree.Delete(23)
-	tree.Delete(102)
-	tree.Delete(109)
-	tree.Delete(112)
-	tree.Delete(112)
-	tree.MidOrder()
-}
 /*
 总结
 红黑树，无论是左偏还是普通的红黑树，理解都可以直接理解2-3或2-3-4树，添加操作比较简单，
@@ -0,0 +1,74 @@
+package search
+
+// 二分查找
+// 数组必须是有序的，每次取中间的数和目标值比较，比较后排除掉一半的区间，
+// 时间复杂度为：O(logn)
+
+// BinarySearch 二分查找，递归实现，在 array[left,right] 中查找 target
+// 找到返回下标，找不到返回 -1
+func BinarySearch(array []int, target, left, right int) int {
+	if left > right {
+		// 遍历完毕，出界了找不到
+		return -1
+	}
+	// 从中间开始查找
+	mid := (left + right) / 2
+	// 获取中间值
+	middleNum := array[mid]
+	// 如果相等，返回找到了
+	if target == middleNum {
+		return mid
+	} else if target > middleNum {
+		// 中间值比目标值还小，从右边区间开始查找
+		return BinarySearch(array, target, mid+1, right)
+	} else {
+		// 中间值比目标值还大，从左边边区间开始查找
+		return BinarySearch(array, target, left, mid-1)
+	}
+}
+
+// 很多计算机问题都可以用递归来简化求解，
+// 理论上，所有的递归方式都可以转化为非递归的方式，不过使用递归，代码的可读性更高
+
+// BinarySearchLoop 二分查找，非递归实现，在 array[l,r] 中查找 target
+// 找到返回下标，找不到返回 -1
+func BinarySearchLoop(array []int, target, l, r int) int {
+	templ := l
+	tempr := r
+	for {
+		// 判断是否查找完毕越界
+		if templ > tempr {
+			return -1
+		}
+		// 从中间开始查找
+		mid := (tempr + templ) / 2
+		midNum := array[mid]
+		if target == midNum {
+			return mid // 找到了
+		} else if target > midNum {
+			// 中间的数比目标还小，从右边找
+			templ = mid + 1
+		} else {
+			// 中间的数比目标还大，从左边找
+			tempr = mid - 1
+		}
+	}
+}
+
+// Contains 二分查找，有序数组 array 中是否存在 target
+func Contains(target int, array []int) bool {
+	lenth := len(array) - 1
+	left, right, mid := 0, lenth, 0
+	for left <= right {
+		mid = (right + left) / 2
+		if array[mid] == target {
+			return true
+		} else if array[mid] < target {
+			left = mid + 1
+		} else if array[mid] > target {
+			right = mid - 1
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package search_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/search"
+)
+
+func ExampleBinarySearch() {
+	array := []int{1, 5, 9, 15, 81, 89, 123, 189, 333}
+	target := 500
+	result := search.BinarySearch(array, target, 0, len(array)-1)
+	fmt.Println(target, result)
+
+	target = 189
+	result = search.BinarySearch(array, target, 0, len(array)-1)
+	fmt.Println(target, result)
+	// Output:
+	// 500 -1
+	// 189 7
+}
+
+func ExampleBinarySearchLoop() {
+	array := []int{1, 5, 9, 15, 81, 89, 123, 189, 333}
+	target := 500
+	result := search.BinarySearchLoop(array, target, 0, len(array)-1)
+	fmt.Println(target, result)
+
+	target = 5
+	result = search.BinarySearchLoop(array, target, 0, len(array)-1)
+	fmt.Println(target, result)
+	// Output:
+	// 500 -1
+	// 5 1
+}
+
+func ExampleContains() {
+	num := []int{1, 2, 3, 4, 5, 6, 8, 9, 11, 16}
+	fmt.Println(search.Contains(9, num), search.Contains(19, num))
+	// Output:
+	// true false
+}
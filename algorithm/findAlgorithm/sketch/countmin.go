@@ -0,0 +1,189 @@
+package sketch
+
+import (
+	"math"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// Count-Min Sketch 频率估计
+/*
+统计数据流中每个键出现的次数，用哈希表需要保存所有的键。
+Count-Min Sketch 使用 d 行、每行 w 个计数器，每行一个哈希函数：
+添加键时每行对应的计数器加 1，查询时取 d 个计数器中的最小值。
+计数器会被其他键共用，所以估计值只会偏大不会偏小，
+误差不超过 eps*N 的概率至少为 1-delta（N 为所有键的总次数），其中 w = e/eps，d = ln(1/delta)。
+
+d 个哈希函数使用带种子的 xxhash 做双重哈希：gi = h1 + i*h2 (mod w)。
+
+保守更新（conservative update）：添加 c 次时，先算出当前的估计值 est，
+每行的计数器只更新为 max(计数器, est+c)，而不是都加 c。
+这样不会破坏"估计值不偏小"的性质，却能明显减小高频键对低频键的干扰，
+代价是不能再支持删除（减少计数）。
+*/
+
+// CountMinSketch 频率估计
+type CountMinSketch struct {
+	width    uint64            // 每行计数器数量
+	depth    int               // 行数
+	seed     uint64            // 哈希种子
+	hasher   hash.XXHashHasher // 带种子的 xxhash
+	counters []uint64          // depth*width 个计数器，第 i 行为 counters[i*width:(i+1)*width]
+	total    uint64            // 所有键的总次数
+	lock     sync.Mutex        // 并发安全
+}
+
+// NewCountMinSketch 创建 depth 行、每行 width 个计数器的 Count-Min Sketch，种子为 0
+func NewCountMinSketch(width uint64, depth int) *CountMinSketch {
+	return NewCountMinSketchWithSeed(width, depth, 0)
+}
+
+// NewCountMinSketchWithSeed 创建哈希种子为 seed 的 Count-Min Sketch
+func NewCountMinSketchWithSeed(width uint64, depth int, seed uint64) *CountMinSketch {
+	width = max(width, 1)
+	depth = max(depth, 1)
+	return &CountMinSketch{
+		width:    width,
+		depth:    depth,
+		seed:     seed,
+		hasher:   hash.NewXXHash(seed),
+		counters: make([]uint64, width*uint64(depth)),
+	}
+}
+
+// NewCountMinSketchWithEstimates 根据误差 eps 和置信度 1-delta 创建 Count-Min Sketch
+// 估计值以至少 1-delta 的概率不超过 真实值 + eps*总次数
+func NewCountMinSketchWithEstimates(eps, delta float64) *CountMinSketch {
+	width := uint64(math.Ceil(math.E / eps))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketch(width, depth)
+}
+
+// Width 返回每行计数器数量
+func (s *CountMinSketch) Width() uint64 {
+	return s.width
+}
+
+// Depth 返回行数
+func (s *CountMinSketch) Depth() int {
+	return s.depth
+}
+
+// Total 返回所有键的总次数
+func (s *CountMinSketch) Total() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.total
+}
+
+// locations 双重哈希，计算键在每行中的计数器下标
+func (s *CountMinSketch) locations(x uint64, dst []uint64) []uint64 {
+	h1 := x
+	h2 := x>>32 | x<<32 | 1
+	for i := 0; i < s.depth; i++ {
+		dst = append(dst, uint64(i)*s.width+(h1+uint64(i)*h2)%s.width)
+	}
+	return dst
+}
+
+// Add 键出现 count 次，返回添加后的估计值
+func (s *CountMinSketch) Add(data []byte, count uint64) uint64 {
+	return s.add(s.hasher.Sum64(data), count)
+}
+
+// AddString 字符串键出现 count 次，返回添加后的估计值
+func (s *CountMinSketch) AddString(key string, count uint64) uint64 {
+	return s.add(s.hasher.Sum64String(key), count)
+}
+
+// AddHash 已经计算好哈希值 x 的键出现 count 次，返回添加后的估计值
+// 用于键不是字符串的场景，比如用 hashmap.Hasher 计算泛型键的哈希值，x 应当是均匀分布的 64 位哈希值
+func (s *CountMinSketch) AddHash(x uint64, count uint64) uint64 {
+	return s.add(x, count)
+}
+
+// add 保守更新，每行的计数器只增加到 估计值+count
+func (s *CountMinSketch) add(x uint64, count uint64) uint64 {
+	var buf [16]uint64
+	locs := s.locations(x, buf[:0])
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	estimate := s.estimate(locs) + count
+	for _, loc := range locs {
+		s.counters[loc] = max(s.counters[loc], estimate)
+	}
+	s.total += count
+	return estimate
+}
+
+// Estimate 估计键出现的次数，估计值不小于真实值
+func (s *CountMinSketch) Estimate(data []byte) uint64 {
+	return s.query(s.hasher.Sum64(data))
+}
+
+// EstimateString 估计字符串键出现的次数
+func (s *CountMinSketch) EstimateString(key string) uint64 {
+	return s.query(s.hasher.Sum64String(key))
+}
+
+// EstimateHash 估计已经计算好哈希值 x 的键出现的次数
+func (s *CountMinSketch) EstimateHash(x uint64) uint64 {
+	return s.query(x)
+}
+
+// query 查询估计值
+func (s *CountMinSketch) query(x uint64) uint64 {
+	var buf [16]uint64
+	locs := s.locations(x, buf[:0])
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.estimate(locs)
+}
+
+// estimate 取 d 个计数器中的最小值
+func (s *CountMinSketch) estimate(locs []uint64) uint64 {
+	estimate := uint64(math.MaxUint64)
+	for _, loc := range locs {
+		estimate = min(estimate, s.counters[loc])
+	}
+	return estimate
+}
+
+// Merge 合并另一个 Count-Min Sketch，对应的计数器相加，两者的大小和种子必须相同
+// 保守更新的草图合并后估计值仍然不小于真实值，但误差会比同时添加所有键更大
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s.width != other.width || s.depth != other.depth || s.seed != other.seed {
+		return ErrIncompatible
+	}
+	other.lock.Lock()
+	counters := append([]uint64(nil), other.counters...)
+	total := other.total
+	other.lock.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, c := range counters {
+		s.counters[i] += c
+	}
+	s.total += total
+	return nil
+}
+
+// Halve 所有计数器减半，用于让很久以前的访问逐渐失去影响（老化）
+func (s *CountMinSketch) Halve() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := range s.counters {
+		s.counters[i] >>= 1
+	}
+	s.total >>= 1
+}
+
+// Clear 清空
+func (s *CountMinSketch) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clear(s.counters)
+	s.total = 0
+}
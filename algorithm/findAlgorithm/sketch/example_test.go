@@ -0,0 +1,70 @@
+package sketch_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/sketch"
+)
+
+func ExampleHyperLogLog() {
+	// 精度 14，16384 个寄存器，标准误差约 0.8%
+	h := sketch.NewHyperLogLog(14)
+	for i := 0; i < 100; i++ {
+		h.AddString(fmt.Sprint("user", i%50))
+	}
+	fmt.Println(h.Count(), h.IsSparse())
+
+	for i := 0; i < 100000; i++ {
+		h.AddString(fmt.Sprint("user", i))
+	}
+	fmt.Println(h.Count(), h.IsSparse())
+	// Output:
+	// 50 true
+	// 100330 false
+}
+
+func ExampleHyperLogLog_Merge() {
+	// 两台机器分别统计，合并后得到总的基数
+	a := sketch.NewHyperLogLog(12)
+	b := sketch.NewHyperLogLog(12)
+	for i := 0; i < 3000; i++ {
+		a.AddString(fmt.Sprint("user", i))
+		b.AddString(fmt.Sprint("user", i+2000))
+	}
+	if err := a.Merge(b); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(a.Count())
+	fmt.Println(a.Merge(sketch.NewHyperLogLog(10)))
+	// Output:
+	// 4954
+	// sketch: incompatible sketches
+}
+
+func ExampleCountMinSketch() {
+	// 误差不超过总次数的 0.1%，置信度 99%
+	s := sketch.NewCountMinSketchWithEstimates(0.001, 0.01)
+	fmt.Println(s.Width(), s.Depth())
+	s.AddString("apple", 3)
+	s.AddString("banana", 1)
+	s.AddString("apple", 2)
+	fmt.Println(s.EstimateString("apple"), s.EstimateString("banana"), s.EstimateString("cherry"), s.Total())
+	// Output:
+	// 2719 5
+	// 5 1 0 6
+}
+
+func ExampleTopK() {
+	t := sketch.NewTopK(3, sketch.NewCountMinSketch(1024, 4))
+	words := []string{"a", "b", "a", "c", "d", "a", "b", "e", "c", "b", "a", "f"}
+	for _, w := range words {
+		t.Add(w)
+	}
+	for _, hh := range t.List() {
+		fmt.Println(hh.Key, hh.Count)
+	}
+	// Output:
+	// a 4
+	// b 3
+	// c 2
+}
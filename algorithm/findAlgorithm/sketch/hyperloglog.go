@@ -0,0 +1,210 @@
+package sketch
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// HyperLogLog 基数估计
+/*
+统计一个数据流中不同元素的个数（基数），用集合需要保存所有元素，内存与基数成正比。
+HyperLogLog 只用 m = 2^p 个很小的寄存器，就能以约 1.04/sqrt(m) 的标准误差估计基数：
+
+对每个元素计算 64 位哈希值，前 p 位作为寄存器下标，剩下的位中第一个 1 出现的位置记为 rho，
+寄存器保存见过的最大的 rho。直观地说，见过 rho = r 的哈希值，大约需要 2^r 个不同的元素，
+对 m 个寄存器求调和平均可以减小误差：
+	E = alpha * m^2 / sum(2^-M[j])
+基数较小时（E <= 2.5m）很多寄存器还是 0，改用线性计数 E = m * ln(m/V)，V 为值为 0 的寄存器数量。
+
+稀疏表示：基数很小时大部分寄存器都是 0，没必要分配 m 个寄存器，
+只用一个有序数组保存不为 0 的寄存器（下标和值编码到一个 uint32 中），
+非零寄存器超过 m/4 个时（稀疏表示占用的内存与稠密表示相同）转换为稠密表示。
+
+两个参数和种子都相同的 HyperLogLog 可以合并，对应寄存器取最大值，结果与把两个数据流都加入一个 HyperLogLog 相同。
+*/
+
+// 精度范围
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// ErrIncompatible 两个草图的参数或种子不同，不能合并
+var ErrIncompatible = errors.New("sketch: incompatible sketches")
+
+// HyperLogLog 基数估计
+type HyperLogLog struct {
+	p         uint8             // 精度，寄存器数量为 2^p
+	m         uint32            // 寄存器数量
+	seed      uint64            // 哈希种子
+	hasher    hash.XXHashHasher // 带种子的 xxhash
+	sparse    []uint32          // 稀疏表示，按下标排序，每个元素为 下标<<8 | rho
+	registers []uint8           // 稠密表示，为 nil 表示当前是稀疏表示
+	lock      sync.Mutex        // 并发安全
+}
+
+// NewHyperLogLog 创建精度为 p 的 HyperLogLog，种子为 0
+// p 取值范围为 [MinPrecision, MaxPrecision]，超出时取最近的边界，标准误差约为 1.04/sqrt(2^p)
+func NewHyperLogLog(p int) *HyperLogLog {
+	return NewHyperLogLogWithSeed(p, 0)
+}
+
+// NewHyperLogLogWithSeed 创建精度为 p、哈希种子为 seed 的 HyperLogLog
+func NewHyperLogLogWithSeed(p int, seed uint64) *HyperLogLog {
+	p = min(max(p, MinPrecision), MaxPrecision)
+	return &HyperLogLog{
+		p:      uint8(p),
+		m:      1 << p,
+		seed:   seed,
+		hasher: hash.NewXXHash(seed),
+	}
+}
+
+// Precision 返回精度
+func (h *HyperLogLog) Precision() int {
+	return int(h.p)
+}
+
+// IsSparse 是否为稀疏表示
+func (h *HyperLogLog) IsSparse() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.registers == nil
+}
+
+// Add 添加元素
+func (h *HyperLogLog) Add(data []byte) {
+	h.insert(h.hasher.Sum64(data))
+}
+
+// AddString 添加字符串元素
+func (h *HyperLogLog) AddString(s string) {
+	h.insert(h.hasher.Sum64String(s))
+}
+
+// insert 根据哈希值更新寄存器
+func (h *HyperLogLog) insert(x uint64) {
+	// 前 p 位作为下标，剩下的位中第一个 1 的位置作为 rho
+	// 最低位补 1 保证 rho 不超过 64-p+1
+	index := uint32(x >> (64 - h.p))
+	rho := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1)) + 1)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.set(index, rho)
+}
+
+// set 寄存器 index 取 rho 和原值中较大的值
+func (h *HyperLogLog) set(index uint32, rho uint8) {
+	if h.registers != nil {
+		h.registers[index] = max(h.registers[index], rho)
+		return
+	}
+	// 稀疏表示，二分查找下标
+	i, found := slices.BinarySearchFunc(h.sparse, index, func(e, index uint32) int {
+		return int(e>>8) - int(index)
+	})
+	if found {
+		h.sparse[i] = index<<8 | uint32(max(uint8(h.sparse[i]), rho))
+		return
+	}
+	h.sparse = slices.Insert(h.sparse, i, index<<8|uint32(rho))
+	if uint32(len(h.sparse)) > h.m/4 {
+		h.toDense()
+	}
+}
+
+// toDense 转换为稠密表示
+func (h *HyperLogLog) toDense() {
+	h.registers = make([]uint8, h.m)
+	for _, e := range h.sparse {
+		h.registers[e>>8] = uint8(e)
+	}
+	h.sparse = nil
+}
+
+// Count 估计基数
+func (h *HyperLogLog) Count() uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	m := float64(h.m)
+	var sum float64
+	var zeros int
+	if h.registers != nil {
+		for _, r := range h.registers {
+			sum += math.Ldexp(1, -int(r))
+			if r == 0 {
+				zeros++
+			}
+		}
+	} else {
+		// 稀疏表示中不存在的寄存器都是 0，2^-0 = 1
+		zeros = int(h.m) - len(h.sparse)
+		sum = float64(zeros)
+		for _, e := range h.sparse {
+			sum += math.Ldexp(1, -int(uint8(e)))
+		}
+	}
+	estimate := alpha(h.m) * m * m / sum
+	// 基数较小时使用线性计数
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// alpha 修正系数
+func alpha(m uint32) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+// Merge 合并另一个 HyperLogLog，两者的精度和种子必须相同
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.p != other.p || h.seed != other.seed {
+		return ErrIncompatible
+	}
+	if h == other {
+		return nil
+	}
+	// 先复制另一个草图，避免同时持有两把锁
+	other.lock.Lock()
+	sparse := slices.Clone(other.sparse)
+	registers := slices.Clone(other.registers)
+	other.lock.Unlock()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if registers != nil {
+		if h.registers == nil {
+			h.toDense()
+		}
+		for i, r := range registers {
+			h.registers[i] = max(h.registers[i], r)
+		}
+		return nil
+	}
+	for _, e := range sparse {
+		h.set(e>>8, uint8(e))
+	}
+	return nil
+}
+
+// Clear 清空
+func (h *HyperLogLog) Clear() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sparse = nil
+	h.registers = nil
+}
@@ -0,0 +1,138 @@
+package sketch
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/sortAlgorithm/heap"
+)
+
+// Top-K 高频键
+/*
+Count-Min Sketch 只能回答某个键出现了多少次，不知道哪些键出现得最多。
+TopK 在草图之外维护 k 个候选键：每个键添加后用草图的估计值与候选键中最小的次数比较，
+更大就替换掉次数最小的候选键。
+
+找次数最小的候选键使用 heap 包中的最大堆，堆中的元素是 -(次数<<slotBits | 槽位)，
+取负数后最大堆的堆顶就是次数最小的槽位。候选键的次数增加时不去调整堆，
+堆中的次数会比实际的小（过期），取堆顶时发现过期就弹出并按实际次数重新放入，直到堆顶不过期，
+因为次数只增不减，不过期的堆顶一定是次数最小的候选键。
+*/
+
+// 槽位占用的位数，k 最大为 1<<slotBits
+const slotBits = 16
+
+// 堆元素中次数的上限，超过时按上限比较
+const maxHeapCount = 1<<(63-slotBits) - 1
+
+// HeavyHitter 高频键及其估计次数
+type HeavyHitter struct {
+	Key   string
+	Count uint64
+}
+
+// TopK 使用 Count-Min Sketch 估计次数，保留次数最多的 k 个键
+type TopK struct {
+	k      int             // 候选键数量
+	sketch *CountMinSketch // 估计次数
+	keys   []string        // 槽位中的候选键
+	counts []uint64        // 槽位中候选键的估计次数
+	slots  map[string]int  // 候选键所在的槽位
+	heap   *heap.Heap      // 每个槽位一个元素，堆顶为次数最小的槽位
+	lock   sync.Mutex      // 并发安全
+}
+
+// NewTopK 创建保留 k 个高频键的 TopK，使用 sketch 估计次数，k 最大为 65536
+func NewTopK(k int, sketch *CountMinSketch) *TopK {
+	k = min(max(k, 1), 1<<slotBits)
+	return &TopK{
+		k:      k,
+		sketch: sketch,
+		slots:  make(map[string]int, k),
+		heap:   heap.NewHeap(make([]int, k)),
+	}
+}
+
+// encode 将次数和槽位编码为堆元素
+func encode(count uint64, slot int) int {
+	return -int(min(count, maxHeapCount)<<slotBits | uint64(slot))
+}
+
+// decode 从堆元素解码次数和槽位
+func decode(x int) (uint64, int) {
+	x = -x
+	return uint64(x) >> slotBits, x & (1<<slotBits - 1)
+}
+
+// Add 键出现一次，返回估计次数
+func (t *TopK) Add(key string) uint64 {
+	return t.AddCount(key, 1)
+}
+
+// AddCount 键出现 count 次，返回估计次数
+func (t *TopK) AddCount(key string, count uint64) uint64 {
+	estimate := t.sketch.AddString(key, count)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// 已经是候选键，只更新次数，堆中的元素过期
+	if slot, ok := t.slots[key]; ok {
+		t.counts[slot] = estimate
+		return estimate
+	}
+	// 候选键不足 k 个，直接加入
+	if len(t.keys) < t.k {
+		slot := len(t.keys)
+		t.keys = append(t.keys, key)
+		t.counts = append(t.counts, estimate)
+		t.slots[key] = slot
+		t.heap.Push(encode(estimate, slot))
+		return estimate
+	}
+	// 比次数最少的候选键多，替换掉它
+	slot, minCount := t.min()
+	if estimate > minCount {
+		t.heap.Pop()
+		delete(t.slots, t.keys[slot])
+		t.keys[slot] = key
+		t.counts[slot] = estimate
+		t.slots[key] = slot
+		t.heap.Push(encode(estimate, slot))
+	}
+	return estimate
+}
+
+// min 返回次数最少的候选键的槽位和次数，堆顶过期时按实际次数重新放入
+func (t *TopK) min() (int, uint64) {
+	for {
+		count, slot := decode(t.heap.Array[0])
+		actual := min(t.counts[slot], maxHeapCount)
+		if count == actual {
+			return slot, t.counts[slot]
+		}
+		t.heap.Pop()
+		t.heap.Push(encode(actual, slot))
+	}
+}
+
+// Estimate 估计键出现的次数，不是候选键也可以查询
+func (t *TopK) Estimate(key string) uint64 {
+	return t.sketch.EstimateString(key)
+}
+
+// List 返回候选键，按次数从多到少排序，次数相同时按键排序
+func (t *TopK) List() []HeavyHitter {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	list := make([]HeavyHitter, len(t.keys))
+	for slot, key := range t.keys {
+		list[slot] = HeavyHitter{key, t.counts[slot]}
+	}
+	slices.SortFunc(list, func(a, b HeavyHitter) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return list
+}
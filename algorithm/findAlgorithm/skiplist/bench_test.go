@@ -0,0 +1,188 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/avl"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/llrb"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/rbtree"
+)
+
+// 与平衡树对比，平衡树只支持 int64，并且没有值
+// 运行：go test -bench . -benchmem ./algorithm/findAlgorithm/skiplist
+// 单线程时平衡树更快：跳表一次查找的比较次数更多，每次比较都要访问一个新的节点，缓存不友好。
+// 跳表的优势在并发：ConcurrentSkipList 的查找不加锁，写操作只锁住前驱节点，
+// 在多核机器上用 BenchmarkParallel 与加读写锁的红黑树比较，写操作比例越高差距越明显。
+
+// orderedSet 基准测试使用的公共操作
+type orderedSet interface {
+	add(key int64)
+	find(key int64) bool
+	delete(key int64)
+}
+
+type rbSet struct{ *rbtree.RBTree }
+
+func (s rbSet) add(key int64)       { s.Add(key) }
+func (s rbSet) find(key int64) bool { return s.Find(key) != nil }
+func (s rbSet) delete(key int64)    { s.Delete(key) }
+
+type avlSet struct{ *avl.AVLTree }
+
+func (s avlSet) add(key int64)       { s.Add(key) }
+func (s avlSet) find(key int64) bool { return s.Find(key) != nil }
+func (s avlSet) delete(key int64)    { s.Delete(key) }
+
+type llrbSet struct{ *llrb.LLRBTree }
+
+func (s llrbSet) add(key int64)       { s.Add(key) }
+func (s llrbSet) find(key int64) bool { return s.Find(key) != nil }
+func (s llrbSet) delete(key int64)    { s.Delete(key) }
+
+type skipSet struct{ *SkipList[int64, struct{}] }
+
+func (s skipSet) add(key int64)       { s.Put(key, struct{}{}) }
+func (s skipSet) find(key int64) bool { _, ok := s.Get(key); return ok }
+func (s skipSet) delete(key int64)    { s.Delete(key) }
+
+type concurrentSet struct {
+	*ConcurrentSkipList[int64, struct{}]
+}
+
+func (s concurrentSet) add(key int64)       { s.Put(key, struct{}{}) }
+func (s concurrentSet) find(key int64) bool { _, ok := s.Get(key); return ok }
+func (s concurrentSet) delete(key int64)    { s.Delete(key) }
+
+var benchSets = []struct {
+	name string
+	new  func() orderedSet
+}{
+	{"RBTree", func() orderedSet { return rbSet{rbtree.NewRBTree()} }},
+	{"AVLTree", func() orderedSet { return avlSet{avl.NewAVLTree()} }},
+	{"LLRBTree", func() orderedSet { return llrbSet{llrb.NewLLRBTree()} }},
+	{"SkipList", func() orderedSet { return skipSet{NewSkipList[int64, struct{}]()} }},
+	{"ConcurrentSkipList", func() orderedSet { return concurrentSet{NewConcurrentSkipList[int64, struct{}]()} }},
+}
+
+// benchKeys 不重复的随机键
+func benchKeys(n int) []int64 {
+	keys := make([]int64, n)
+	for i, k := range rand.New(rand.NewSource(1)).Perm(n) {
+		keys[i] = int64(k)
+	}
+	return keys
+}
+
+func BenchmarkAdd(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bs := range benchSets {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.new()
+			for i := 0; i < b.N; i++ {
+				if i%len(keys) == 0 {
+					s = bs.new()
+				}
+				s.add(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bs := range benchSets {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.new()
+			for _, key := range keys {
+				s.add(key)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.find(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+func BenchmarkAddDelete(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, bs := range benchSets {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.new()
+			for _, key := range keys[:len(keys)/2] {
+				s.add(key)
+			}
+			b.ResetTimer()
+			// 保持元素数量不变
+			for i := 0; i < b.N; i++ {
+				s.add(keys[(i+len(keys)/2)%len(keys)])
+				s.delete(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+// lockedSet 用读写锁保护的平衡树或跳表
+type lockedSet struct {
+	set  orderedSet
+	lock sync.RWMutex
+}
+
+func (s *lockedSet) add(key int64) {
+	s.lock.Lock()
+	s.set.add(key)
+	s.lock.Unlock()
+}
+
+func (s *lockedSet) find(key int64) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.find(key)
+}
+
+func (s *lockedSet) delete(key int64) {
+	s.lock.Lock()
+	s.set.delete(key)
+	s.lock.Unlock()
+}
+
+// BenchmarkParallel 多个线程同时操作，writes 为写操作（一半插入一半删除）的百分比
+func BenchmarkParallel(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	sets := []struct {
+		name string
+		new  func() orderedSet
+	}{
+		{"RBTree+RWMutex", func() orderedSet { return &lockedSet{set: rbSet{rbtree.NewRBTree()}} }},
+		{"SkipList+RWMutex", func() orderedSet { return &lockedSet{set: skipSet{NewSkipList[int64, struct{}]()}} }},
+		{"ConcurrentSkipList", func() orderedSet { return concurrentSet{NewConcurrentSkipList[int64, struct{}]()} }},
+	}
+	for _, writes := range []int{0, 10, 50} {
+		for _, bs := range sets {
+			b.Run(fmt.Sprintf("%s/writes=%d%%", bs.name, writes), func(b *testing.B) {
+				s := bs.new()
+				for _, key := range keys[:len(keys)/2] {
+					s.add(key)
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					r := rand.New(rand.NewSource(rand.Int63()))
+					for pb.Next() {
+						key := keys[r.Intn(len(keys))]
+						switch op := r.Intn(100); {
+						case op < writes/2:
+							s.add(key)
+						case op < writes:
+							s.delete(key)
+						default:
+							s.find(key)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// 并发跳表（惰性跳表，Herlihy, Lev, Luchangco, Shavit 2006）
+/*
+跳表的插入和删除只修改前驱节点的指针，不同位置的修改互不影响，所以可以只锁住需要修改的节点：
+	查找（Get、Floor、Ceiling、Range）不加锁，只读取原子指针
+	插入时锁住每一层的前驱节点，检查前驱没有被删除、后继没有变化后再链接
+	删除时先锁住节点并标记 marked（逻辑删除），再锁住每一层的前驱节点，从上到下摘除（物理删除）
+
+每个节点有两个标志：
+	fullyLinked  所有层都链接好了，查找到没有 fullyLinked 的节点视为不存在
+	marked       已经被逻辑删除
+节点存在当且仅当 fullyLinked && !marked，所以查找的结果总是某个时刻的真实状态（可线性化）。
+
+加锁总是从第 0 层的前驱开始往上，上层的前驱的键不会更大，所有线程都按键从大到小的顺序加锁，不会死锁。
+检查失败（前驱被删除或者中间插入了新节点）时释放所有锁重新查找。
+
+并发的插入删除会改变后面所有节点的排名，维护跨度需要全局的锁，所以并发跳表不支持 Rank 和 ByRank，
+Len 使用原子计数器，并发修改时只是一个近似值。
+Range 是弱一致的：不会重复或者乱序，但迭代过程中的修改可能看到也可能看不到。
+*/
+
+// cnode 并发跳表的节点
+type cnode[K, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[cnode[K, V]]  // 每一层的后继节点
+	marked      atomic.Bool                    // 已经被逻辑删除
+	fullyLinked atomic.Bool                    // 所有层都已经链接好
+	lock        sync.Mutex                     // 修改 next 时加锁
+	inline      [1]atomic.Pointer[cnode[K, V]] // 只有一层的节点直接使用 inline
+}
+
+// ConcurrentSkipList 细粒度锁的并发跳表，查找不加锁
+type ConcurrentSkipList[K, V any] struct {
+	head    *cnode[K, V] // 头节点，有 MaxLevel 层
+	level   atomic.Int32 // 用到过的最高层数，只增不减，查找从这一层开始
+	len     atomic.Int64
+	compare func(a, b K) int
+	options Options
+}
+
+// NewConcurrentSkipList 使用默认参数创建并发跳表，键按 cmp.Compare 排序
+func NewConcurrentSkipList[K cmp.Ordered, V any]() *ConcurrentSkipList[K, V] {
+	return NewConcurrentSkipListFunc[K, V](cmp.Compare[K], Options{})
+}
+
+// NewConcurrentSkipListFunc 创建按 compare 排序、使用指定参数的并发跳表
+func NewConcurrentSkipListFunc[K, V any](compare func(a, b K) int, options Options) *ConcurrentSkipList[K, V] {
+	options = options.normalize()
+	head := &cnode[K, V]{next: make([]atomic.Pointer[cnode[K, V]], options.MaxLevel)}
+	head.fullyLinked.Store(true)
+	s := &ConcurrentSkipList[K, V]{head: head, compare: compare, options: options}
+	s.level.Store(1)
+	return s
+}
+
+// Len 返回键值对数量
+func (s *ConcurrentSkipList[K, V]) Len() int {
+	return int(s.len.Load())
+}
+
+// raiseLevel 把最高层数提高到 lvl，插入节点之前调用，保证查找时不会漏掉节点所在的层
+func (s *ConcurrentSkipList[K, V]) raiseLevel(lvl int) {
+	for {
+		cur := s.level.Load()
+		if int(cur) >= lvl || s.level.CompareAndSwap(cur, int32(lvl)) {
+			return
+		}
+	}
+}
+
+// find 找到每一层中最后一个键小于 key 的节点和它的后继，返回键等于 key 的节点所在的最高层，没有时返回 -1
+// 只查找用到过的层，更高的层都是空的，不会被插入和删除用到
+func (s *ConcurrentSkipList[K, V]) find(key K, preds, succs *[maxLevelLimit]*cnode[K, V]) int {
+	found := -1
+	x := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		next := x.next[i].Load()
+		for next != nil && s.compare(next.key, key) < 0 {
+			x, next = next, next.next[i].Load()
+		}
+		if found == -1 && next != nil && s.compare(next.key, key) == 0 {
+			found = i
+		}
+		preds[i], succs[i] = x, next
+	}
+	return found
+}
+
+// lockPreds 从第 0 层开始锁住前驱节点，同一个节点只锁一次
+// 检查每一层的前驱没有被删除、后继没有变化（valid 检查后继本身），失败时释放已经加的锁
+func (s *ConcurrentSkipList[K, V]) lockPreds(top int, preds, succs *[maxLevelLimit]*cnode[K, V], valid func(succ *cnode[K, V]) bool) bool {
+	var prev *cnode[K, V]
+	for i := 0; i <= top; i++ {
+		pred, succ := preds[i], succs[i]
+		if pred != prev {
+			pred.lock.Lock()
+			prev = pred
+		}
+		if pred.marked.Load() || pred.next[i].Load() != succ || !valid(succ) {
+			s.unlockPreds(i, preds)
+			return false
+		}
+	}
+	return true
+}
+
+// unlockPreds 释放第 0 层到第 top 层前驱节点的锁
+func (s *ConcurrentSkipList[K, V]) unlockPreds(top int, preds *[maxLevelLimit]*cnode[K, V]) {
+	var prev *cnode[K, V]
+	for i := 0; i <= top; i++ {
+		if preds[i] != prev {
+			preds[i].lock.Unlock()
+			prev = preds[i]
+		}
+	}
+}
+
+// Get 获取键对应的值，不加锁
+func (s *ConcurrentSkipList[K, V]) Get(key K) (value V, ok bool) {
+	x := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		next := x.next[i].Load()
+		for next != nil && s.compare(next.key, key) < 0 {
+			x, next = next, next.next[i].Load()
+		}
+		if next != nil && s.compare(next.key, key) == 0 {
+			if !next.fullyLinked.Load() || next.marked.Load() {
+				return
+			}
+			return *next.value.Load(), true
+		}
+	}
+	return
+}
+
+// Put 添加键值对，键已存在时更新值，返回是否是新添加的键
+func (s *ConcurrentSkipList[K, V]) Put(key K, value V) bool {
+	top := s.options.randomLevel(rand.Float64) - 1
+	s.raiseLevel(top + 1)
+	var preds, succs [maxLevelLimit]*cnode[K, V]
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 {
+			x := succs[found]
+			if !x.marked.Load() {
+				// 另一个线程正在插入，等它链接好
+				for !x.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				x.value.Store(&value)
+				// 更新值的同时节点被删除了，重新插入，保证返回时键存在
+				if !x.marked.Load() {
+					return false
+				}
+			}
+			// 节点正在被删除，等它摘除后重试
+			continue
+		}
+		if !s.lockPreds(top, &preds, &succs, func(succ *cnode[K, V]) bool {
+			return succ == nil || !succ.marked.Load()
+		}) {
+			continue
+		}
+		x := &cnode[K, V]{key: key}
+		if top == 0 {
+			x.next = x.inline[:]
+		} else {
+			x.next = make([]atomic.Pointer[cnode[K, V]], top+1)
+		}
+		x.value.Store(&value)
+		for i := 0; i <= top; i++ {
+			x.next[i].Store(succs[i])
+		}
+		for i := 0; i <= top; i++ {
+			preds[i].next[i].Store(x)
+		}
+		x.fullyLinked.Store(true)
+		s.unlockPreds(top, &preds)
+		s.len.Add(1)
+		return true
+	}
+}
+
+// Delete 删除键值对，返回键是否存在
+func (s *ConcurrentSkipList[K, V]) Delete(key K) bool {
+	var preds, succs [maxLevelLimit]*cnode[K, V]
+	var victim *cnode[K, V]
+	top := -1
+	for {
+		found := s.find(key, &preds, &succs)
+		if victim == nil {
+			// 只有完全链接、还没被删除、并且在最高层被找到的节点才能删除
+			if found == -1 {
+				return false
+			}
+			x := succs[found]
+			if !x.fullyLinked.Load() || x.marked.Load() || len(x.next)-1 != found {
+				return false
+			}
+			x.lock.Lock()
+			if x.marked.Load() {
+				x.lock.Unlock()
+				return false
+			}
+			// 逻辑删除，此后查找不到这个节点，其他线程也不会再修改它的 next
+			x.marked.Store(true)
+			victim, top = x, found
+		}
+		if !s.lockPreds(top, &preds, &succs, func(succ *cnode[K, V]) bool {
+			return succ == victim
+		}) {
+			continue
+		}
+		for i := top; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+		victim.lock.Unlock()
+		s.unlockPreds(top, &preds)
+		s.len.Add(-1)
+		return true
+	}
+}
+
+// live 节点是否存在
+func (x *cnode[K, V]) live() bool {
+	return x.fullyLinked.Load() && !x.marked.Load()
+}
+
+// ceiling 返回第一个键大于等于 key 的存在的节点
+func (s *ConcurrentSkipList[K, V]) ceiling(key K) *cnode[K, V] {
+	x := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		next := x.next[i].Load()
+		for next != nil && s.compare(next.key, key) < 0 {
+			x, next = next, next.next[i].Load()
+		}
+	}
+	x = x.next[0].Load()
+	for x != nil && !x.live() {
+		x = x.next[0].Load()
+	}
+	return x
+}
+
+// Ceiling 返回键大于等于 key 的最小键值对
+func (s *ConcurrentSkipList[K, V]) Ceiling(key K) (k K, v V, ok bool) {
+	if x := s.ceiling(key); x != nil {
+		return x.key, *x.value.Load(), true
+	}
+	return
+}
+
+// Floor 返回键小于等于 key 的最大键值对
+// 第 0 层的前驱节点正在被删除时重新查找
+func (s *ConcurrentSkipList[K, V]) Floor(key K) (k K, v V, ok bool) {
+	var preds, succs [maxLevelLimit]*cnode[K, V]
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 && succs[found].live() {
+			return succs[found].key, *succs[found].value.Load(), true
+		}
+		pred := preds[0]
+		if pred == s.head {
+			return
+		}
+		if pred.live() {
+			return pred.key, *pred.value.Load(), true
+		}
+	}
+}
+
+// Range 按顺序迭代键在 [from, to] 之间的键值对，迭代过程中可以修改（弱一致）
+func (s *ConcurrentSkipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.ceiling(from); x != nil && s.compare(x.key, to) <= 0; x = x.next[0].Load() {
+			if x.live() && !yield(x.key, *x.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// All 按顺序迭代所有键值对，迭代过程中可以修改（弱一致）
+func (s *ConcurrentSkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+			if x.live() && !yield(x.key, *x.value.Load()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package skiplist_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/skiplist"
+)
+
+func ExampleSkipList() {
+	s := skiplist.NewSkipList[int, string]()
+	for _, k := range []int{30, 10, 50, 20, 40} {
+		s.Put(k, fmt.Sprint("v", k))
+	}
+	s.Delete(20)
+	fmt.Println(s.Get(30))
+	fmt.Println(s.Floor(25))
+	fmt.Println(s.Ceiling(25))
+	rank, _ := s.Rank(40)
+	fmt.Println(rank)
+	fmt.Println(s.ByRank(0))
+	for k, v := range s.Range(15, 45) {
+		fmt.Print(k, "=", v, " ")
+	}
+	fmt.Println()
+	// Output:
+	// v30 true
+	// 10 v10 true
+	// 30 v30 true
+	// 2
+	// 10 v10 true
+	// 30=v30 40=v40
+}
+
+func ExampleNewSkipListFunc() {
+	// 不区分大小写排序，每个节点出现在上一层的概率为 1/2，最多 16 层
+	s := skiplist.NewSkipListFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, skiplist.Options{MaxLevel: 16, P: 0.5})
+	s.Put("banana", 1)
+	s.Put("Apple", 2)
+	s.Put("cherry", 3)
+	s.Put("BANANA", 4)
+	for k, v := range s.All() {
+		fmt.Println(k, v)
+	}
+	// Output:
+	// Apple 2
+	// banana 4
+	// cherry 3
+}
+
+func ExampleConcurrentSkipList() {
+	s := skiplist.NewConcurrentSkipList[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < 100; i += 4 {
+				s.Put(i, i*i)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 100; i += 2 {
+		s.Delete(i)
+	}
+	fmt.Println(s.Len())
+	fmt.Println(s.Get(9))
+	fmt.Println(s.Floor(50))
+	// Output:
+	// 50
+	// 81 true
+	// 49 2401 true
+}
@@ -0,0 +1,290 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// 跳表（Pugh 1990）
+/*
+有序链表查找只能从头开始逐个比较，是 O(n) 的。跳表在链表上加了多层"快速通道"：
+	第 0 层是包含所有节点的有序链表
+	每个节点以概率 p 出现在上一层，第 i 层大约有 n*p^i 个节点
+查找时从最高层开始向右走，下一个节点的键大于目标时下降一层，期望 O(log n) 次比较。
+
+与平衡树（红黑树、AVL 树、左倾红黑树）相比：
+	插入和删除只需要修改前驱节点的指针，不需要旋转，实现简单
+	性能靠随机保证，没有最坏情况的保证，但与输入的顺序无关
+	同一层的修改互不影响，容易做成并发的版本（见 ConcurrentSkipList）
+
+每一层的指针还记录了跨度 span：这个指针跨过了多少个第 0 层的节点。
+查找时把经过的跨度加起来就是排名，所以 Rank 和 ByRank 也是 O(log n) 的。
+
+MaxLevel 和 P 可以配置：
+	P 越小，每个节点的平均层数 1/(1-P) 越小，占用内存越少，但每层需要比较的次数 1/P 越多
+	MaxLevel 应当不小于 log(1/P) n，默认 32 层、P=0.25 足够 2^64 个元素（与 Redis 相同）
+*/
+
+// 默认参数
+const (
+	defaultMaxLevel = 32
+	defaultP        = 0.25
+	maxLevelLimit   = 64 // MaxLevel 的上限，查找时的前驱数组可以分配在栈上
+)
+
+// Options 跳表的参数，字段为 0 时使用默认值
+type Options struct {
+	MaxLevel int     // 最大层数，默认 32，不能超过 64
+	P        float64 // 节点出现在上一层的概率，默认 0.25
+}
+
+// normalize 填充默认值并检查是否合法，不合法时 panic
+func (o Options) normalize() Options {
+	if o.MaxLevel == 0 {
+		o.MaxLevel = defaultMaxLevel
+	}
+	if o.P == 0 {
+		o.P = defaultP
+	}
+	if o.MaxLevel < 1 || o.MaxLevel > maxLevelLimit {
+		panic("skiplist: MaxLevel must be in [1, 64]")
+	}
+	if o.P < 0 || o.P >= 1 {
+		panic("skiplist: P must be in (0, 1)")
+	}
+	return o
+}
+
+// randomLevel 随机生成节点的层数，层数为 k 的概率为 (1-p)*p^(k-1)
+func (o Options) randomLevel(float func() float64) int {
+	level := 1
+	for level < o.MaxLevel && float() < o.P {
+		level++
+	}
+	return level
+}
+
+// node 跳表节点
+type node[K, V any] struct {
+	key    K
+	value  V
+	levels []level[K, V]  // 每一层的后继节点和跨度
+	inline [1]level[K, V] // 大部分节点只有一层，直接使用 inline，少一次内存分配和一次指针跳转
+}
+
+// newNode 创建有 lvl 层的节点
+func newNode[K, V any](key K, value V, lvl int) *node[K, V] {
+	x := &node[K, V]{key: key, value: value}
+	if lvl == 1 {
+		x.levels = x.inline[:]
+	} else {
+		x.levels = make([]level[K, V], lvl)
+	}
+	return x
+}
+
+// level 节点在某一层的后继节点，以及到后继节点跨过的第 0 层节点数量
+type level[K, V any] struct {
+	next *node[K, V]
+	span int
+}
+
+// SkipList 跳表实现的有序映射，不是并发安全的
+type SkipList[K, V any] struct {
+	head    *node[K, V] // 头节点，不保存键值对，有 MaxLevel 层
+	level   int         // 当前的最高层数
+	len     int
+	compare func(a, b K) int
+	options Options
+	rng     *rand.Rand
+}
+
+// NewSkipList 使用默认参数创建跳表，键按 cmp.Compare 排序
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFunc[K, V](cmp.Compare[K], Options{})
+}
+
+// NewSkipListWithOptions 使用指定参数创建跳表
+func NewSkipListWithOptions[K cmp.Ordered, V any](options Options) *SkipList[K, V] {
+	return NewSkipListFunc[K, V](cmp.Compare[K], options)
+}
+
+// NewSkipListFunc 创建按 compare 排序的跳表，compare(a, b) 小于 0 表示 a 排在 b 之前
+func NewSkipListFunc[K, V any](compare func(a, b K) int, options Options) *SkipList[K, V] {
+	options = options.normalize()
+	return &SkipList[K, V]{
+		head:    &node[K, V]{levels: make([]level[K, V], options.MaxLevel)},
+		level:   1,
+		compare: compare,
+		options: options,
+		rng:     rand.New(rand.NewPCG(hash.RandomSeed(), hash.RandomSeed())),
+	}
+}
+
+// Len 返回键值对数量
+func (s *SkipList[K, V]) Len() int {
+	return s.len
+}
+
+// findPrev 找到每一层中最后一个键小于 key 的节点，以及这些节点的排名（头节点为 0）
+func (s *SkipList[K, V]) findPrev(key K) (prev [maxLevelLimit]*node[K, V], rank [maxLevelLimit]int) {
+	x, r := s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.compare(x.levels[i].next.key, key) < 0 {
+			r += x.levels[i].span
+			x = x.levels[i].next
+		}
+		prev[i], rank[i] = x, r
+	}
+	return prev, rank
+}
+
+// lowerBound 返回第一个键大于等于 key 的节点的前驱节点及其排名，不分配内存
+func (s *SkipList[K, V]) lowerBound(key K) (*node[K, V], int) {
+	x, r := s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.compare(x.levels[i].next.key, key) < 0 {
+			r += x.levels[i].span
+			x = x.levels[i].next
+		}
+	}
+	return x, r
+}
+
+// Get 获取键对应的值
+func (s *SkipList[K, V]) Get(key K) (value V, ok bool) {
+	x, _ := s.lowerBound(key)
+	if x = x.levels[0].next; x != nil && s.compare(x.key, key) == 0 {
+		return x.value, true
+	}
+	return
+}
+
+// Put 添加键值对，键已存在时更新值，返回是否是新添加的键
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	prev, rank := s.findPrev(key)
+	if x := prev[0].levels[0].next; x != nil && s.compare(x.key, key) == 0 {
+		x.value = value
+		return false
+	}
+	lvl := s.options.randomLevel(s.rng.Float64)
+	// 新增的层，前驱节点为头节点，跨度为整个链表
+	for i := s.level; i < lvl; i++ {
+		prev[i], rank[i] = s.head, 0
+		s.head.levels[i].span = s.len
+	}
+	s.level = max(s.level, lvl)
+	x := newNode(key, value, lvl)
+	for i := 0; i < lvl; i++ {
+		x.levels[i].next = prev[i].levels[i].next
+		prev[i].levels[i].next = x
+		// 前驱到新节点跨过 rank[0]-rank[i]+1 个节点，剩下的跨度属于新节点
+		x.levels[i].span = prev[i].levels[i].span - (rank[0] - rank[i])
+		prev[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+	// 更高层的指针跨过了新节点
+	for i := lvl; i < s.level; i++ {
+		prev[i].levels[i].span++
+	}
+	s.len++
+	return true
+}
+
+// Delete 删除键值对，返回键是否存在
+func (s *SkipList[K, V]) Delete(key K) bool {
+	prev, _ := s.findPrev(key)
+	x := prev[0].levels[0].next
+	if x == nil || s.compare(x.key, key) != 0 {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if prev[i].levels[i].next == x {
+			prev[i].levels[i].span += x.levels[i].span - 1
+			prev[i].levels[i].next = x.levels[i].next
+		} else {
+			prev[i].levels[i].span--
+		}
+	}
+	for s.level > 1 && s.head.levels[s.level-1].next == nil {
+		s.head.levels[s.level-1].span = 0
+		s.level--
+	}
+	s.len--
+	return true
+}
+
+// Ceiling 返回键大于等于 key 的最小键值对
+func (s *SkipList[K, V]) Ceiling(key K) (k K, v V, ok bool) {
+	x, _ := s.lowerBound(key)
+	if x = x.levels[0].next; x != nil {
+		return x.key, x.value, true
+	}
+	return
+}
+
+// Floor 返回键小于等于 key 的最大键值对
+func (s *SkipList[K, V]) Floor(key K) (k K, v V, ok bool) {
+	x, _ := s.lowerBound(key)
+	if next := x.levels[0].next; next != nil && s.compare(next.key, key) == 0 {
+		return next.key, next.value, true
+	}
+	if x != s.head {
+		return x.key, x.value, true
+	}
+	return
+}
+
+// Rank 返回键的排名，从 0 开始，键不存在时返回 false
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	x, r := s.lowerBound(key)
+	if x = x.levels[0].next; x != nil && s.compare(x.key, key) == 0 {
+		return r, true
+	}
+	return -1, false
+}
+
+// ByRank 返回排名为 rank 的键值对，rank 从 0 开始
+func (s *SkipList[K, V]) ByRank(rank int) (k K, v V, ok bool) {
+	if rank < 0 || rank >= s.len {
+		return
+	}
+	// 跨度之和等于 rank+1 的节点就是要找的节点
+	x, r := s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && r+x.levels[i].span <= rank+1 {
+			r += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if r == rank+1 {
+			break
+		}
+	}
+	return x.key, x.value, true
+}
+
+// Range 按顺序迭代键在 [from, to] 之间的键值对
+// 迭代过程中不能修改跳表
+func (s *SkipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		x, _ := s.lowerBound(from)
+		for x = x.levels[0].next; x != nil && s.compare(x.key, to) <= 0; x = x.levels[0].next {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// All 按顺序迭代所有键值对
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.levels[0].next; x != nil; x = x.levels[0].next {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
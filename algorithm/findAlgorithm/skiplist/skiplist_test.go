@@ -0,0 +1,231 @@
+package skiplist
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// checkSkipList 检查每一层的顺序和跨度
+func checkSkipList(t *testing.T, s *SkipList[int, int]) {
+	t.Helper()
+	rank := map[*node[int, int]]int{s.head: 0}
+	n := 0
+	for x := s.head.levels[0].next; x != nil; x = x.levels[0].next {
+		n++
+		rank[x] = n
+	}
+	if n != s.len {
+		t.Fatalf("len = %d, want %d", s.len, n)
+	}
+	for x := s.head; x != nil; x = x.levels[0].next {
+		for i := 0; i < min(len(x.levels), s.level); i++ {
+			next := x.levels[i].next
+			want := n - rank[x]
+			if next != nil {
+				want = rank[next] - rank[x]
+				if next.key <= x.key && x != s.head {
+					t.Fatalf("level %d: %d before %d", i, x.key, next.key)
+				}
+			}
+			if x.levels[i].span != want {
+				t.Fatalf("span of %d at level %d = %d, want %d", x.key, i, x.levels[i].span, want)
+			}
+		}
+	}
+}
+
+// 与排好序的切片对比
+func TestSkipListRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, options := range []Options{{}, {MaxLevel: 1}, {MaxLevel: 4, P: 0.5}, {P: 0.9}} {
+		s := NewSkipListWithOptions[int, int](options)
+		var keys []int
+		values := map[int]int{}
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(300)
+			j, found := slices.BinarySearch(keys, key)
+			switch op := r.Intn(6); {
+			case op < 3:
+				if s.Put(key, i) == found {
+					t.Fatalf("Put(%d) returned %v", key, found)
+				}
+				if !found {
+					keys = slices.Insert(keys, j, key)
+				}
+				values[key] = i
+			case op < 5:
+				if s.Delete(key) != found {
+					t.Fatalf("Delete(%d) returned %v", key, !found)
+				}
+				if found {
+					keys = slices.Delete(keys, j, j+1)
+					delete(values, key)
+				}
+			default:
+				if v, ok := s.Get(key); ok != found || v != values[key] {
+					t.Fatalf("Get(%d) = %d, %v", key, v, ok)
+				}
+				if rank, ok := s.Rank(key); ok != found || (found && rank != j) {
+					t.Fatalf("Rank(%d) = %d, %v, want %d", key, rank, ok, j)
+				}
+				if k, _, ok := s.Ceiling(key); ok != (j < len(keys)) || (ok && k != keys[j]) {
+					t.Fatalf("Ceiling(%d) = %d, %v", key, k, ok)
+				}
+				floor := j - 1
+				if found {
+					floor = j
+				}
+				if k, _, ok := s.Floor(key); ok != (floor >= 0) || (ok && k != keys[floor]) {
+					t.Fatalf("Floor(%d) = %d, %v", key, k, ok)
+				}
+				if len(keys) > 0 {
+					k, v, _ := s.ByRank(j % len(keys))
+					if k != keys[j%len(keys)] || v != values[k] {
+						t.Fatalf("ByRank(%d) = %d", j%len(keys), k)
+					}
+				}
+			}
+			checkSkipList(t, s)
+		}
+		var got []int
+		for k := range s.Range(100, 199) {
+			got = append(got, k)
+		}
+		lo, hi := sort.SearchInts(keys, 100), sort.SearchInts(keys, 200)
+		if !slices.Equal(got, keys[lo:hi]) {
+			t.Fatalf("Range = %v, want %v", got, keys[lo:hi])
+		}
+	}
+}
+
+// 多个线程同时插入、删除和查找，结束后与每个键最后的操作对比
+func TestConcurrentSkipList(t *testing.T) {
+	s := NewConcurrentSkipList[int, int]()
+	const workers, keys = 8, 500
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 5000; i++ {
+				// 每个线程负责 key%workers == w 的键，其他键只读，所以结束时状态是确定的
+				key := r.Intn(keys/workers)*workers + w
+				switch r.Intn(4) {
+				case 0, 1:
+					s.Put(key, key*10)
+				case 2:
+					s.Delete(key)
+				default:
+					other := r.Intn(keys)
+					if v, ok := s.Get(other); ok && v != other*10 {
+						t.Errorf("Get(%d) = %d", other, v)
+					}
+					if k, _, ok := s.Floor(other); ok && k > other {
+						t.Errorf("Floor(%d) = %d", other, k)
+					}
+				}
+			}
+		}()
+	}
+	// 同时迭代，结果必须有序
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			prev := -1
+			for k := range s.All() {
+				if k <= prev {
+					t.Errorf("All: %d after %d", k, prev)
+				}
+				prev = k
+			}
+		}
+	}()
+	wg.Wait()
+
+	// 单线程重放同样的操作得到期望的结果
+	want := NewSkipList[int, int]()
+	for w := 0; w < workers; w++ {
+		r := rand.New(rand.NewSource(int64(w)))
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(keys/workers)*workers + w
+			switch r.Intn(4) {
+			case 0, 1:
+				want.Put(key, key*10)
+			case 2:
+				want.Delete(key)
+			default:
+				r.Intn(keys)
+			}
+		}
+	}
+	if s.Len() != want.Len() {
+		t.Fatalf("Len = %d, want %d", s.Len(), want.Len())
+	}
+	var got, wantKeys []int
+	for k := range s.All() {
+		got = append(got, k)
+	}
+	for k := range want.All() {
+		wantKeys = append(wantKeys, k)
+	}
+	if !slices.Equal(got, wantKeys) {
+		t.Fatalf("keys = %v, want %v", got, wantKeys)
+	}
+	// 每一层都是有序的，并且只包含存在的节点
+	for i := range s.head.next {
+		prev := -1
+		for x := s.head.next[i].Load(); x != nil; x = x.next[i].Load() {
+			if x.key <= prev || !x.live() {
+				t.Fatalf("level %d: bad node %d", i, x.key)
+			}
+			if _, ok := s.Get(x.key); !ok {
+				t.Fatalf("level %d: %d not found", i, x.key)
+			}
+			prev = x.key
+		}
+	}
+}
+
+// 所有线程争抢同样的几个键，结束后结构必须完整，Len 与实际数量一致
+func TestConcurrentSkipListContention(t *testing.T) {
+	s := NewConcurrentSkipListFunc[int, int](func(a, b int) int { return a - b }, Options{MaxLevel: 4, P: 0.5})
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 20000; i++ {
+				key := r.Intn(8)
+				if r.Intn(2) == 0 {
+					s.Put(key, key)
+				} else {
+					s.Delete(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	n := 0
+	for k, v := range s.All() {
+		if k != v {
+			t.Fatalf("%d = %d", k, v)
+		}
+		n++
+	}
+	if n != s.Len() {
+		t.Fatalf("Len = %d, want %d", s.Len(), n)
+	}
+	for i := range s.head.next {
+		for x := s.head.next[i].Load(); x != nil; x = x.next[i].Load() {
+			if !x.live() {
+				t.Fatalf("level %d: deleted node %d still linked", i, x.key)
+			}
+		}
+	}
+}
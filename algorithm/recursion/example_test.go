@@ -0,0 +1,25 @@
+package recursion_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/recursion"
+)
+
+func ExampleFibonacci() {
+	fmt.Println(recursion.Fibonacci(9))
+	fmt.Println(recursion.FibonacciTail(5, 1, 1))
+	// Output:
+	// 21
+	// 8
+}
+
+func ExampleFactorial() {
+	fmt.Println(recursion.Factorial(5))
+	fmt.Println(recursion.FactorialTail(5, 1))
+	fmt.Println(recursion.Sum(100))
+	// Output:
+	// 120
+	// 120
+	// 5050
+}
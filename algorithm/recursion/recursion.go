@@ -0,0 +1,58 @@
+package recursion
+
+// Fibonacci 递归实现菲波那切数列，第1位为0，第2位为1
+func Fibonacci(num int) int {
+	if num == 1 {
+		return 0
+	} else if num == 2 {
+		return 1
+	}
+	return Fibonacci(num-2) + Fibonacci(num-1)
+}
+
+// Factorial 求阶乘常规递归算法，使用栈保存，计算过程先进后出，需要消耗大量的栈空间，如果没有
+// 终止条件，栈的层数无线增加，会栈溢出
+func Factorial(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return Factorial(n-1) * n
+}
+
+// FactorialTail 使用尾递归，节约堆栈的空间，减少堆栈层数
+// 函数在调用自身后直接传回其值，而不对其再加运算，效率将会极大的提高。
+// 如果一个函数中所有递归形式的调用都出现在函数的末尾，我们称这个递归函数是尾递归的
+// 当递归调用是整个函数体中最后执行的语句且它的返回值不属于表达式的一部分时，这个递归调用就是尾递归
+// 调用时 a 传 1
+func FactorialTail(n int, a int) int {
+	if n <= 1 {
+		return a
+	}
+	return FactorialTail(n-1, n*a)
+}
+
+// FibonacciTail 尾递归求解斐波拉切数列,n为第几位斐波拉切数
+// 所有的结果都由a，b = 1, 1初始值累加而来
+// 当 n=5 的递归过程如下:
+/* F(5,1,1)
+F(4,1,1+1)=F(4,1,2)
+F(3,2,1+2)=F(3,2,3)
+F(2,3,2+3)=F(2,3,5)
+F(1,5,3+5)=F(1,5,8)
+F(0,8,5+8)=F(0,8,13)
+8
+*/
+func FibonacciTail(n, a, b int) int {
+	if n == 0 {
+		return a
+	}
+	return FibonacciTail(n-1, b, a+b)
+}
+
+// Sum 递归求 1+2+...+num
+func Sum(num int) int {
+	if num <= 0 {
+		return 0
+	}
+	return Sum(num-1) + num
+}
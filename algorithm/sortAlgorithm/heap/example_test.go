@@ -0,0 +1,27 @@
+package heap_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/sortAlgorithm/heap"
+)
+
+// 普通堆排序
+// 根据最大堆，堆顶元素一直是最大的元素特征，可以实现堆排序。
+// 先构建一个最大堆，然后依次把根节点元素 pop 出即可
+func ExampleHeap() {
+	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	// 构建最大堆
+	h := heap.NewHeap(list)
+	for _, v := range list {
+		h.Push(v)
+	}
+	// 将元素移除
+	for range list {
+		h.Pop()
+	}
+	// 打印排序后的值
+	fmt.Println(list)
+	// Output:
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package sorting
 
 /*
 冒泡排序：
@@ -50,12 +48,3 @@ func BubbleSortFlag(num []int) {
 		}
 	}
 }
-
-func main() {
-	// 验证
-	num := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	BubbleSort(num)
-	fmt.Println(num)
-	BubbleSortFlag(num)
-	fmt.Println(num)
-}
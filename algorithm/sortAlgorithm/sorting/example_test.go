@@ -0,0 +1,155 @@
+package sorting_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/sortAlgorithm/sorting"
+)
+
+func ExampleBubbleSort() {
+	num := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.BubbleSort(num)
+	fmt.Println(num)
+	sorting.BubbleSortFlag(num)
+	fmt.Println(num)
+	// Output:
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
+
+func ExampleRotation() {
+	array := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	sorting.Rotation(array, 3)
+	fmt.Println(array)
+	// Output:
+	// [4 5 6 7 8 9 10 1 2 3]
+}
+
+func ExampleInsertSort() {
+	list := []int{5}
+	sorting.InsertSort(list)
+	fmt.Println(list)
+
+	list1 := []int{5, 9}
+	sorting.InsertSort(list1)
+	fmt.Println(list1)
+
+	list2 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.InsertSort(list2)
+	fmt.Println(list2)
+	// Output:
+	// [5]
+	// [5 9]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
+
+func ExampleSelectSort() {
+	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.SelectSort(list)
+	fmt.Println(list)
+
+	list = []int{5}
+	sorting.SelectGoodSort(list)
+	fmt.Println(list)
+
+	list1 := []int{5, 9}
+	sorting.SelectGoodSort(list1)
+	fmt.Println(list1)
+
+	list2 := []int{5, 9, 1}
+	sorting.SelectGoodSort(list2)
+	fmt.Println(list2)
+
+	list3 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.SelectGoodSort(list3)
+	fmt.Println(list3)
+	// Output:
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [5]
+	// [5 9]
+	// [1 5 9]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
+
+func ExampleShellSort() {
+	list := []int{5}
+	sorting.ShellSort(list)
+	fmt.Println(list)
+
+	list1 := []int{5, 9}
+	sorting.ShellSort(list1)
+	fmt.Println(list1)
+
+	list2 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.ShellSort(list2)
+	fmt.Println(list2)
+	// Output:
+	// [5]
+	// [5 9]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
+
+func ExampleMergeSort() {
+	fmt.Println("自上向底排序")
+	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.MergeSort(list, 0, len(list))
+	fmt.Println(list)
+
+	fmt.Println("自底向上排序")
+	list = []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.MergeSort2(list, 0, len(list))
+	fmt.Println(list)
+
+	fmt.Println("优化算法后排序")
+	list = []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3, 45, 67, 2, 5, 24, 56, 34, 24, 56, 2, 2, 21, 4, 1, 4, 7, 9}
+	sorting.MergeSort3(list, len(list))
+	fmt.Println(list)
+	// Output:
+	// 自上向底排序
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// 自底向上排序
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// 优化算法后排序
+	// [1 1 2 2 2 3 4 4 4 5 5 6 6 6 7 8 9 9 14 21 24 24 25 34 45 49 56 56 67]
+}
+
+func ExampleQuickSort() {
+	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort(list, 0, len(list)-1)
+	fmt.Println(list)
+
+	list1 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort1(list1, 0, len(list1)-1)
+	fmt.Println(list1)
+
+	list2 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort2(list2, 0, len(list2)-1)
+	fmt.Println(list2)
+
+	list3 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort3(list3, 0, len(list3)-1)
+	fmt.Println(list3)
+
+	list4 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort5(list4)
+	fmt.Println(list4)
+
+	list5 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.QuickSort6(list5)
+	fmt.Println(list5)
+	// Output:
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
+
+func ExampleHeapSort() {
+	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
+	sorting.HeapSort(list)
+	fmt.Println(list)
+	// Output:
+	// [1 3 4 5 6 6 6 8 9 14 25 49]
+}
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package sorting
 
 /*
 翻转算法，也叫手摇算法，主要用来对数组两部分进行位置互换
@@ -19,17 +17,15 @@ import "fmt"
 3. 整体逆序：[1234567abcde]
 */
 
+// Rotation 手摇算法，将数组的前 n 个元素与后面的元素交换位置
 func Rotation(array []int, n int) {
 	// 分成2部分 1-mid/mid-r
 	l := 0
 	mid := n
 	r := len(array)-1
 	reverse(array, l, mid-1)
-	fmt.Println("拍完左边后：", array)
 	reverse(array, mid, r)
-	fmt.Println("拍完右边后：", array)
 	reverse(array, l , r)
-	fmt.Println("整体拍完后：", array)
 }
 
 // 翻转
@@ -42,9 +38,3 @@ func reverse(array []int, l , r int) {
 		r--
 	}
 }
-
-func main() {
-	// 测试
-	array := []int{1,2,3,4,5,6,7,8,9,10}
-	Rotation(array, 3)
-}
\ No newline at end of file
@@ -0,0 +1,77 @@
+package sorting
+
+/*
+自底向上堆排序,优化后的堆排序
+自底向上堆排序，仅仅将构建堆的时间复杂度从 O(nlogn) 改进到 O(n)，其他保持不变。
+
+这种堆排序，不再每次都将元素添加到尾部，然后上浮翻转，而是在混乱堆的基础上，从底部向上逐层进行下沉操作，下沉操作比较的次数会减少。步骤如下：
+
+1、先对最底部的所有非叶子节点进行下沉，即这些非叶子节点与它们的儿子节点比较，较大的儿子和父亲交换位置。
+2、接着从次二层开始的非叶子节点重复这个操作，直到到达根节点最大堆就构建好了。
+从底部开始，向上推进，所以这种堆排序又叫自底向上的堆排序。
+
+自底向上构建堆的时间复杂度是：O(n)。证明如下：
+第 k 层的非叶子节点的数量为 n/2^k，每一个非叶子节点下沉的最大次数为其子孙的层数：k，而树的层数为 logn 层，那么总
+翻转的次数计算结果为：2n 次。也就是构建堆的时间复杂度为：O(n)
+*/
+
+// 下沉操作，需要下沉的元素时 array[start]，参数 count 只要用来判断是否到堆底，使得下沉结束
+func sift(array []int, start, count int) {
+	// 父亲结点
+	root := start
+	// 左儿子
+	child := root*2 + 1
+	// 如果有下一代
+	for child < count {
+		// 右儿子比左儿子大，那么要翻转的儿子改为右儿子
+		if count - child > 1 && array[child] < array[child+1] {
+			child++
+		}
+		//  父亲节点比儿子小，那么将父亲和儿子位置交换
+		if array[root] < array[child] {
+			array[root], array[child] = array[child], array[root]
+			// 继续下沉
+			root = child
+			child = root*2 + 1
+		}else {
+			return
+		}
+	}
+}
+
+// HeapSort 自底向上堆排序,用非递归的形式来实现，非递归相对容易理解
+// 先自底向上构建最大堆，再移除堆元素实现堆排序
+func HeapSort(array []int) {
+	// 堆元素的数量
+	count := len(array)
+	// 最底层的叶子节点下标，该节点位置不定，但是该叶子节点左边的节点都是叶子节点(完全二叉树)
+	start := count/2 + 1
+	// 最后元素的下标
+	end := count-1
+	// 从最底层开始，逐一对结点进行下沉
+	for start >= 0 {
+		sift(array, start, count)
+		start-- // 表示左偏移一个节点，如果该层没有节点了，那么表示到了上一层的最右边
+	}
+	// 下沉结束了，现在要来排序了
+	// 元素大于2个的最大堆才可以移除
+	for end > 0 {
+		// 将堆顶元素与队尾元素互换，表示移除最大堆元素，将最大放在最尾部
+		array[end], array[0] = array[0], array[end]
+		// 对堆顶进行下沉操作,因为移除后还是需要保持二叉最大堆的特性
+		sift(array, 0, end)
+		// 一直移除堆顶yuans
+		end--
+	}
+}
+
+/*
+根据最大堆的时间复杂度分析，从堆构建到移除最坏和最好的时间复杂度：O(nlogn)，这也是堆排序的最好和最坏的时间复杂度。
+这样实现的堆排序是普通的堆排序，性能不是最优的。
+因为一开始会认为堆是空的，每次添加元素都需要添加到尾部，然后向上翻转，需要用 Heap.Size 来记录堆的大小增长，
+这种堆构建，可以认为是非原地的构建，影响了效率。
+
+美国籍计算机科学家 R. W. Floyd 改进的原地自底向上的堆排序，不会从空堆开始，而是把待排序的数列当成一个混乱的最大堆，
+从底层逐层开始，对元素进行下沉操作，一直恢复最大堆的特征，直到根节点。
+将构建堆的时间复杂度从 O(nlogn) 降为 O(n)，总的堆排序时间复杂度从 O(2nlogn) 改进到 O(n+nlogn)
+*/
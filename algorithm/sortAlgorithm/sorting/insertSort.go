@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package sorting
 
 /*
 插入排序：
@@ -18,6 +16,7 @@ import "fmt"
 一般很少使用冒泡、直接选择，直接插入排序算法，因为在有大量元素的无序数列下，这些算法的效率都很低。
 */
 
+// InsertSort 直接插入排序
 func InsertSort(num []int) {
 	lenth := len(num) // 获取长度
 	// 进行N-1轮迭代
@@ -34,19 +33,3 @@ func InsertSort(num []int) {
 		}
 	}
 }
-
-
-func main() {
-	// 测试
-	list := []int{5}
-	InsertSort(list)
-	fmt.Println(list)
-
-	list1 := []int{5, 9}
-	InsertSort(list1)
-	fmt.Println(list1)
-
-	list2 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	InsertSort(list2)
-	fmt.Println(list2)
-}
\ No newline at end of file
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package sorting
 
 /*
 归并排序：
@@ -70,9 +68,9 @@ func MergeSort2(array []int, begin, end int) {
 			var lo = i				 // 第一个有序数组的上界
 			var mid = lo + step		 // 第一个有序数组的下界，第二个有序数组的上界
 			var hi = lo + (step << 1)  // 第二个有序数组的下界
-			// 不存在第二个数组，直接返回
-			if mid > end {
-				return
+			// 不存在第二个数组，这一轮归并结束
+			if mid >= end {
+				break
 			}
 			// 第二个数组长度不够
 			if hi > end {
@@ -135,24 +133,6 @@ func merge(array []int, begin, mid, end int) {
 相同元素位置不变，可以使用归并排序
 */
 
-// InsertSort 直接插入排序
-func InsertSort(list []int) {
-	n := len(list)
-	// 进行 N-1 轮迭代
-	for i := 1; i <= n-1; i++ {
-		deal := list[i] // 待排序的数
-		j := i - 1		// 待排序的数左边的第一个数的位置
-		// 如果第一次比较，比左边的已排好序的第一个数小，那么进入处理
-		if deal < list[j] {
-			// 一直往左边找，比待排序大的数都往后挪，腾空位给待排序插入
-			for ; j >= 0 && deal < list[j]; j-- {
-				list[j+1] = list[j] // 某数后移，给待排序留空位
-			}
-			list[j+1] = deal // 结束了，待排序的数插入空位
-		}
-	}
-}
-
 // 原地归并排序
 func merges(array []int, begin, mid, end int) {
 	// 三个下标，将数组 array[begin,mid] 和 array[mid,end-1]进行原地归并
@@ -187,18 +167,8 @@ func rotation(array []int, l, mid, r int) {
 	reverse(array, l , r)
 }
 
-// 翻转
-func reverse(array []int, l , r int) {
-	for l < r {
-		// 左右互换
-		array[l], array[r] = array[r], array[l]
-		// 翻转
-		l++
-		r--
-	}
-}
-
 // MergeSort3 自底向上归并排序优化版本
+// 小数组使用 insertSort.go 中的直接插入排序，原地归并使用 flipAlgorithm.go 中的翻转
 func MergeSort3(array []int, n int) {
 	// 按照三个元素为一组进行小数组排序，使用直接插入排序
 	blockSize := 3
@@ -223,50 +193,3 @@ func MergeSort3(array []int, n int) {
 		blockSize *= 2
 	}
 }
-
-
-
-func main() {
-	// 测试
-	fmt.Println("自上向底排序-----------------------------------------")
-	list := []int{5}
-	MergeSort(list, 0, len(list))
-	fmt.Println(list)
-
-	list1 := []int{5, 9}
-	MergeSort(list1, 0, len(list1))
-	fmt.Println(list1)
-
-	list2 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	MergeSort(list2, 0, len(list2))
-	fmt.Println(list2)
-	fmt.Println("自底向上排序-----------------------------------------")
-	list = []int{5}
-	MergeSort2(list, 0, len(list))
-	fmt.Println(list)
-
-	list1 = []int{5, 9}
-	MergeSort2(list1, 0, len(list1))
-	fmt.Println(list1)
-
-	list2 = []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	MergeSort2(list2, 0, len(list2))
-	fmt.Println(list2)
-	fmt.Println("优化算法后排序-----------------------------------------")
-	list = []int{5}
-	MergeSort3(list, len(list))
-	fmt.Println(list)
-
-	list1 = []int{5, 9}
-	MergeSort3(list1, len(list1))
-	fmt.Println(list1)
-
-	list2 = []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	MergeSort3(list2, len(list2))
-	fmt.Println(list2)
-
-	list3 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3, 45, 67, 2, 5, 24, 56, 34, 24, 56, 2, 2, 21, 4, 1, 4, 7, 9}
-	MergeSort3(list3, len(list3))
-	fmt.Println(list3)
-}
-
@@ -1,7 +1,6 @@
-package main
+package sorting
 
 import (
-	"fmt"
 	"sync"
 )
 
@@ -94,24 +93,9 @@ func QuickSort(array []int, begin, end int) {
 // 在小规模数组的情况下，直接插入排序的效率最好，当快速排序递归部分进入小数组范围，
 // 可以切换成直接插入排序
 
-// InsertSort 改进：当数组规模小时使用直接插入排序
-func InsertSort(list []int) {
-	n := len(list)
-	// 进行N-1轮迭代
-	for i := 1; i <= n-1; i++ {
-		deal := list[i] // 待排序的数
-		j := i - 1      // 待排序的数左边的第一个数的位置
-		// 如果第一次比较，比左边的已排好序的第一个数小，那么进入处理
-		for deal > list[j] {
-			// 一直往左边找，比待排序大的数都往后挪，腾空位给待排序插入
-			for ; j >= 0 && deal < list[j]; j-- {
-				list[j+1] = list[j] // 某数后移，给待排序留空位
-			}
-			list[j+1] = deal // 结束了，待排序的数插入空位
-		}
-	}
-}
+// 直接插入排序复用 insertSort.go 中的 InsertSort
 
+// QuickSort1 小规模数组使用直接插入排序的快速排序
 func QuickSort1(array []int, begin, end int) {
 	if begin < end {
 		// 当数组小于 4 时使用直接插入排序
@@ -191,17 +175,19 @@ func QuickSort2(array []int, begin, end int) {
 
 // QuickSort3 伪尾递归快速排序
 func QuickSort3(array []int, begin, end int) {
-	// 进行切分
-	loc := partition(array, begin, end)
-	// 那边元素少先排那边
-	if loc-begin < end-loc {
-		// 先排序左边
-		QuickSort3(array, begin, loc-1)
-		begin = loc + 1
-	} else {
-		// 先排序右边
-		QuickSort3(array, loc+1, end)
-		end = loc - 1
+	for begin < end {
+		// 进行切分
+		loc := partition(array, begin, end)
+		// 那边元素少先排那边
+		if loc-begin < end-loc {
+			// 先排序左边
+			QuickSort3(array, begin, loc-1)
+			begin = loc + 1
+		} else {
+			// 先排序右边
+			QuickSort3(array, loc+1, end)
+			end = loc - 1
+		}
 	}
 }
 
@@ -209,33 +195,33 @@ func QuickSort3(array []int, begin, end int) {
 // 非递归写法仅仅是将之前的递归栈转化为自己维持的手工栈
 // 使用人工栈替代递归的程序栈，换汤不换药，速度并没有什么变化，但是代码可读性降低。
 
-// LinkStack 定义LinkStack 链表栈，后进先出
-type LinkStack struct {
-	root *LinkNode  // 链表起点
+// linkStack 辅助排序的链表栈，后进先出
+type linkStack struct {
+	root *linkNode  // 链表起点
 	size int        // 栈的元素数量
 	lock sync.Mutex // 为了并发安全使用的锁
 }
 
-// LinkNode 定义链表节点
-type LinkNode struct {
-	Next  *LinkNode
+// linkNode 定义链表节点
+type linkNode struct {
+	Next  *linkNode
 	Value int
 }
 
 // Push 入栈
-func (stack *LinkStack) Push(v int) {
+func (stack *linkStack) Push(v int) {
 	stack.lock.Lock()
 	defer stack.lock.Unlock()
 	// 如果栈顶为空，那么增加节点
 	if stack.size == 0 {
-		stack.root = new(LinkNode)
+		stack.root = new(linkNode)
 		stack.root.Value = v
 	} else {
 		// 否则新元素插入链表的头部
 		// 原来的链表
 		preNode := stack.root
 		// 新节点
-		newNode := new(LinkNode)
+		newNode := new(linkNode)
 		newNode.Value = v
 		// 原来的链表链接到新元素后面
 		newNode.Next = preNode
@@ -247,7 +233,7 @@ func (stack *LinkStack) Push(v int) {
 }
 
 // Pop 出栈
-func (stack *LinkStack) Pop() int {
+func (stack *linkStack) Pop() int {
 	stack.lock.Lock()
 	defer stack.lock.Unlock()
 
@@ -266,14 +252,18 @@ func (stack *LinkStack) Pop() int {
 }
 
 // IsEmpty 栈是否为空
-func (stack *LinkStack) IsEmpty() bool {
+func (stack *linkStack) IsEmpty() bool {
 	return stack.size == 0
 }
 
 // QuickSort5 非递归快速排序
 func QuickSort5(array []int) {
+	// 少于两个元素，不需要排序
+	if len(array) < 2 {
+		return
+	}
 	// 人工栈
-	helpStack := new(LinkStack)
+	helpStack := new(linkStack)
 	// 第一次初始化栈，推入下标0，len(array)-1，表示第一次对全数组范围切分
 	helpStack.Push(len(array) - 1)
 	helpStack.Push(0)
@@ -306,8 +296,12 @@ func QuickSort5(array []int) {
 
 // QuickSort6 非递归版本优化
 func QuickSort6(array []int) {
+	// 少于两个元素，不需要排序
+	if len(array) < 2 {
+		return
+	}
 	// 人工栈
-	helpStack := new(LinkStack)
+	helpStack := new(linkStack)
 	// 第一次初始化栈，推入下标0，len(array)-1，表示第一次对全数组范围切分
 	helpStack.Push(len(array) - 1)
 	helpStack.Push(0)
@@ -356,34 +350,6 @@ func QuickSort6(array []int) {
 	}
 }
 
-// 测试
-func main() {
-	list := []int{5}
-	QuickSort(list, 0, len(list)-1)
-	fmt.Println(list)
-
-	list1 := []int{5, 9}
-	QuickSort(list1, 0, len(list1)-1)
-	fmt.Println(list1)
-
-	list2 := []int{5, 9, 1}
-	QuickSort(list2, 0, len(list2)-1)
-	fmt.Println(list2)
-
-	list3 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	QuickSort(list3, 0, len(list3)-1)
-	fmt.Println(list3)
-
-	list4 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	QuickSort5(list4)
-	fmt.Println(list4)
-
-	list5 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	QuickSort6(list5)
-	fmt.Println(list5)
-}
-
-
 /*
 补充：内置库使用快速排序的原因
 	首先堆排序，归并排序最好最坏时间复杂度都是：O(nlogn)，
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package sorting
 
 /*
 选择排序：
@@ -49,7 +47,7 @@ func SelectGoodSort(num []int) {
 		minIndex := i // 最小值下标
 		maxIndex := i // 最大值下标
 		// 在这一轮迭代中要找到最大值和最小值的下标
-		for j := i + 1; j < lenth; j++ {
+		for j := i + 1; j < lenth-i; j++ {
 			// 找到最大值下标
 			if num[j] > num[maxIndex] {
 				maxIndex = j // 这一轮这个是大的，直接 continue
@@ -80,30 +78,3 @@ func SelectGoodSort(num []int) {
 
 	}
 }
-
-func main() {
-	// 测试
-	list := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	SelectSort(list)
-	fmt.Println(list)
-
-	list = []int{5}
-	SelectGoodSort(list)
-	fmt.Println(list)
-
-	list1 := []int{5, 9}
-	SelectGoodSort(list1)
-	fmt.Println(list1)
-
-	list2 := []int{5, 9, 1}
-	SelectGoodSort(list2)
-	fmt.Println(list2)
-
-	list3 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6, 3}
-	SelectGoodSort(list3)
-	fmt.Println(list3)
-
-	list4 := []int{5, 9, 1, 6, 8, 14, 6, 49, 25, 4, 6}
-	SelectGoodSort(list4)
-	fmt.Println(list4)
-}
@@ -1,129 +1,267 @@
-package main
+package array
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 )
 
-// 自定义实现一个可变成数组，类似go中的slice
+// 自定义实现一个可变长数组，类似go中的slice
+/*
+用一个满容量、满大小的切片充当固定数组，len 记录真正使用的长度：
+	容量不够时分配更大的数组，把旧数组的数据挪过去，这一步是 O(n) 的
+	容量较小时翻倍，超过阈值（默认 256）后每次只增长 1.25 倍，与 Go 运行时 append 的策略相同，
+	大数组不会因为多加一个元素就浪费近一半的内存，翻倍时的均摊 O(1) 也不受影响
+	删除元素后长度不到容量的 1/4 时容量砍半，不会低于创建时的容量；
+	在 1/4 而不是 1/2 时缩容，是为了避免在边界上反复插入删除导致反复扩容缩容
+下标越界等错误通过返回 error 告知调用者，不会 panic。
+*/
 
-// Array 可变成数组
-type Array struct {
-	array []int      // 固定大小的数组，用满容量和满大小的切片来代替
-	len   int        // 真正长度
-	cap   int        // 容量
-	lock  sync.Mutex // 为了并发安全使用的锁
+var (
+	// ErrIndexOutOfRange 下标超出范围
+	ErrIndexOutOfRange = errors.New("array: index out of range")
+	// ErrEmpty 数组为空
+	ErrEmpty = errors.New("array: empty array")
+	// ErrInvalidLength 长度为负数或者大于容量
+	ErrInvalidLength = errors.New("array: len out of range [0, cap]")
+	// ErrInvalidOptions 伸缩策略不合法
+	ErrInvalidOptions = errors.New("array: invalid options")
+)
+
+// 默认的伸缩策略
+const (
+	defaultGrowThreshold = 256
+	defaultGrowFactor    = 1.25
+	defaultShrinkFactor  = 0.25
+)
+
+// Options 数组的伸缩策略，字段为 0 时使用默认值
+type Options struct {
+	GrowThreshold int     // 容量小于该值时翻倍，默认 256
+	GrowFactor    float64 // 容量达到阈值后每次增长的倍数，默认 1.25，必须大于 1
+	ShrinkFactor  float64 // 长度小于 容量*ShrinkFactor 时容量砍半，默认 0.25，小于 0 表示不缩容，必须小于 0.5
+}
+
+// normalize 填充默认值并检查是否合法
+func (o Options) normalize() (Options, error) {
+	if o.GrowThreshold == 0 {
+		o.GrowThreshold = defaultGrowThreshold
+	}
+	if o.GrowFactor == 0 {
+		o.GrowFactor = defaultGrowFactor
+	}
+	if o.ShrinkFactor == 0 {
+		o.ShrinkFactor = defaultShrinkFactor
+	} else if o.ShrinkFactor < 0 {
+		o.ShrinkFactor = 0
+	}
+	// 缩容后长度不能超过新容量的一半，否则再加几个元素又要扩容
+	if o.GrowThreshold < 0 || o.GrowFactor <= 1 || o.ShrinkFactor >= 0.5 {
+		return o, ErrInvalidOptions
+	}
+	return o, nil
+}
+
+// Array 可变长数组，并发安全
+type Array[T any] struct {
+	array   []T        // 固定大小的数组，用满容量和满大小的切片来代替
+	len     int        // 真正长度
+	cap     int        // 容量
+	minCap  int        // 创建时的容量，缩容不会低于它
+	options Options    // 伸缩策略
+	lock    sync.Mutex // 为了并发安全使用的锁
 }
 
 // Make 数组初始化
-// 创建一个 len 个元素，容量为 cap 的可变长数组
-// 新建一个可变长数组
+// 创建一个 len 个零值元素，容量为 cap 的可变长数组，使用默认的伸缩策略
+// len 为负数或大于 cap 时返回 ErrInvalidLength
 /*
 主要利用满容量和满大小的切片来充当固定数组，结构体 Array 里面的字段 len 和 cap 来控制值的存取
-不允许设置 len > cap 的可变长数组。时间复杂度为：O(1)，因为分配内存空间和设置几个值是常数时间
+时间复杂度为：O(cap)，分配的内存会被清零
 */
-func Make(len, cap int) *Array {
-	s := new(Array)
-	if len > cap {
-		panic("len large than cap")
+func Make[T any](len, cap int) (*Array[T], error) {
+	return MakeWithOptions[T](len, cap, Options{})
+}
+
+// MakeWithOptions 创建使用指定伸缩策略的可变长数组
+func MakeWithOptions[T any](len, cap int, options Options) (*Array[T], error) {
+	if len < 0 || len > cap {
+		return nil, ErrInvalidLength
+	}
+	options, err := options.normalize()
+	if err != nil {
+		return nil, err
 	}
-	// 把切片当数组使用
-	array := make([]int, cap, cap)
-	// 元数据
-	s.array = array
-	s.cap = cap
-	s.len = 0
-	return s
+	return &Array[T]{
+		// 把切片当数组使用
+		array:   make([]T, cap),
+		len:     len,
+		cap:     cap,
+		minCap:  cap,
+		options: options,
+	}, nil
+}
+
+// grow 计算至少能容纳 needed 个元素的新容量
+func (a *Array[T]) grow(needed int) int {
+	newCap := a.cap
+	for newCap < needed {
+		if newCap < a.options.GrowThreshold {
+			// 如果之前的容量为0，那么新增的容量为1
+			newCap = max(2*newCap, 1)
+		} else {
+			newCap = max(int(float64(newCap)*a.options.GrowFactor), newCap+1)
+		}
+	}
+	return newCap
+}
+
+// resize 生成一个容量为 cap 的新数组，把旧数组的数据挪移过去，时间复杂度为：O(n)
+func (a *Array[T]) resize(cap int) {
+	newArray := make([]T, cap)
+	copy(newArray, a.array[:a.len])
+	a.array = newArray
+	a.cap = cap
+}
+
+// shrinkIfNeeded 长度小于 容量*ShrinkFactor 时容量砍半，不低于创建时的容量
+func (a *Array[T]) shrinkIfNeeded() {
+	if a.cap <= a.minCap || float64(a.len) >= float64(a.cap)*a.options.ShrinkFactor {
+		return
+	}
+	a.resize(max(a.cap/2, a.minCap))
 }
 
 // Append 增加一个元素
 // 耗时主要在老数组中的数据移动到新数组，时间复杂度为：O(n)
 // 如果容量够的情况下，时间复杂度会变为：O(1)
-func (a *Array) Append(element int) {
+func (a *Array[T]) Append(element T) {
+	a.AppendMany(element)
+}
+
+// AppendMany 添加多个元素，容量不够时一次扩容到足够的大小
+// ...T 是 Golang 的语言特征，表示多个函数变量
+func (a *Array[T]) AppendMany(elements ...T) {
 	// 并发锁
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	// 大小等于容量，表示没有多余位置了
+	if a.len+len(elements) > a.cap {
+		a.resize(a.grow(a.len + len(elements)))
+	}
+	// 把元素放在数组的末尾
+	copy(a.array[a.len:], elements)
+	a.len += len(elements)
+}
+
+// Insert 在下标 index 处插入元素，后面的元素整体后移一位，index 等于长度时相当于 Append
+// 下标不在 [0, len] 之间时返回 ErrIndexOutOfRange，时间复杂度为：O(n)
+func (a *Array[T]) Insert(index int, element T) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if index < 0 || index > a.len {
+		return ErrIndexOutOfRange
+	}
 	if a.len == a.cap {
-		// 没容量，数组要扩容，扩容到2倍
-		newCap := 2 * a.len
-		// 如果之前的容量为0，那么新增的容量为1
-		if a.cap == 0 {
-			newCap = 1
-		}
-		// 生成一个新数组
-		newArray := make([]int, newCap, newCap)
-		// 把旧数组的数据挪移到新的数组
-		for k, v := range a.array {
-			newArray[k] = v
-		}
-		// 替换数组
-		a.array = newArray
-		a.cap = newCap
+		a.resize(a.grow(a.len + 1))
 	}
-	// 把元素放在数组里,放在最后一位，数组的末尾
-	a.array[a.len] = element
-	// 真实长度+1
-	a.len = a.len + 1
+	copy(a.array[index+1:a.len+1], a.array[index:a.len])
+	a.array[index] = element
+	a.len++
+	return nil
 }
 
-// AppendMany AppendAny 添加多个元素
-// 简单遍历一下，调用 Append 函数
-// ...int 是 Golang 的语言特征，表示多个函数变量
-func (a *Array) AppendMany(element ...int) {
-	for _, v := range element {
-		a.Append(v)
+// Delete 删除下标 index 处的元素并返回，后面的元素整体前移一位
+// 下标越界时返回 ErrIndexOutOfRange，时间复杂度为：O(n)
+func (a *Array[T]) Delete(index int) (element T, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if index < 0 || index >= a.len {
+		return element, ErrIndexOutOfRange
 	}
+	element = a.array[index]
+	copy(a.array[index:], a.array[index+1:a.len])
+	a.removeLast()
+	return element, nil
+}
+
+// Pop 删除并返回最后一个元素，数组为空时返回 ErrEmpty，时间复杂度为：O(1)
+func (a *Array[T]) Pop() (element T, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.len == 0 {
+		return element, ErrEmpty
+	}
+	element = a.array[a.len-1]
+	a.removeLast()
+	return element, nil
+}
+
+// removeLast 长度减一，清空腾出的位置（让垃圾回收可以回收它引用的内存），需要时缩容
+func (a *Array[T]) removeLast() {
+	a.len--
+	var zero T
+	a.array[a.len] = zero
+	a.shrinkIfNeeded()
 }
 
 // Get 获取指定下标元素
-// 可变长数组的真实大小为0，或者下标 index 超出了真实长度 len ，将会 panic 越界
+// 下标 index 超出了真实长度 len 时返回 ErrIndexOutOfRange
 // 因为只获取下标的值，所以时间复杂度为 O(1)
-func (a *Array) Get(index int) int {
-	// 越界了
-	if a.len == 0 || index >= a.len {
-		panic("index over len")
+func (a *Array[T]) Get(index int) (element T, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if index < 0 || index >= a.len {
+		return element, ErrIndexOutOfRange
 	}
-	return a.array[index]
+	return a.array[index], nil
+}
+
+// Set 修改指定下标元素，下标越界时返回 ErrIndexOutOfRange，时间复杂度为 O(1)
+func (a *Array[T]) Set(index int, element T) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if index < 0 || index >= a.len {
+		return ErrIndexOutOfRange
+	}
+	a.array[index] = element
+	return nil
+}
+
+// Slice 返回下标在 [lo, hi) 之间的元素的副本，修改副本不影响数组
+// 不满足 0 <= lo <= hi <= len 时返回 ErrIndexOutOfRange
+func (a *Array[T]) Slice(lo, hi int) ([]T, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if lo < 0 || lo > hi || hi > a.len {
+		return nil, ErrIndexOutOfRange
+	}
+	return append([]T(nil), a.array[lo:hi]...), nil
 }
 
 // Len 获取真实长度容量 时间复杂度为 O(1)
-func (a *Array) Len() int {
+func (a *Array[T]) Len() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	return a.len
 }
 
 // Cap 获取真实容量
-func (a *Array) Cap() int {
+func (a *Array[T]) Cap() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	return a.cap
 }
 
 // Print 测试辅助打印
-func Print(array *Array) (result string) {
-	result = "["
-	for i := 0; i < array.Len(); i++ {
-		if i == 0 { //第一个元素
-			result = fmt.Sprintf("%s%d", result, array.Get(i))
-			continue
-		}
-		result = fmt.Sprintf("%s %d", result, array.Get(i))
-	}
-	result = result + "]"
-	return result
-}
-
-// 测试自定义可变数组
-func main() {
-	// 创建一个容量为3的动态数组
-	a := Make(0, 3)
-	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", Print(a))
-	// 增加一个元素
-	a.Append(10)
-	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", Print(a))
-	// 增加一个元素
-	a.Append(9)
-	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", Print(a))
-	// 增加多个元素
-	a.AppendMany(8, 7)
-	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", Print(a))
+func Print[T any](array *Array[T]) string {
+	array.lock.Lock()
+	defer array.lock.Unlock()
+	return fmt.Sprint(array.array[:array.len])
 }
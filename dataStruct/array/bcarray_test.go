@@ -0,0 +1,110 @@
+package array
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// 与切片对比，并检查容量的伸缩
+func TestArrayRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, options := range []Options{{}, {GrowThreshold: 8, GrowFactor: 1.5}, {ShrinkFactor: -1}} {
+		a, err := MakeWithOptions[int](2, 4, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int{0, 0}
+		for i := 0; i < 20000; i++ {
+			n := len(want)
+			index := r.Intn(n+3) - 1
+			switch op := r.Intn(10); {
+			case op < 3:
+				// 前一半时间以插入为主，后一半以删除为主，让数组先变大再变小
+				if i < 10000 || op == 0 {
+					a.Append(i)
+					want = append(want, i)
+				}
+			case op < 5:
+				err := a.Insert(index, i)
+				if (err == nil) != (index >= 0 && index <= n) {
+					t.Fatalf("Insert(%d) with len %d: %v", index, n, err)
+				}
+				if err == nil {
+					want = slices.Insert(want, index, i)
+				}
+			case op < 7:
+				v, err := a.Delete(index)
+				if (err == nil) != (index >= 0 && index < n) {
+					t.Fatalf("Delete(%d) with len %d: %v", index, n, err)
+				}
+				if err == nil {
+					if v != want[index] {
+						t.Fatalf("Delete(%d) = %d, want %d", index, v, want[index])
+					}
+					want = slices.Delete(want, index, index+1)
+				}
+			case op < 8:
+				v, err := a.Pop()
+				if (err == nil) != (n > 0) || (n > 0 && v != want[n-1]) {
+					t.Fatalf("Pop = %d, %v", v, err)
+				}
+				if n > 0 {
+					want = want[:n-1]
+				}
+			case op < 9:
+				err := a.Set(index, -i)
+				if (err == nil) != (index >= 0 && index < n) {
+					t.Fatalf("Set(%d) with len %d: %v", index, n, err)
+				}
+				if err == nil {
+					want[index] = -i
+				}
+			default:
+				lo, hi := r.Intn(n+2)-1, r.Intn(n+2)
+				got, err := a.Slice(lo, hi)
+				if (err == nil) != (lo >= 0 && lo <= hi && hi <= n) {
+					t.Fatalf("Slice(%d, %d) with len %d: %v", lo, hi, n, err)
+				}
+				if err == nil && !slices.Equal(got, want[lo:hi]) {
+					t.Fatalf("Slice(%d, %d) = %v, want %v", lo, hi, got, want[lo:hi])
+				}
+			}
+			if a.len != len(want) || a.cap != len(a.array) || a.cap < a.len || a.cap < a.minCap {
+				t.Fatalf("len %d cap %d, want len %d", a.len, a.cap, len(want))
+			}
+			if !slices.Equal(a.array[:a.len], want) {
+				t.Fatalf("array = %v, want %v", a.array[:a.len], want)
+			}
+			// 腾出的位置已经清零
+			for _, v := range a.array[a.len:] {
+				if v != 0 {
+					t.Fatalf("stale element %d after len", v)
+				}
+			}
+			// 缩容后长度不小于 容量*ShrinkFactor，除非已经是最小容量
+			if a.cap > a.minCap && float64(a.len) < float64(a.cap)*a.options.ShrinkFactor {
+				t.Fatalf("len %d cap %d not shrunk", a.len, a.cap)
+			}
+		}
+	}
+}
+
+func TestMakeErrors(t *testing.T) {
+	for _, tt := range []struct {
+		len, cap int
+		options  Options
+		err      error
+	}{
+		{-1, 2, Options{}, ErrInvalidLength},
+		{3, 2, Options{}, ErrInvalidLength},
+		{0, 0, Options{GrowFactor: 0.5}, ErrInvalidOptions},
+		{0, 0, Options{ShrinkFactor: 0.5}, ErrInvalidOptions},
+		{0, 0, Options{GrowThreshold: -1}, ErrInvalidOptions},
+		{2, 2, Options{}, nil},
+	} {
+		if _, err := MakeWithOptions[int](tt.len, tt.cap, tt.options); err != tt.err {
+			t.Errorf("MakeWithOptions(%d, %d, %+v) = %v, want %v", tt.len, tt.cap, tt.options, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package array_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/array"
+)
+
+// 测试自定义可变数组
+func ExampleArray() {
+	// 创建一个容量为3的动态数组
+	a, _ := array.Make[int](0, 3)
+	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", array.Print(a))
+	// 增加一个元素
+	a.Append(10)
+	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", array.Print(a))
+	// 增加一个元素
+	a.Append(9)
+	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", array.Print(a))
+	// 增加多个元素
+	a.AppendMany(8, 7)
+	fmt.Println("cap", a.Cap(), "len", a.Len(), "array:", array.Print(a))
+	// Output:
+	// cap 3 len 0 array: []
+	// cap 3 len 1 array: [10]
+	// cap 3 len 2 array: [10 9]
+	// cap 6 len 4 array: [10 9 8 7]
+}
+
+func ExampleArray_Insert() {
+	a, _ := array.Make[string](0, 2)
+	a.AppendMany("a", "c", "e")
+	a.Insert(1, "b")
+	a.Insert(a.Len(), "f")
+	fmt.Println(array.Print(a), a.Cap())
+	v, _ := a.Delete(3)
+	last, _ := a.Pop()
+	a.Set(0, "A")
+	s, _ := a.Slice(1, 3)
+	fmt.Println(v, last, s, array.Print(a))
+	// 越界返回错误，不会 panic
+	_, err := a.Get(10)
+	fmt.Println(err, a.Insert(-1, "x"))
+	_, err = array.Make[int](3, 2)
+	fmt.Println(err)
+	// Output:
+	// [a b c e f] 8
+	// e f [b c] [A b c]
+	// array: index out of range array: index out of range
+	// array: len out of range [0, cap]
+}
+
+func ExampleMakeWithOptions() {
+	// 容量小于 4 时翻倍，之后每次增长 1.5 倍
+	a, _ := array.MakeWithOptions[int](0, 0, array.Options{GrowThreshold: 4, GrowFactor: 1.5})
+	var caps []int
+	for i := 0; i < 20; i++ {
+		a.Append(i)
+		if len(caps) == 0 || caps[len(caps)-1] != a.Cap() {
+			caps = append(caps, a.Cap())
+		}
+	}
+	fmt.Println(caps)
+	// 长度不到容量的 1/4 时容量砍半
+	for a.Len() > 1 {
+		a.Pop()
+	}
+	fmt.Println(a.Len(), a.Cap())
+	// Output:
+	// [1 2 4 6 9 13 19 28]
+	// 1 3
+}
+
+func ExampleTrimmedMean() {
+	fmt.Println(array.TrimmedMean([]int{2, 4, 6, 8, 10}))
+	// Output:
+	// 6
+}
+
+func ExampleRemoveDuplicates() {
+	a := []int{0, 0, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5}
+	n := array.RemoveDuplicates(a)
+	fmt.Println(n, a[:n])
+	// Output:
+	// 6 [0 1 2 3 4 5]
+}
+
+func ExampleReverse() {
+	a := []int{1, 2, 3, 4, 5}
+	fmt.Println(array.ReverseCopy(a))
+	array.Reverse(a)
+	fmt.Println(a)
+	fmt.Println(array.MostFrequent([]int{1, 2, 3, 4, 5, 5, 6, 6, 6, 2, 2, 2, 2}))
+	fmt.Println(array.ContainsDuplicate(a))
+	// Output:
+	// [5 4 3 2 1]
+	// [5 4 3 2 1]
+	// 2 5
+	// false
+}
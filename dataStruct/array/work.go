@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package array
 
 /*
 //例题，数组存储了 5 个评委对 1 个运动员的打分，且每个评委的打分都不相等
@@ -10,82 +8,129 @@ import "fmt"
 #要求是，不允许再开辟 O(n) 空间复杂度的复杂数据结构。
 */
 
-func arrayCount(array []int) {
-	maxTemp := 0
+// TrimmedMean 去掉一个最高分和一个最低分后求平均分
+// 通过移动下标使后面的数值整体向前移动一位，在原数组上删除最大值和最小值，
+// 返回的平均分由 array 前 len(array)-2 个样本计算得出
+func TrimmedMean(array []int) int {
+	if len(array) <= 2 {
+		return 0
+	}
+	// 获取到最大值的下标索引值
 	maxIndex := 0
-	minTemp := 0
-	minIndex := 0
-
-	// 获取到最大值，最小值的下标索引值
 	for i, v := range array {
-		if v > maxTemp {
+		if v > array[maxIndex] {
 			maxIndex = i
-			maxTemp = v
-		}else if v < minTemp {
-			minIndex = i
-			minTemp = i
 		}
 	}
-	// 从最大值最小值的索引开始分别遍历去掉，最大值，最小值,
-	// 通过移动下标实现使整体的数值都向前移动一位
+	// 从最大值的索引开始遍历，使整体的数值都向前移动一位，去掉最大值
 	for i := maxIndex; i < len(array)-1; i++ {
 		array[i] = array[i+1]
 	}
-	fmt.Println("去掉最大值后：", array)
+	// 剩下的 len(array)-1 个样本中获取最小值的下标索引值
+	minIndex := 0
+	for i := 0; i < len(array)-1; i++ {
+		if array[i] < array[minIndex] {
+			minIndex = i
+		}
+	}
+	// 去掉最小值
 	for i := minIndex; i < len(array)-1; i++ {
 		array[i] = array[i+1]
 	}
-	fmt.Println("去掉最小值后：", array)
 	// 获取总分
 	sumScore := 0
-	for i := 0; i < len(array)-2; i++{
+	for i := 0; i < len(array)-2; i++ {
 		sumScore += array[i]
 	}
-	fmt.Println("去掉最大最小后总分：", sumScore)
 	// 求平均分
-	avgScore := sumScore/3
-	fmt.Println("平均值：",avgScore)
+	return sumScore / (len(array) - 2)
 }
 
 /*
-例题2：给定一个重复的数组，返回去掉重复后的数组，与新数组不重复的长度
+例题2：给定一个有序的重复数组，返回去掉重复后的数组，与新数组不重复的长度
 ，要求空间复杂度为O(1)，就使不在开辟新的内存空间 ，在原有数组上做操作
 
 分析，可以利用数组的索引下标做操作在远数组上进行操作删除
 */
-func arrayCounts(array []int) {
-	tempValue := 0
-	valueIndex := 0
-	vauleCount := 0
 
-	// 获取到最大值，最小值的下标索引值
-	for i := 0; i < len(array); i++ {
-		for i, v := range array {
-			// 存在重复的
-			if v == tempValue {
-				valueIndex = i
-				// 从重复值的索引开始,通过移动下标实现使整体的数值都向前移动一位,
-				// 所有重复的值都会以索引最后一位的值填充，填充的数量就是重复交换发的数量
-				for i := valueIndex; i < len(array)-1; i++ {
-					array[i] = array[i+1]
-				}
-				vauleCount++
-			} else {
-				// 不存在重复的从这个不重复的值开始，重新开始统计,数组不做操作
-				tempValue = v
-				valueIndex = 0
-				vauleCount = 0
-			}
+// RemoveDuplicates 有序数组原地去重，返回不重复的长度，去重后的数据保存在 array[:n]
+func RemoveDuplicates(array []int) int {
+	if len(array) == 0 {
+		return 0
+	}
+	// n 为不重复部分的长度，遇到与最后一个不重复值不相等的数，就往前挪到不重复部分的末尾
+	n := 1
+	for i := 1; i < len(array); i++ {
+		if array[i] != array[n-1] {
+			array[n] = array[i]
+			n++
+		}
+	}
+	return n
+}
+
+// ContainsDuplicate 数组中是否有重复的值
+/*
+时间复杂度O(N), N 为数组的长度
+空间复杂度O(N), 使用了额外的存储空间map数据结构，N 为数组的长度
+*/
+func ContainsDuplicate(nums []int) bool {
+	// 定义map变量
+	numsMap := make(map[int]int)
+	// 遍历数组将值添加到map中，key:数值， value:值出现的次数
+	for _, val := range nums {
+		// 如果存在，说明重复了
+		if _, ok := numsMap[val]; ok {
+			return true
 		}
+		// 不存在,将值加入map
+		numsMap[val] += 1
 	}
-	fmt.Println("去重后：", array, vauleCount)
+	return false
 }
 
-func main() {
-	array := []int{2,4,6,8,10}
-	array1 := []int{0,0,1,1,1,2,2,3,3,4,4,5}
-	arrayCount(array)
-	arrayCounts(array1)
+// 输入a = [1, 2, 3, 4, 5],输出[5, 4, 3, 2, 1]
+
+// ReverseCopy 方法一 时间复杂度是O(n) + O(n) 也就是O(n)，空间复杂度是O(n)
+func ReverseCopy(a []int) []int {
+	b := make([]int, len(a))
+	// 利用b的逆序索引顺序实现，把a正序索引值的数据赋值
+	for j := 0; j < len(a); j++ {
+		b[len(a)-j-1] = a[j]
+	}
+	return b
 }
 
+// Reverse 方法二 原地翻转，空间复杂度是O(1)
+func Reverse(a []int) {
+	tmp := 0
+	// len(a)/2 因为一个数组只需要比较1/2次就可以出结果
+	for i := 0; i < len(a)/2; i++ {
+		// 保存正序遍历的临时值
+		tmp = a[i]
+		// 最后一个赋值给第一个
+		a[i] = a[len(a)-i-1]
+		// 第一个赋值给最后一个
+		a[len(a)-i-1] = tmp
+	}
+}
 
+// MostFrequent 在一个数组中找出出现次数最多的那个元素的值，以及出现的次数
+// 例如：输入数组a = [1, 2, 3, 4, 5, 5, 6]
+// 出现次数相同时，返回值较小的那个
+func MostFrequent(a []int) (maxKey, maxCount int) {
+	b := map[int]int{}
+	// o(n)
+	for _, value := range a {
+		b[value]++
+	}
+	// o(n)
+	for key, value := range b {
+		if value > maxCount || (value == maxCount && key < maxKey) {
+			maxCount = value
+			maxKey = key
+		}
+	}
+	// o(n) + o(n) = o(n)
+	return maxKey, maxCount
+}
@@ -0,0 +1,210 @@
+package bitmap
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// 位集合 BitSet
+/*
+set.Set[int] 基于 map，每个整数要占用几十个字节（键、哈希表的桶和溢出指针）。
+整数比较稠密时，用一个位表示一个整数是否存在要省得多：第 i 个位为 1 表示 i 在集合中，
+n 个位只需要 n/8 个字节，与集合中整数的数量无关。
+
+位保存在 []uint64 中，第 i 个位在 words[i/64] 的第 i%64 位：
+	集合运算按字（64 位）进行，交集、并集、对称差、差集分别是 &、|、^、&^
+	计数使用 popcount 指令（bits.OnesCount64）
+	NextSet 用 bits.TrailingZeros64 跳过一个字中连续的 0，迭代只访问为 1 的位
+集合中最大的整数决定了占用的内存，稀疏的集合应当使用 RoaringBitmap。
+*/
+
+// wordBits 每个字的位数
+const wordBits = 64
+
+// BitSet 位集合，会按需扩容，不是并发安全的
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet 创建能容纳 [0, n) 而不需要扩容的位集合
+func NewBitSet(n uint) *BitSet {
+	return &BitSet{words: make([]uint64, (n+wordBits-1)/wordBits)}
+}
+
+// NewBitSetOf 创建包含指定整数的位集合
+func NewBitSetOf(values ...uint) *BitSet {
+	b := &BitSet{}
+	for _, v := range values {
+		b.Set(v)
+	}
+	return b
+}
+
+// grow 扩容到至少有 n 个字
+func (b *BitSet) grow(n int) {
+	if n > len(b.words) {
+		b.words = append(b.words, make([]uint64, n-len(b.words))...)
+	}
+}
+
+// Set 添加整数 i
+func (b *BitSet) Set(i uint) {
+	b.grow(int(i/wordBits) + 1)
+	b.words[i/wordBits] |= 1 << (i % wordBits)
+}
+
+// Clear 删除整数 i
+func (b *BitSet) Clear(i uint) {
+	if w := int(i / wordBits); w < len(b.words) {
+		b.words[w] &^= 1 << (i % wordBits)
+	}
+}
+
+// Flip 整数 i 存在时删除，不存在时添加
+func (b *BitSet) Flip(i uint) {
+	b.grow(int(i/wordBits) + 1)
+	b.words[i/wordBits] ^= 1 << (i % wordBits)
+}
+
+// Test 整数 i 是否存在
+func (b *BitSet) Test(i uint) bool {
+	w := int(i / wordBits)
+	return w < len(b.words) && b.words[w]&(1<<(i%wordBits)) != 0
+}
+
+// Count 返回整数的数量
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Cap 返回不需要扩容就能容纳的位数
+func (b *BitSet) Cap() uint {
+	return uint(len(b.words)) * wordBits
+}
+
+// NextSet 返回大于等于 i 的最小整数，没有时返回 false
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	w := int(i / wordBits)
+	if w >= len(b.words) {
+		return 0, false
+	}
+	// 去掉第一个字中小于 i 的位
+	word := b.words[w] >> (i % wordBits)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return uint(w)*wordBits + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear 返回大于等于 i 的最小的不在集合中的整数
+func (b *BitSet) NextClear(i uint) uint {
+	w := int(i / wordBits)
+	if w >= len(b.words) {
+		return i
+	}
+	word := ^b.words[w] >> (i % wordBits)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word))
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return uint(w)*wordBits + uint(bits.TrailingZeros64(^b.words[w]))
+		}
+	}
+	return uint(len(b.words)) * wordBits
+}
+
+// All 从小到大迭代所有整数
+func (b *BitSet) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for w, word := range b.words {
+			for word != 0 {
+				t := bits.TrailingZeros64(word)
+				if !yield(uint(w)*wordBits + uint(t)) {
+					return
+				}
+				// 清掉最低位的 1
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Clone 复制位集合
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// longer 返回字数较多和较少的一方
+func longer(a, b *BitSet) (*BitSet, *BitSet) {
+	if len(a.words) >= len(b.words) {
+		return a, b
+	}
+	return b, a
+}
+
+// And 交集
+func (b *BitSet) And(other *BitSet) *BitSet {
+	result := &BitSet{words: make([]uint64, min(len(b.words), len(other.words)))}
+	for i := range result.words {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+	return result
+}
+
+// Or 并集
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	long, short := longer(b, other)
+	result := long.Clone()
+	for i, w := range short.words {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// Xor 对称差
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	long, short := longer(b, other)
+	result := long.Clone()
+	for i, w := range short.words {
+		result.words[i] ^= w
+	}
+	return result
+}
+
+// AndNot 差集，在 b 中但不在 other 中
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	result := b.Clone()
+	for i := range min(len(b.words), len(other.words)) {
+		result.words[i] &^= other.words[i]
+	}
+	return result
+}
+
+// Equal 两个集合是否包含相同的整数，与容量无关
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
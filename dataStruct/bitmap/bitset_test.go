@@ -0,0 +1,84 @@
+package bitmap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// 与 map 实现的集合对比
+func TestBitSetRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func() (*BitSet, map[uint]bool) {
+		b, m := NewBitSet(0), map[uint]bool{}
+		for i := r.Intn(300); i > 0; i-- {
+			x := uint(r.Intn(500))
+			if r.Intn(4) == 0 {
+				b.Flip(x)
+				m[x] = !m[x]
+			} else {
+				b.Set(x)
+				m[x] = true
+			}
+			if r.Intn(5) == 0 {
+				y := uint(r.Intn(600))
+				b.Clear(y)
+				delete(m, y)
+			}
+		}
+		return b, m
+	}
+	for round := 0; round < 200; round++ {
+		a, ma := random()
+		b, mb := random()
+		ops := []struct {
+			name string
+			got  *BitSet
+			want func(x, y bool) bool
+		}{
+			{"And", a.And(b), func(x, y bool) bool { return x && y }},
+			{"Or", a.Or(b), func(x, y bool) bool { return x || y }},
+			{"Xor", a.Xor(b), func(x, y bool) bool { return x != y }},
+			{"AndNot", a.AndNot(b), func(x, y bool) bool { return x && !y }},
+		}
+		for _, op := range ops {
+			var want []uint
+			for x := uint(0); x < 700; x++ {
+				if op.want(ma[x], mb[x]) {
+					want = append(want, x)
+				}
+				if op.got.Test(x) != op.want(ma[x], mb[x]) {
+					t.Fatalf("round %d: %s wrong for %d", round, op.name, x)
+				}
+			}
+			if op.got.Count() != len(want) {
+				t.Fatalf("round %d: %s Count = %d, want %d", round, op.name, op.got.Count(), len(want))
+			}
+			if got := slices.Collect(op.got.All()); !slices.Equal(got, want) {
+				t.Fatalf("round %d: %s All = %v, want %v", round, op.name, got, want)
+			}
+			// NextSet 逐个跳到下一个整数
+			var next []uint
+			for x, ok := op.got.NextSet(0); ok; x, ok = op.got.NextSet(x + 1) {
+				next = append(next, x)
+			}
+			if !slices.Equal(next, want) {
+				t.Fatalf("round %d: %s NextSet = %v, want %v", round, op.name, next, want)
+			}
+		}
+		for x := uint(0); x < 700; x += 7 {
+			c := a.NextClear(x)
+			if c < x || ma[c] {
+				t.Fatalf("NextClear(%d) = %d", x, c)
+			}
+			for y := x; y < c; y++ {
+				if !ma[y] {
+					t.Fatalf("NextClear(%d) = %d, but %d is clear", x, c, y)
+				}
+			}
+		}
+		if !a.Xor(b).Equal(a.Or(b).AndNot(a.And(b))) || !a.Equal(a.Clone()) {
+			t.Fatalf("round %d: Equal", round)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package bitmap
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Roaring 的三种容器
+/*
+每个容器保存高 16 位相同的整数的低 16 位，最多 65536 个：
+	arrayContainer   有序的 []uint16，每个整数 2 个字节，最多 4096 个
+	bitmapContainer  65536 个位（1024 个 uint64），固定 8KB，超过 4096 个整数时使用
+	runContainer     有序的区间 [start, last]，每个区间 4 个字节，适合连续的整数
+4096 是数组和位图大小相等（8KB）的分界点，数组满了 4096 个时转换为位图，位图减少到 4096 个时转换回数组，
+这个不变式也是序列化格式的要求：读取时根据数量判断是数组还是位图。
+run 容器只在 RunOptimize 或 AddRange 时生成，不会自动从数组或位图转换过来。
+*/
+
+// 容器的大小
+const (
+	arrayMaxSize = 4096 // 数组容器最多保存的整数数量
+	bitmapWords  = 1024 // 位图容器的字数
+)
+
+// container 容器，修改操作返回修改后的容器，可能转换了类型
+type container interface {
+	cardinality() int
+	contains(x uint16) bool
+	add(x uint16) container
+	remove(x uint16) container
+	// iterate 从小到大迭代，yield 返回 false 时停止并返回 false
+	iterate(yield func(uint16) bool) bool
+	clone() container
+	minimum() uint16
+	maximum() uint16
+	// rank 返回小于等于 x 的整数数量
+	rank(x uint16) int
+	// numRuns 返回连续区间的数量
+	numRuns() int
+	// toBitmap 复制为位图容器，用于与其他容器运算
+	toBitmap() *bitmapContainer
+}
+
+// arrayContainer 数组容器
+type arrayContainer struct {
+	values []uint16
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	_, found := slices.BinarySearch(a.values, x)
+	return found
+}
+
+func (a *arrayContainer) add(x uint16) container {
+	i, found := slices.BinarySearch(a.values, x)
+	if found {
+		return a
+	}
+	if len(a.values) == arrayMaxSize {
+		b := a.toBitmap()
+		b.add(x)
+		return b
+	}
+	a.values = slices.Insert(a.values, i, x)
+	return a
+}
+
+func (a *arrayContainer) remove(x uint16) container {
+	if i, found := slices.BinarySearch(a.values, x); found {
+		a.values = slices.Delete(a.values, i, i+1)
+	}
+	return a
+}
+
+func (a *arrayContainer) iterate(yield func(uint16) bool) bool {
+	for _, v := range a.values {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{values: slices.Clone(a.values)}
+}
+
+func (a *arrayContainer) minimum() uint16 {
+	return a.values[0]
+}
+
+func (a *arrayContainer) maximum() uint16 {
+	return a.values[len(a.values)-1]
+}
+
+func (a *arrayContainer) rank(x uint16) int {
+	i, found := slices.BinarySearch(a.values, x)
+	if found {
+		return i + 1
+	}
+	return i
+}
+
+func (a *arrayContainer) numRuns() int {
+	runs := 0
+	for i, v := range a.values {
+		if i == 0 || v != a.values[i-1]+1 {
+			runs++
+		}
+	}
+	return runs
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{card: len(a.values)}
+	for _, v := range a.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	return b
+}
+
+// filter 返回 other 中包含（keep 为 true）或不包含（keep 为 false）的整数，用于交集和差集
+func (a *arrayContainer) filter(other container, keep bool) *arrayContainer {
+	result := &arrayContainer{values: make([]uint16, 0, len(a.values))}
+	for _, v := range a.values {
+		if other.contains(v) == keep {
+			result.values = append(result.values, v)
+		}
+	}
+	return result
+}
+
+// bitmapContainer 位图容器
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int // 整数的数量
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	if !b.contains(x) {
+		b.words[x/64] |= 1 << (x % 64)
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	if !b.contains(x) {
+		return b
+	}
+	b.words[x/64] &^= 1 << (x % 64)
+	b.card--
+	return shrink(b)
+}
+
+func (b *bitmapContainer) iterate(yield func(uint16) bool) bool {
+	for i, w := range b.words {
+		for w != 0 {
+			if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) clone() container {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) minimum() uint16 {
+	for i, w := range b.words {
+		if w != 0 {
+			return uint16(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+	return 0
+}
+
+func (b *bitmapContainer) maximum() uint16 {
+	for i := bitmapWords - 1; i >= 0; i-- {
+		if w := b.words[i]; w != 0 {
+			return uint16(i*64 + 63 - bits.LeadingZeros64(w))
+		}
+	}
+	return 0
+}
+
+func (b *bitmapContainer) rank(x uint16) int {
+	n := 0
+	for _, w := range b.words[:x/64] {
+		n += bits.OnesCount64(w)
+	}
+	// 第 x%64 位及更低的位
+	return n + bits.OnesCount64(b.words[x/64]<<(63-x%64))
+}
+
+func (b *bitmapContainer) numRuns() int {
+	runs := 0
+	var prev uint64 // 上一个字的最高位
+	for _, w := range b.words {
+		// 区间的起点：这一位是 1，前一位是 0
+		runs += bits.OnesCount64(w &^ (w<<1 | prev))
+		prev = w >> 63
+	}
+	return runs
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	c := *b
+	return &c
+}
+
+// count 重新计算整数的数量
+func (b *bitmapContainer) count() {
+	b.card = 0
+	for _, w := range b.words {
+		b.card += bits.OnesCount64(w)
+	}
+}
+
+// rangeWords 对 [start, last] 覆盖的每个字调用 fn，mask 为字中属于区间的位
+func rangeWords(start, last uint16, fn func(i int, mask uint64)) {
+	first, end := int(start/64), int(last/64)
+	for i := first; i <= end; i++ {
+		mask := ^uint64(0)
+		if i == first {
+			mask &= ^uint64(0) << (start % 64)
+		}
+		if i == end {
+			mask &= ^uint64(0) >> (63 - last%64)
+		}
+		fn(i, mask)
+	}
+}
+
+// orWith 并入另一个容器，原地修改
+func (b *bitmapContainer) orWith(c container) {
+	switch c := c.(type) {
+	case *arrayContainer:
+		for _, v := range c.values {
+			b.words[v/64] |= 1 << (v % 64)
+		}
+	case *bitmapContainer:
+		for i, w := range c.words {
+			b.words[i] |= w
+		}
+	case *runContainer:
+		for _, r := range c.runs {
+			rangeWords(r.start, r.last, func(i int, mask uint64) { b.words[i] |= mask })
+		}
+	}
+	b.count()
+}
+
+// xorWith 与另一个容器求对称差，原地修改
+func (b *bitmapContainer) xorWith(c container) {
+	switch c := c.(type) {
+	case *arrayContainer:
+		for _, v := range c.values {
+			b.words[v/64] ^= 1 << (v % 64)
+		}
+	case *bitmapContainer:
+		for i, w := range c.words {
+			b.words[i] ^= w
+		}
+	case *runContainer:
+		for _, r := range c.runs {
+			rangeWords(r.start, r.last, func(i int, mask uint64) { b.words[i] ^= mask })
+		}
+	}
+	b.count()
+}
+
+// andNotWith 去掉另一个容器中的整数，原地修改
+func (b *bitmapContainer) andNotWith(c container) {
+	switch c := c.(type) {
+	case *arrayContainer:
+		for _, v := range c.values {
+			b.words[v/64] &^= 1 << (v % 64)
+		}
+	case *bitmapContainer:
+		for i, w := range c.words {
+			b.words[i] &^= w
+		}
+	case *runContainer:
+		for _, r := range c.runs {
+			rangeWords(r.start, r.last, func(i int, mask uint64) { b.words[i] &^= mask })
+		}
+	}
+	b.count()
+}
+
+// andWith 与另一个位图求交集，原地修改
+func (b *bitmapContainer) andWith(c *bitmapContainer) {
+	for i, w := range c.words {
+		b.words[i] &= w
+	}
+	b.count()
+}
+
+// toArray 转换为数组容器
+func (b *bitmapContainer) toArray() *arrayContainer {
+	a := &arrayContainer{values: make([]uint16, 0, b.card)}
+	b.iterate(func(v uint16) bool {
+		a.values = append(a.values, v)
+		return true
+	})
+	return a
+}
+
+// shrink 位图中的整数不超过 4096 个时转换为数组
+func shrink(b *bitmapContainer) container {
+	if b.card <= arrayMaxSize {
+		return b.toArray()
+	}
+	return b
+}
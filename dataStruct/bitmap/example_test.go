@@ -0,0 +1,67 @@
+package bitmap_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/bitmap"
+)
+
+func ExampleBitSet() {
+	a := bitmap.NewBitSetOf(1, 3, 5, 64, 130)
+	b := bitmap.NewBitSetOf(3, 64, 65)
+	fmt.Println(slices.Collect(a.And(b).All()), slices.Collect(a.Or(b).All()))
+	fmt.Println(slices.Collect(a.Xor(b).All()), slices.Collect(a.AndNot(b).All()))
+	fmt.Println(a.Count(), a.Test(64), a.Cap())
+	// 从 6 开始的下一个整数
+	fmt.Println(a.NextSet(6))
+	fmt.Println(a.NextClear(64))
+	// Output:
+	// [3 64] [1 3 5 64 65 130]
+	// [1 5 65 130] [1 5 130]
+	// 5 true 192
+	// 64 true
+	// 65
+}
+
+func ExampleRoaringBitmap() {
+	a := bitmap.NewRoaringBitmapOf(1, 2, 3, 1000, 1<<20)
+	b := bitmap.NewRoaringBitmap()
+	b.AddRange(2, 999)
+	fmt.Println(a.And(b).ToArray(), a.Or(b).Cardinality())
+	fmt.Println(a.AndNot(b).ToArray(), a.Xor(b).Cardinality())
+	fmt.Println(a.Contains(1<<20), a.Rank(1000))
+	// Output:
+	// [2 3] 1001
+	// [1 1000 1048576] 999
+	// true 4
+}
+
+func ExampleRoaringBitmap_RunOptimize() {
+	rb := bitmap.NewRoaringBitmap()
+	// 稀疏的块
+	for x := uint32(0); x < 1000; x++ {
+		rb.Add(x * 1000)
+	}
+	// 稠密的块
+	for x := uint32(0); x < 30000; x++ {
+		rb.Add(100<<16 | x*2)
+	}
+	// 连续的块
+	for x := uint32(0); x < 50000; x++ {
+		rb.Add(200<<16 | x)
+	}
+	fmt.Printf("%+v\n", rb.Stats())
+	rb.RunOptimize()
+	fmt.Printf("%+v\n", rb.Stats())
+
+	// 标准格式，可以与其他语言的 Roaring 库交换
+	data, _ := rb.MarshalBinary()
+	other := bitmap.NewRoaringBitmap()
+	err := other.UnmarshalBinary(data)
+	fmt.Println(len(data), err, other.Equal(rb))
+	// Output:
+	// {Containers:18 ArrayContainers:16 BitmapContainers:2 RunContainers:0 Cardinality:81000 SerializedBytes:18536}
+	// {Containers:18 ArrayContainers:16 BitmapContainers:1 RunContainers:1 Cardinality:81000 SerializedBytes:10349}
+	// 10349 <nil> true
+}
@@ -0,0 +1,140 @@
+package bitmap
+
+// 容器之间的集合运算
+/*
+数组与数组直接归并；有位图参与时按字运算或者逐个修改位；
+区间容器与区间容器的并集和交集直接合并区间，其他情况先把区间容器转换为位图。
+结果为位图时，整数不超过 4096 个就转换为数组；两个区间容器运算的结果选择最小的表示。
+返回的容器可能为空，由调用者删除。
+*/
+
+// and 交集
+func and(a, b container) container {
+	switch x := a.(type) {
+	case *arrayContainer:
+		return x.filter(b, true)
+	case *bitmapContainer:
+		if y, ok := b.(*arrayContainer); ok {
+			return y.filter(x, true)
+		}
+		result := b.toBitmap()
+		result.andWith(x)
+		return shrink(result)
+	default:
+		switch y := b.(type) {
+		case *arrayContainer:
+			return y.filter(x, true)
+		case *runContainer:
+			return optimize(x.(*runContainer).intersect(y))
+		default:
+			result := x.toBitmap()
+			result.andWith(y.(*bitmapContainer))
+			return shrink(result)
+		}
+	}
+}
+
+// or 并集
+func or(a, b container) container {
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok {
+		return unionArrays(x.values, y.values)
+	}
+	if x, ok := a.(*runContainer); ok {
+		if y, ok := b.(*runContainer); ok {
+			return optimize(x.union(y))
+		}
+	}
+	// 从位图的一方开始，少一次转换
+	if _, ok := b.(*bitmapContainer); ok {
+		a, b = b, a
+	}
+	result := a.toBitmap()
+	result.orWith(b)
+	return shrink(result)
+}
+
+// xor 对称差
+func xor(a, b container) container {
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok {
+		return xorArrays(x.values, y.values)
+	}
+	result := a.toBitmap()
+	result.xorWith(b)
+	return bothRuns(a, b, shrink(result))
+}
+
+// andNot 差集
+func andNot(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		return x.filter(b, false)
+	}
+	result := a.toBitmap()
+	result.andNotWith(b)
+	return bothRuns(a, b, shrink(result))
+}
+
+// bothRuns 两个都是区间容器时，结果转换为最小的表示
+func bothRuns(a, b, result container) container {
+	_, xok := a.(*runContainer)
+	_, yok := b.(*runContainer)
+	if xok && yok {
+		return optimize(result)
+	}
+	return result
+}
+
+// fromValues 有序的整数转换为数组或位图容器
+func fromValues(values []uint16) container {
+	a := &arrayContainer{values: values}
+	if len(values) <= arrayMaxSize {
+		return a
+	}
+	return a.toBitmap()
+}
+
+// unionArrays 归并两个有序数组的并集
+func unionArrays(a, b []uint16) container {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return fromValues(append(result, b[j:]...))
+}
+
+// xorArrays 归并两个有序数组的对称差
+func xorArrays(a, b []uint16) container {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return fromValues(append(result, b[j:]...))
+}
@@ -0,0 +1,295 @@
+package bitmap
+
+import (
+	"iter"
+	"slices"
+)
+
+// Roaring 压缩位图（Chambi, Lemire, Kaser, Godin 2016）
+/*
+BitSet 的内存取决于最大的整数，集合中只有 1 和 40 亿两个数时也需要 500MB。
+Roaring 把 32 位整数按高 16 位分块，每块最多 65536 个整数，放在一个容器中，只为非空的块分配容器，
+每个容器根据块内整数的分布选择数组、位图或区间三种表示之一（见 container.go）：
+	稀疏的块用数组，每个整数 2 个字节
+	稠密的块用位图，固定 8KB
+	连续的块用区间，每段连续的整数 4 个字节，调用 RunOptimize 后生成
+集合运算按高 16 位归并两个有序的容器列表，只有高 16 位相同的容器之间需要运算，
+容器之间的运算根据类型选择归并、按字运算或者合并区间。
+
+序列化使用 Roaring 的标准格式（github.com/RoaringBitmap/RoaringFormatSpec），
+可以与 CRoaring、Java、Go 等语言的 Roaring 库互相读写，格式见 serialize.go。
+*/
+
+// RoaringBitmap 32 位整数的 Roaring 压缩位图，不是并发安全的
+type RoaringBitmap struct {
+	keys       []uint16    // 容器对应的高 16 位，从小到大
+	containers []container // 非空的容器
+}
+
+// NewRoaringBitmap 创建空的 Roaring 位图
+func NewRoaringBitmap() *RoaringBitmap {
+	return &RoaringBitmap{}
+}
+
+// NewRoaringBitmapOf 创建包含指定整数的 Roaring 位图
+func NewRoaringBitmapOf(values ...uint32) *RoaringBitmap {
+	rb := NewRoaringBitmap()
+	rb.Add(values...)
+	return rb
+}
+
+// split 拆分为高 16 位和低 16 位
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+// find 查找高 16 位为 key 的容器的下标，不存在时返回应当插入的位置
+func (rb *RoaringBitmap) find(key uint16) (int, bool) {
+	return slices.BinarySearch(rb.keys, key)
+}
+
+// Add 添加整数
+func (rb *RoaringBitmap) Add(values ...uint32) {
+	for _, x := range values {
+		key, low := split(x)
+		i, found := rb.find(key)
+		if found {
+			rb.containers[i] = rb.containers[i].add(low)
+			continue
+		}
+		rb.keys = slices.Insert(rb.keys, i, key)
+		rb.containers = slices.Insert(rb.containers, i, container(&arrayContainer{values: []uint16{low}}))
+	}
+}
+
+// AddRange 添加 [start, last] 之间的所有整数，新的块直接使用区间容器
+func (rb *RoaringBitmap) AddRange(start, last uint32) {
+	if start > last {
+		return
+	}
+	for key := uint32(start >> 16); key <= last>>16; key++ {
+		lo, hi := uint16(0), uint16(0xFFFF)
+		if key == start>>16 {
+			lo = uint16(start)
+		}
+		if key == last>>16 {
+			hi = uint16(last)
+		}
+		run := &runContainer{runs: []interval{{lo, hi}}}
+		i, found := rb.find(uint16(key))
+		if found {
+			rb.containers[i] = or(rb.containers[i], run)
+			continue
+		}
+		rb.keys = slices.Insert(rb.keys, i, uint16(key))
+		rb.containers = slices.Insert(rb.containers, i, optimize(run))
+	}
+}
+
+// Remove 删除整数
+func (rb *RoaringBitmap) Remove(values ...uint32) {
+	for _, x := range values {
+		key, low := split(x)
+		i, found := rb.find(key)
+		if !found {
+			continue
+		}
+		rb.containers[i] = rb.containers[i].remove(low)
+		if rb.containers[i].cardinality() == 0 {
+			rb.keys = slices.Delete(rb.keys, i, i+1)
+			rb.containers = slices.Delete(rb.containers, i, i+1)
+		}
+	}
+}
+
+// Contains 整数是否存在
+func (rb *RoaringBitmap) Contains(x uint32) bool {
+	key, low := split(x)
+	i, found := rb.find(key)
+	return found && rb.containers[i].contains(low)
+}
+
+// Cardinality 返回整数的数量
+func (rb *RoaringBitmap) Cardinality() int {
+	n := 0
+	for _, c := range rb.containers {
+		n += c.cardinality()
+	}
+	return n
+}
+
+// IsEmpty 是否为空
+func (rb *RoaringBitmap) IsEmpty() bool {
+	return len(rb.containers) == 0
+}
+
+// Minimum 返回最小的整数，为空时返回 false
+func (rb *RoaringBitmap) Minimum() (uint32, bool) {
+	if rb.IsEmpty() {
+		return 0, false
+	}
+	return uint32(rb.keys[0])<<16 | uint32(rb.containers[0].minimum()), true
+}
+
+// Maximum 返回最大的整数，为空时返回 false
+func (rb *RoaringBitmap) Maximum() (uint32, bool) {
+	if rb.IsEmpty() {
+		return 0, false
+	}
+	n := len(rb.keys) - 1
+	return uint32(rb.keys[n])<<16 | uint32(rb.containers[n].maximum()), true
+}
+
+// Rank 返回小于等于 x 的整数数量
+func (rb *RoaringBitmap) Rank(x uint32) int {
+	key, low := split(x)
+	n := 0
+	for i, k := range rb.keys {
+		if k > key {
+			break
+		}
+		if k < key {
+			n += rb.containers[i].cardinality()
+		} else {
+			n += rb.containers[i].rank(low)
+		}
+	}
+	return n
+}
+
+// All 从小到大迭代所有整数
+func (rb *RoaringBitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range rb.containers {
+			high := uint32(rb.keys[i]) << 16
+			if !c.iterate(func(low uint16) bool { return yield(high | uint32(low)) }) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray 返回从小到大排列的所有整数
+func (rb *RoaringBitmap) ToArray() []uint32 {
+	values := make([]uint32, 0, rb.Cardinality())
+	for x := range rb.All() {
+		values = append(values, x)
+	}
+	return values
+}
+
+// Clone 复制位图
+func (rb *RoaringBitmap) Clone() *RoaringBitmap {
+	c := &RoaringBitmap{
+		keys:       slices.Clone(rb.keys),
+		containers: make([]container, len(rb.containers)),
+	}
+	for i, ct := range rb.containers {
+		c.containers[i] = ct.clone()
+	}
+	return c
+}
+
+// Equal 两个位图是否包含相同的整数，与容器的表示无关
+func (rb *RoaringBitmap) Equal(other *RoaringBitmap) bool {
+	if !slices.Equal(rb.keys, other.keys) {
+		return false
+	}
+	for i, c := range rb.containers {
+		if c.cardinality() != other.containers[i].cardinality() ||
+			xor(c, other.containers[i]).cardinality() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// merge 按高 16 位归并两个位图，op 计算高 16 位相同的容器，onlyA/onlyB 表示只在一方的容器是否保留
+func (rb *RoaringBitmap) merge(other *RoaringBitmap, op func(a, b container) container, onlyA, onlyB bool) *RoaringBitmap {
+	result := &RoaringBitmap{}
+	appendContainer := func(key uint16, c container) {
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(rb.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(rb.keys) && rb.keys[i] < other.keys[j]):
+			if onlyA {
+				appendContainer(rb.keys[i], rb.containers[i].clone())
+			}
+			i++
+		case i == len(rb.keys) || rb.keys[i] > other.keys[j]:
+			if onlyB {
+				appendContainer(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			appendContainer(rb.keys[i], op(rb.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// And 交集
+func (rb *RoaringBitmap) And(other *RoaringBitmap) *RoaringBitmap {
+	return rb.merge(other, and, false, false)
+}
+
+// Or 并集
+func (rb *RoaringBitmap) Or(other *RoaringBitmap) *RoaringBitmap {
+	return rb.merge(other, or, true, true)
+}
+
+// Xor 对称差
+func (rb *RoaringBitmap) Xor(other *RoaringBitmap) *RoaringBitmap {
+	return rb.merge(other, xor, true, true)
+}
+
+// AndNot 差集，在 rb 中但不在 other 中
+func (rb *RoaringBitmap) AndNot(other *RoaringBitmap) *RoaringBitmap {
+	return rb.merge(other, andNot, true, false)
+}
+
+// RunOptimize 把每个容器转换为最小的表示（连续的整数较多时转换为区间容器），返回是否有区间容器
+func (rb *RoaringBitmap) RunOptimize() bool {
+	hasRun := false
+	for i, c := range rb.containers {
+		rb.containers[i] = optimize(c)
+		if _, ok := rb.containers[i].(*runContainer); ok {
+			hasRun = true
+		}
+	}
+	return hasRun
+}
+
+// RoaringStats 容器的统计信息
+type RoaringStats struct {
+	Containers       int // 容器数量
+	ArrayContainers  int
+	BitmapContainers int
+	RunContainers    int
+	Cardinality      int // 整数的数量
+	SerializedBytes  int // 序列化后的字节数
+}
+
+// Stats 返回容器的统计信息
+func (rb *RoaringBitmap) Stats() RoaringStats {
+	stats := RoaringStats{Containers: len(rb.containers), SerializedBytes: rb.SerializedSize()}
+	for _, c := range rb.containers {
+		stats.Cardinality += c.cardinality()
+		switch c.(type) {
+		case *arrayContainer:
+			stats.ArrayContainers++
+		case *bitmapContainer:
+			stats.BitmapContainers++
+		case *runContainer:
+			stats.RunContainers++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,326 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// checkRoaring 检查容器的不变式
+func checkRoaring(t *testing.T, rb *RoaringBitmap) {
+	t.Helper()
+	if len(rb.keys) != len(rb.containers) || !slices.IsSorted(rb.keys) {
+		t.Fatalf("keys %v", rb.keys)
+	}
+	for i, c := range rb.containers {
+		if c.cardinality() == 0 {
+			t.Fatalf("container %d is empty", rb.keys[i])
+		}
+		switch c := c.(type) {
+		case *arrayContainer:
+			if len(c.values) > arrayMaxSize {
+				t.Fatalf("array container with %d values", len(c.values))
+			}
+			for j := 1; j < len(c.values); j++ {
+				if c.values[j] <= c.values[j-1] {
+					t.Fatalf("array container not sorted")
+				}
+			}
+		case *bitmapContainer:
+			card := c.card
+			c.count()
+			if card != c.card || card <= arrayMaxSize {
+				t.Fatalf("bitmap container card %d, counted %d", card, c.card)
+			}
+		case *runContainer:
+			for j, r := range c.runs {
+				if r.start > r.last || (j > 0 && int(r.start) <= int(c.runs[j-1].last)+1) {
+					t.Fatalf("bad runs %v", c.runs)
+				}
+			}
+		}
+	}
+}
+
+// randomValue 集中在几个块中，让三种容器都会出现
+func randomValue(r *rand.Rand) uint32 {
+	switch r.Intn(4) {
+	case 0:
+		return uint32(r.Intn(3))<<16 | uint32(r.Intn(100)) // 稀疏
+	case 1:
+		return 3<<16 | uint32(r.Intn(8000)) // 超过 4096 个，转换为位图
+	case 2:
+		return 4<<16 | uint32(r.Intn(64))<<10 | uint32(r.Intn(1000)) // 连续的段
+	default:
+		return r.Uint32()
+	}
+}
+
+// 与 map 对比
+func TestRoaringRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func() (*RoaringBitmap, map[uint32]bool) {
+		rb, m := NewRoaringBitmap(), map[uint32]bool{}
+		for i := r.Intn(8000); i > 0; i-- {
+			x := randomValue(r)
+			switch r.Intn(10) {
+			case 0, 1:
+				rb.Remove(x)
+				delete(m, x)
+			case 2:
+				last := x + uint32(r.Intn(1000))
+				if last < x {
+					last = x
+				}
+				rb.AddRange(x, last)
+				for y := x; ; y++ {
+					m[y] = true
+					if y == last {
+						break
+					}
+				}
+			default:
+				rb.Add(x)
+				m[x] = true
+			}
+			if r.Intn(2000) == 0 {
+				rb.RunOptimize()
+			}
+		}
+		if r.Intn(2) == 0 {
+			rb.RunOptimize()
+		}
+		checkRoaring(t, rb)
+		return rb, m
+	}
+	sorted := func(m map[uint32]bool) []uint32 {
+		values := make([]uint32, 0, len(m))
+		for x, ok := range m {
+			if ok {
+				values = append(values, x)
+			}
+		}
+		slices.Sort(values)
+		return values
+	}
+	for round := 0; round < 8; round++ {
+		a, ma := random()
+		b, mb := random()
+		want := sorted(ma)
+		if got := a.ToArray(); !slices.Equal(got, want) || a.Cardinality() != len(want) {
+			t.Fatalf("round %d: %d values, want %d", round, len(got), len(want))
+		}
+		for i := 0; i < 1000; i++ {
+			x := randomValue(r)
+			if a.Contains(x) != ma[x] {
+				t.Fatalf("round %d: Contains(%d) = %v", round, x, !ma[x])
+			}
+			rank, _ := slices.BinarySearch(want, x+1)
+			if x == ^uint32(0) {
+				rank = len(want)
+			}
+			if got := a.Rank(x); got != rank {
+				t.Fatalf("round %d: Rank(%d) = %d, want %d", round, x, got, rank)
+			}
+		}
+		if len(want) > 0 {
+			lo, _ := a.Minimum()
+			hi, _ := a.Maximum()
+			if lo != want[0] || hi != want[len(want)-1] {
+				t.Fatalf("round %d: Minimum/Maximum = %d, %d", round, lo, hi)
+			}
+		}
+		ops := []struct {
+			name string
+			got  *RoaringBitmap
+			want func(x, y bool) bool
+		}{
+			{"And", a.And(b), func(x, y bool) bool { return x && y }},
+			{"Or", a.Or(b), func(x, y bool) bool { return x || y }},
+			{"Xor", a.Xor(b), func(x, y bool) bool { return x != y }},
+			{"AndNot", a.AndNot(b), func(x, y bool) bool { return x && !y }},
+		}
+		union := map[uint32]bool{}
+		for x := range ma {
+			union[x] = true
+		}
+		for x := range mb {
+			union[x] = true
+		}
+		for _, op := range ops {
+			checkRoaring(t, op.got)
+			m := map[uint32]bool{}
+			for x := range union {
+				if op.want(ma[x], mb[x]) {
+					m[x] = true
+				}
+			}
+			if got := op.got.ToArray(); !slices.Equal(got, sorted(m)) {
+				t.Fatalf("round %d: %s has %d values, want %d", round, op.name, len(got), len(m))
+			}
+		}
+		// 序列化后读回来相同，并且 RunOptimize 不改变内容
+		for _, rb := range []*RoaringBitmap{a, b} {
+			data, err := rb.MarshalBinary()
+			if err != nil || len(data) != rb.SerializedSize() {
+				t.Fatalf("round %d: MarshalBinary %d bytes, %v, want %d", round, len(data), err, rb.SerializedSize())
+			}
+			var c RoaringBitmap
+			if err := c.UnmarshalBinary(data); err != nil {
+				t.Fatalf("round %d: UnmarshalBinary: %v", round, err)
+			}
+			checkRoaring(t, &c)
+			if !c.Equal(rb) {
+				t.Fatalf("round %d: round trip changed the bitmap", round)
+			}
+			c.RunOptimize()
+			checkRoaring(t, &c)
+			if !c.Equal(rb) || c.SerializedSize() > rb.SerializedSize() {
+				t.Fatalf("round %d: RunOptimize changed the bitmap or made it larger", round)
+			}
+		}
+	}
+}
+
+// 按 Roaring 格式规范手工构造的数据
+func TestRoaringFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		values func() *RoaringBitmap
+		hex    string
+	}{
+		{
+			"empty",
+			NewRoaringBitmap,
+			"3a300000" + "00000000",
+		},
+		{
+			// 没有区间容器：cookie 12346，容器数量，描述头，偏移头，两个数组容器
+			"arrays",
+			func() *RoaringBitmap { return NewRoaringBitmapOf(1, 2, 3, 1<<16+5) },
+			"3a300000" + "02000000" + "00000200" + "01000000" + "18000000" + "1e000000" + "010002000300" + "0500",
+		},
+		{
+			// 有区间容器，容器少于 4 个：cookie 12347|(2-1)<<16，区间标志，描述头，没有偏移头
+			"runs",
+			func() *RoaringBitmap {
+				rb := NewRoaringBitmap()
+				rb.AddRange(0, 99)
+				rb.Add(1<<16 + 5)
+				rb.RunOptimize()
+				return rb
+			},
+			"3b300100" + "01" + "00006300" + "01000000" + "0100" + "00006300" + "0500",
+		},
+	}
+	for _, tt := range tests {
+		rb := tt.values()
+		data, err := rb.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(data); got != tt.hex {
+			t.Errorf("%s: MarshalBinary = %s, want %s", tt.name, got, tt.hex)
+		}
+		want, _ := hex.DecodeString(tt.hex)
+		var c RoaringBitmap
+		if err := c.UnmarshalBinary(want); err != nil || !c.Equal(rb) {
+			t.Errorf("%s: UnmarshalBinary = %v, %v", tt.name, c.ToArray(), err)
+		}
+	}
+
+	// 4 个以上的容器并且有区间容器时有偏移头，位图容器按数量判断
+	rb := NewRoaringBitmap()
+	rb.AddRange(0, 9999)
+	rb.Add(1<<16, 2<<16, 3<<16)
+	for x := uint32(0); x < 10000; x += 2 {
+		rb.Add(5<<16 | x)
+	}
+	rb.RunOptimize()
+	var buf bytes.Buffer
+	n, err := rb.WriteTo(&buf)
+	if err != nil || int(n) != buf.Len() || n != int64(rb.SerializedSize()) {
+		t.Fatalf("WriteTo = %d, %v, want %d", n, err, rb.SerializedSize())
+	}
+	data := buf.Bytes()
+	// 偏移头在区间标志和描述头之后，第一个容器紧跟在偏移头之后
+	if off := int(data[4+1+4*5]) | int(data[4+1+4*5+1])<<8; off != 4+1+8*5 {
+		t.Fatalf("first offset = %d", off)
+	}
+	stats := rb.Stats()
+	if stats.RunContainers != 1 || stats.ArrayContainers != 3 || stats.BitmapContainers != 1 {
+		t.Fatalf("Stats = %+v", stats)
+	}
+
+	// 不完整或者不合法的数据
+	var c RoaringBitmap
+	for i := 0; i < len(data); i += 97 {
+		if err := c.UnmarshalBinary(data[:i]); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("truncated at %d: %v", i, err)
+		}
+	}
+	for _, bad := range []string{
+		"39300000" + "00000000", // cookie 不对
+		"3a300000" + "02000000" + "01000000" + "00000000" + "00000000" + "00000000" + "0000" + "0000", // 键不是递增的
+		"3a300000" + "01000000" + "00000100" + "10000000" + "02000100",                                // 数组不是递增的
+		"3b300000" + "01" + "00000900" + "0200" + "00000400" + "04000400",                             // 区间重叠
+	} {
+		data, _ := hex.DecodeString(bad)
+		if err := c.UnmarshalBinary(data); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("%s: %v", bad, err)
+		}
+	}
+	if !c.IsEmpty() {
+		t.Fatalf("failed UnmarshalBinary changed the bitmap")
+	}
+}
+
+// 其他 Roaring 实现生成的文件，来自 RoaringFormatSpec 的 testdata（由 Java 版本生成）
+// 内容为 0 到 100000 之间 1000 的倍数、[100000, 200000) 中每个数的 3 倍、[700000, 800000)，
+// bitmapwithruns.bin 在生成前调用了 runOptimize，bitmapwithoutruns.bin 没有
+func TestRoaringFormatInterop(t *testing.T) {
+	want := NewRoaringBitmap()
+	for x := uint32(0); x < 100000; x += 1000 {
+		want.Add(x)
+	}
+	for x := uint32(100000); x < 200000; x++ {
+		want.Add(3 * x)
+	}
+	for x := uint32(700000); x < 800000; x++ {
+		want.Add(x)
+	}
+	optimized := want.Clone()
+	optimized.RunOptimize()
+	for name, built := range map[string]*RoaringBitmap{"bitmapwithruns.bin": optimized, "bitmapwithoutruns.bin": want} {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rb RoaringBitmap
+		if err := rb.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		checkRoaring(t, &rb)
+		if !rb.Equal(want) {
+			t.Fatalf("%s: Cardinality = %d, want %d", name, rb.Cardinality(), want.Cardinality())
+		}
+		// 重新编码得到同样的字节
+		got, err := rb.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("%s: MarshalBinary differs from the file (%d bytes, want %d)", name, len(got), len(data))
+		}
+		// 自己构造的位图也编码为同样的字节
+		if got, _ = built.MarshalBinary(); !bytes.Equal(got, data) {
+			t.Errorf("%s: built bitmap encodes differently (%d bytes, want %d)", name, len(got), len(data))
+		}
+	}
+}
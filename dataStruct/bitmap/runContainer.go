@@ -0,0 +1,214 @@
+package bitmap
+
+import "sort"
+
+// interval 连续整数的区间 [start, last]
+type interval struct {
+	start, last uint16
+}
+
+// runContainer 区间容器，区间有序、不重叠、不相邻
+type runContainer struct {
+	runs []interval
+}
+
+func (r *runContainer) cardinality() int {
+	n := 0
+	for _, run := range r.runs {
+		n += int(run.last-run.start) + 1
+	}
+	return n
+}
+
+// search 返回第一个 start 大于 x 的区间的下标
+func (r *runContainer) search(x uint16) int {
+	return sort.Search(len(r.runs), func(i int) bool { return r.runs[i].start > x })
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := r.search(x) - 1
+	return i >= 0 && x <= r.runs[i].last
+}
+
+func (r *runContainer) add(x uint16) container {
+	i := r.search(x)
+	prev := i - 1
+	if prev >= 0 && x <= r.runs[prev].last {
+		return r
+	}
+	// x 不在前一个区间中，所以 x > last，x-1 不会溢出；x < 后一个区间的 start，x+1 不会溢出
+	joinPrev := prev >= 0 && r.runs[prev].last == x-1
+	joinNext := i < len(r.runs) && r.runs[i].start == x+1
+	switch {
+	case joinPrev && joinNext:
+		r.runs[prev].last = r.runs[i].last
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case joinPrev:
+		r.runs[prev].last = x
+	case joinNext:
+		r.runs[i].start = x
+	default:
+		r.runs = append(r.runs, interval{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i] = interval{x, x}
+	}
+	return r
+}
+
+func (r *runContainer) remove(x uint16) container {
+	i := r.search(x) - 1
+	if i < 0 || x > r.runs[i].last {
+		return r
+	}
+	run := r.runs[i]
+	switch {
+	case run.start == run.last:
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case x == run.start:
+		r.runs[i].start++
+	case x == run.last:
+		r.runs[i].last--
+	default:
+		// 从中间拆成两个区间
+		r.runs = append(r.runs, interval{})
+		copy(r.runs[i+2:], r.runs[i+1:])
+		r.runs[i].last = x - 1
+		r.runs[i+1] = interval{x + 1, run.last}
+	}
+	return r
+}
+
+func (r *runContainer) iterate(yield func(uint16) bool) bool {
+	for _, run := range r.runs {
+		for v := run.start; ; v++ {
+			if !yield(v) {
+				return false
+			}
+			if v == run.last {
+				break
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{runs: append([]interval(nil), r.runs...)}
+}
+
+func (r *runContainer) minimum() uint16 {
+	return r.runs[0].start
+}
+
+func (r *runContainer) maximum() uint16 {
+	return r.runs[len(r.runs)-1].last
+}
+
+func (r *runContainer) rank(x uint16) int {
+	n := 0
+	for _, run := range r.runs {
+		if run.start > x {
+			break
+		}
+		n += int(min(run.last, x)-run.start) + 1
+	}
+	return n
+}
+
+func (r *runContainer) numRuns() int {
+	return len(r.runs)
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	b.orWith(r)
+	return b
+}
+
+// union 区间的并集，合并重叠和相邻的区间
+func (r *runContainer) union(other *runContainer) *runContainer {
+	result := &runContainer{runs: make([]interval, 0, len(r.runs)+len(other.runs))}
+	i, j := 0, 0
+	for i < len(r.runs) || j < len(other.runs) {
+		var next interval
+		if j == len(other.runs) || (i < len(r.runs) && r.runs[i].start <= other.runs[j].start) {
+			next = r.runs[i]
+			i++
+		} else {
+			next = other.runs[j]
+			j++
+		}
+		n := len(result.runs)
+		// 与上一个区间重叠或相邻时合并，last < 65535 时 last+1 才不会溢出
+		if n > 0 && (result.runs[n-1].last == 0xFFFF || next.start <= result.runs[n-1].last+1) {
+			result.runs[n-1].last = max(result.runs[n-1].last, next.last)
+			continue
+		}
+		result.runs = append(result.runs, next)
+	}
+	return result
+}
+
+// intersect 区间的交集
+func (r *runContainer) intersect(other *runContainer) *runContainer {
+	result := &runContainer{}
+	i, j := 0, 0
+	for i < len(r.runs) && j < len(other.runs) {
+		a, b := r.runs[i], other.runs[j]
+		if start, last := max(a.start, b.start), min(a.last, b.last); start <= last {
+			result.runs = append(result.runs, interval{start, last})
+		}
+		// 先结束的区间不会再与后面的区间相交
+		if a.last < b.last {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// toRun 转换为区间容器
+func toRun(c container) *runContainer {
+	if r, ok := c.(*runContainer); ok {
+		return r
+	}
+	r := &runContainer{runs: make([]interval, 0, c.numRuns())}
+	c.iterate(func(v uint16) bool {
+		if n := len(r.runs); n > 0 && r.runs[n-1].last+1 == v {
+			r.runs[n-1].last = v
+		} else {
+			r.runs = append(r.runs, interval{v, v})
+		}
+		return true
+	})
+	return r
+}
+
+// serializedSize 容器序列化后的字节数
+func serializedSize(c container) int {
+	switch c := c.(type) {
+	case *arrayContainer:
+		return 2 * len(c.values)
+	case *runContainer:
+		return 2 + 4*len(c.runs)
+	default:
+		return 8 * bitmapWords
+	}
+}
+
+// optimize 转换为序列化后最小的表示：区间更小时使用区间容器，否则按数量使用数组或位图
+func optimize(c container) container {
+	card := c.cardinality()
+	size := 8 * bitmapWords
+	if card <= arrayMaxSize {
+		size = 2 * card
+	}
+	if 2+4*c.numRuns() < size {
+		return toRun(c)
+	}
+	if _, ok := c.(*runContainer); !ok {
+		return c
+	}
+	return shrink(c.toBitmap())
+}
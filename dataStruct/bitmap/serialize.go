@@ -0,0 +1,298 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// Roaring 的标准序列化格式（所有整数都是小端序）
+/*
+	cookie        没有区间容器时为 32 位的 12346，后跟 32 位的容器数量 n；
+	              有区间容器时低 16 位为 12347，高 16 位为 n-1，后跟 (n+7)/8 字节的位集合，第 i 位表示第 i 个容器是否是区间容器
+	描述头        每个容器 16 位的键（高 16 位）和 16 位的 数量-1
+	偏移头        每个容器 32 位的偏移量（从开头算起），有区间容器并且 n < 4 时省略
+	容器          数组：数量 个 16 位整数；位图：1024 个 64 位字；区间：16 位的区间数量，每个区间 16 位的起点和 16 位的 长度-1
+读取时不是区间容器的，数量不超过 4096 为数组，否则为位图。
+*/
+
+// 序列化格式的常量
+const (
+	serialCookieNoRun = 12346 // 没有区间容器
+	serialCookie      = 12347 // 有区间容器
+	noOffsetThreshold = 4     // 有区间容器时，容器数量小于 4 不写偏移头
+)
+
+// ErrInvalidFormat 数据不是合法的 Roaring 序列化格式
+var ErrInvalidFormat = errors.New("bitmap: invalid roaring format")
+
+// hasRun 是否有区间容器
+func (rb *RoaringBitmap) hasRun() bool {
+	for _, c := range rb.containers {
+		if _, ok := c.(*runContainer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// headerSize 容器之前的字节数
+func (rb *RoaringBitmap) headerSize() int {
+	n := len(rb.containers)
+	if !rb.hasRun() {
+		return 8 + 8*n
+	}
+	size := 4 + (n+7)/8 + 4*n
+	if n >= noOffsetThreshold {
+		size += 4 * n
+	}
+	return size
+}
+
+// SerializedSize 返回序列化后的字节数
+func (rb *RoaringBitmap) SerializedSize() int {
+	size := rb.headerSize()
+	for _, c := range rb.containers {
+		size += serializedSize(c)
+	}
+	return size
+}
+
+// MarshalBinary 序列化为 Roaring 的标准格式
+func (rb *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(rb.SerializedSize())
+	_, err := rb.WriteTo(&buf)
+	return buf.Bytes(), err
+}
+
+// WriteTo 按 Roaring 的标准格式写入 w，返回写入的字节数
+func (rb *RoaringBitmap) WriteTo(w io.Writer) (int64, error) {
+	n := len(rb.containers)
+	hasRun := rb.hasRun()
+	header := make([]byte, 0, rb.headerSize())
+	if hasRun {
+		header = binary.LittleEndian.AppendUint32(header, serialCookie|uint32(n-1)<<16)
+		flags := make([]byte, (n+7)/8)
+		for i, c := range rb.containers {
+			if _, ok := c.(*runContainer); ok {
+				flags[i/8] |= 1 << (i % 8)
+			}
+		}
+		header = append(header, flags...)
+	} else {
+		header = binary.LittleEndian.AppendUint32(header, serialCookieNoRun)
+		header = binary.LittleEndian.AppendUint32(header, uint32(n))
+	}
+	for i, c := range rb.containers {
+		header = binary.LittleEndian.AppendUint16(header, rb.keys[i])
+		header = binary.LittleEndian.AppendUint16(header, uint16(c.cardinality()-1))
+	}
+	if !hasRun || n >= noOffsetThreshold {
+		offset := rb.headerSize()
+		for _, c := range rb.containers {
+			header = binary.LittleEndian.AppendUint32(header, uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+	written, err := w.Write(header)
+	total := int64(written)
+	if err != nil {
+		return total, err
+	}
+	var buf []byte
+	for _, c := range rb.containers {
+		buf = appendContainer(buf[:0], c)
+		written, err = w.Write(buf)
+		total += int64(written)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// appendContainer 序列化一个容器
+func appendContainer(buf []byte, c container) []byte {
+	switch c := c.(type) {
+	case *arrayContainer:
+		for _, v := range c.values {
+			buf = binary.LittleEndian.AppendUint16(buf, v)
+		}
+	case *bitmapContainer:
+		for _, w := range c.words {
+			buf = binary.LittleEndian.AppendUint64(buf, w)
+		}
+	case *runContainer:
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(c.runs)))
+		for _, r := range c.runs {
+			buf = binary.LittleEndian.AppendUint16(buf, r.start)
+			buf = binary.LittleEndian.AppendUint16(buf, r.last-r.start)
+		}
+	}
+	return buf
+}
+
+// UnmarshalBinary 从 Roaring 的标准格式读取，替换原有的内容
+func (rb *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := rb.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return ErrInvalidFormat
+	}
+	return nil
+}
+
+// reader 记录读取的字节数，遇到错误后不再读取
+type reader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// read 读取 n 个字节
+func (r *reader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	buf := make([]byte, n)
+	m, err := io.ReadFull(r.r, buf)
+	r.n += int64(m)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	r.err = err
+	return buf
+}
+
+// ReadFrom 从 r 读取 Roaring 的标准格式，替换原有的内容，返回读取的字节数
+// 格式不合法时返回 ErrInvalidFormat，数据不完整时返回 io.ErrUnexpectedEOF，原有的内容不变
+func (rb *RoaringBitmap) ReadFrom(r io.Reader) (int64, error) {
+	in := &reader{r: r}
+	result, err := readRoaring(in)
+	if err == nil {
+		err = in.err
+	}
+	if err != nil {
+		return in.n, err
+	}
+	*rb = *result
+	return in.n, nil
+}
+
+// readRoaring 读取并检查格式
+func readRoaring(in *reader) (*RoaringBitmap, error) {
+	cookie := in.read(4)
+	if in.err != nil {
+		return nil, in.err
+	}
+	var n int
+	var runFlags []byte
+	switch c := binary.LittleEndian.Uint32(cookie); {
+	case c == serialCookieNoRun:
+		size := in.read(4)
+		if in.err != nil {
+			return nil, in.err
+		}
+		if n = int(binary.LittleEndian.Uint32(size)); n > 1<<16 {
+			return nil, ErrInvalidFormat
+		}
+	case c&0xFFFF == serialCookie:
+		n = int(c>>16) + 1
+		runFlags = in.read((n + 7) / 8)
+	default:
+		return nil, ErrInvalidFormat
+	}
+	header := in.read(4 * n)
+	if runFlags == nil || n >= noOffsetThreshold {
+		in.read(4 * n) // 偏移头只用于随机访问，顺序读取时不需要
+	}
+	if in.err != nil {
+		return nil, in.err
+	}
+	rb := &RoaringBitmap{keys: make([]uint16, n), containers: make([]container, n)}
+	for i := range n {
+		rb.keys[i] = binary.LittleEndian.Uint16(header[4*i:])
+		if i > 0 && rb.keys[i] <= rb.keys[i-1] {
+			return nil, ErrInvalidFormat
+		}
+		card := int(binary.LittleEndian.Uint16(header[4*i+2:])) + 1
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+		c, err := readContainer(in, card, isRun)
+		if err != nil {
+			return nil, err
+		}
+		rb.containers[i] = c
+	}
+	return rb, nil
+}
+
+// readContainer 读取一个容器，检查数量与描述头一致，整数有序
+func readContainer(in *reader, card int, isRun bool) (container, error) {
+	switch {
+	case isRun:
+		buf := in.read(2)
+		if in.err != nil {
+			return nil, in.err
+		}
+		runs := int(binary.LittleEndian.Uint16(buf))
+		buf = in.read(4 * runs)
+		if in.err != nil {
+			return nil, in.err
+		}
+		r := &runContainer{runs: make([]interval, 0, runs)}
+		total := 0
+		for i := range runs {
+			start := binary.LittleEndian.Uint16(buf[4*i:])
+			length := binary.LittleEndian.Uint16(buf[4*i+2:])
+			// 区间不能超过 65535，不能与前一个区间重叠，相邻时合并
+			last := len(r.runs) - 1
+			if int(start)+int(length) > 0xFFFF || (last >= 0 && start <= r.runs[last].last) {
+				return nil, ErrInvalidFormat
+			}
+			if last >= 0 && start == r.runs[last].last+1 {
+				r.runs[last].last = start + length
+			} else {
+				r.runs = append(r.runs, interval{start, start + length})
+			}
+			total += int(length) + 1
+		}
+		if total != card {
+			return nil, ErrInvalidFormat
+		}
+		return r, nil
+	case card <= arrayMaxSize:
+		buf := in.read(2 * card)
+		if in.err != nil {
+			return nil, in.err
+		}
+		a := &arrayContainer{values: make([]uint16, card)}
+		for i := range a.values {
+			a.values[i] = binary.LittleEndian.Uint16(buf[2*i:])
+			if i > 0 && a.values[i] <= a.values[i-1] {
+				return nil, ErrInvalidFormat
+			}
+		}
+		return a, nil
+	default:
+		buf := in.read(8 * bitmapWords)
+		if in.err != nil {
+			return nil, in.err
+		}
+		b := &bitmapContainer{}
+		total := 0
+		for i := range b.words {
+			b.words[i] = binary.LittleEndian.Uint64(buf[8*i:])
+			total += bits.OnesCount64(b.words[i])
+		}
+		if total != card {
+			return nil, ErrInvalidFormat
+		}
+		b.card = total
+		return b, nil
+	}
+}
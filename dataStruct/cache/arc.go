@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+// ARC 缓存（Adaptive Replacement Cache，Megiddo & Modha 2003）
+/*
+ARC 同时维护最近访问和频繁访问两部分，并根据访问情况自动调整两部分的大小：
+	T1  只被访问过一次的键值对（最近访问），LRU 顺序
+	T2  被访问过至少两次的键值对（频繁访问），LRU 顺序
+	B1  从 T1 淘汰的键（幽灵，不保存值）
+	B2  从 T2 淘汰的键
+T1+T2 不超过容量 c，T1+B1、T1+T2+B1+B2 分别不超过 c 和 2c。
+p 是 T1 的目标大小：添加的键命中 B1，说明 T1 太小了，增大 p；命中 B2 说明 T2 太小了，减小 p。
+淘汰时 T1 超过 p 就淘汰 T1 的，否则淘汰 T2 的。
+
+相比 2Q 不需要调参数，对扫描和频繁访问两种模式都能自适应。
+*/
+
+// ARC 中的四个队列
+const (
+	arcT1 = iota
+	arcT2
+	arcB1
+	arcB2
+)
+
+// arcEntry 键值对，在 B1、B2 中时只有键有意义
+type arcEntry[K comparable, V any] struct {
+	key   K
+	value V
+	where int                              // 所在的队列
+	node  *deque.ListNode[*arcEntry[K, V]] // 在所在队列中的节点
+}
+
+// ARCCache ARC 缓存，不是并发安全的
+type ARCCache[K comparable, V any] struct {
+	capacity int
+	p        int                                  // T1 的目标大小
+	items    map[K]*arcEntry[K, V]                // 四个队列中的所有键
+	lists    [4]deque.DoubleList[*arcEntry[K, V]] // T1、T2、B1、B2
+}
+
+// NewARCCache 创建最多保存 capacity 个键值对的 ARC 缓存
+func NewARCCache[K comparable, V any](capacity int) *ARCCache[K, V] {
+	capacity = max(capacity, 1)
+	return &ARCCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*arcEntry[K, V], 2*capacity),
+	}
+}
+
+// Len 返回键值对数量，不包括幽灵队列中的键
+func (c *ARCCache[K, V]) Len() int {
+	return c.lists[arcT1].Len() + c.lists[arcT2].Len()
+}
+
+// Target 返回 T1 当前的目标大小，用于观察自适应的过程
+func (c *ARCCache[K, V]) Target() int {
+	return c.p
+}
+
+// move 将节点移到指定队列的头部
+func (c *ARCCache[K, V]) move(e *arcEntry[K, V], where int) {
+	c.lists[e.where].Remove(e.node)
+	e.node = c.lists[where].PushFront(e)
+	e.where = where
+}
+
+// back 返回队列尾部的键值对，队列为空时返回 nil
+func (c *ARCCache[K, V]) back(where int) *arcEntry[K, V] {
+	if node := c.lists[where].Last(); !node.IsNil() {
+		return node.GetValue()
+	}
+	return nil
+}
+
+// Get 获取键值对，命中 T1 或 T2 时移到 T2 头部
+func (c *ARCCache[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok || e.where >= arcB1 {
+		return value, false
+	}
+	c.move(e, arcT2)
+	return e.value, true
+}
+
+// Peek 获取键值对，不改变顺序
+func (c *ARCCache[K, V]) Peek(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok || e.where >= arcB1 {
+		return value, false
+	}
+	return e.value, true
+}
+
+// Put 添加键值对
+func (c *ARCCache[K, V]) Put(key K, value V) {
+	e, ok := c.items[key]
+	if ok {
+		switch e.where {
+		case arcT1, arcT2:
+			// 缓存中已存在，替换值，算作一次访问
+			e.value = value
+			c.move(e, arcT2)
+			return
+		case arcB1:
+			// 命中 B1，T1 太小，增大 p
+			delta := max(c.lists[arcB2].Len()/c.lists[arcB1].Len(), 1)
+			c.p = min(c.p+delta, c.capacity)
+			c.replace(false)
+		case arcB2:
+			// 命中 B2，T2 太小，减小 p
+			delta := max(c.lists[arcB1].Len()/c.lists[arcB2].Len(), 1)
+			c.p = max(c.p-delta, 0)
+			c.replace(true)
+		}
+		e.value = value
+		c.move(e, arcT2)
+		return
+	}
+	// 完全没有见过的键
+	t1, b1 := c.lists[arcT1].Len(), c.lists[arcB1].Len()
+	total := t1 + b1 + c.lists[arcT2].Len() + c.lists[arcB2].Len()
+	if t1+b1 >= c.capacity {
+		if t1 < c.capacity {
+			c.dropGhost(arcB1)
+			c.replace(false)
+		} else {
+			// B1 为空，直接淘汰 T1 最久没有访问的
+			c.drop(c.back(arcT1))
+		}
+	} else if total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.dropGhost(arcB2)
+		}
+		c.replace(false)
+	}
+	entry := &arcEntry[K, V]{key: key, value: value, where: arcT1}
+	entry.node = c.lists[arcT1].PushFront(entry)
+	c.items[key] = entry
+}
+
+// replace 缓存满了时淘汰一个键值对，键留在对应的幽灵队列中
+func (c *ARCCache[K, V]) replace(inB2 bool) {
+	if c.Len() < c.capacity {
+		return
+	}
+	t1 := c.lists[arcT1].Len()
+	if t1 > 0 && (t1 > c.p || (inB2 && t1 == c.p)) {
+		e := c.back(arcT1)
+		c.move(e, arcB1)
+		e.value = *new(V)
+	} else if e := c.back(arcT2); e != nil {
+		c.move(e, arcB2)
+		e.value = *new(V)
+	}
+}
+
+// dropGhost 删除幽灵队列中最早的键
+func (c *ARCCache[K, V]) dropGhost(where int) {
+	if e := c.back(where); e != nil {
+		c.drop(e)
+	}
+}
+
+// drop 彻底删除一个键
+func (c *ARCCache[K, V]) drop(e *arcEntry[K, V]) {
+	c.lists[e.where].Remove(e.node)
+	delete(c.items, e.key)
+}
+
+// Remove 删除键值对，返回键是否在缓存中，幽灵队列中的键也一并删除
+func (c *ARCCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.drop(e)
+	return e.where < arcB1
+}
@@ -0,0 +1,33 @@
+package cache
+
+// 缓存淘汰策略
+/*
+LRU 实现简单，但一次大范围的扫描（比如遍历所有数据）会把热点数据全部挤出缓存，
+所以热点路径上的缓存需要抗扫描的策略：
+
+	LFU       淘汰访问次数最少的，O(1) 实现使用按访问次数排列的链表
+	2Q        新数据先进入一个小的先进先出队列，再次被访问才进入主 LRU 队列
+	ARC       自适应地调整最近访问和频繁访问两部分的大小
+	W-TinyLFU 新数据先进入一个很小的窗口 LRU，被挤出窗口时与主缓存的淘汰者比较访问频率（Count-Min Sketch 估计），频率高的留下
+
+以上策略都实现了 Cache 接口，可以通过 Replay、ReplayTrace 回放访问记录比较命中率，
+命令行工具见 cachesim。
+*/
+
+// Cache 缓存接口，容量满了以后按各自的策略淘汰
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)  // 获取并记录一次访问
+	Peek(key K) (V, bool) // 获取但不记录访问
+	Put(key K, value V)   // 添加或替换
+	Remove(key K) bool    // 删除，返回键是否存在
+	Len() int             // 键值对数量
+}
+
+var (
+	_ Cache[string, int] = (*LRUCache[string, int])(nil)
+	_ Cache[string, int] = (*SyncLRUCache[string, int])(nil)
+	_ Cache[string, int] = (*LFUCache[string, int])(nil)
+	_ Cache[string, int] = (*TwoQueueCache[string, int])(nil)
+	_ Cache[string, int] = (*ARCCache[string, int])(nil)
+	_ Cache[string, int] = (*TinyLFUCache[string, int])(nil)
+)
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 各个淘汰策略的随机操作：数量不超过容量，命中时返回最后一次添加的值，删除后不能再命中
+func TestCachesRandomOps(t *testing.T) {
+	const capacity = 30
+	caches := map[string]Cache[int, int]{
+		"LFU":       NewLFUCache[int, int](capacity),
+		"2Q":        NewTwoQueueCache[int, int](capacity),
+		"ARC":       NewARCCache[int, int](capacity),
+		"W-TinyLFU": NewTinyLFUCache[int, int](capacity),
+	}
+	for name, c := range caches {
+		r := rand.New(rand.NewSource(1))
+		values := make(map[int]int)
+		for i := 0; i < 100000; i++ {
+			key := r.Intn(80)
+			switch r.Intn(4) {
+			case 0, 1:
+				c.Put(key, i)
+				values[key] = i
+			case 2:
+				if v, ok := c.Get(key); ok && v != values[key] {
+					t.Fatalf("%s: Get(%d) = %d, want %d", name, key, v, values[key])
+				}
+			case 3:
+				c.Remove(key)
+				delete(values, key)
+				if _, ok := c.Peek(key); ok {
+					t.Fatalf("%s: Peek(%d) after Remove", name, key)
+				}
+			}
+			if c.Len() > capacity {
+				t.Fatalf("%s: Len() = %d, want <= %d", name, c.Len(), capacity)
+			}
+		}
+	}
+}
+
+// ARC 的幽灵队列不超过容量，T1 的目标大小在 [0, c] 之间
+func TestARCCacheInvariants(t *testing.T) {
+	const capacity = 20
+	c := NewARCCache[int, int](capacity)
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 100000; i++ {
+		// 一半时间访问热点，一半时间扫描
+		key := r.Intn(15)
+		if i/1000%2 == 1 {
+			key = i
+		}
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, i)
+		}
+		t1, t2 := c.lists[arcT1].Len(), c.lists[arcT2].Len()
+		b1, b2 := c.lists[arcB1].Len(), c.lists[arcB2].Len()
+		if t1+b1 > capacity || t1+t2+b1+b2 > 2*capacity || len(c.items) != t1+t2+b1+b2 {
+			t.Fatalf("T1 %d T2 %d B1 %d B2 %d items %d", t1, t2, b1, b2, len(c.items))
+		}
+		if c.p < 0 || c.p > capacity {
+			t.Fatalf("p = %d", c.p)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// cachesim 在访问记录上回放，比较各缓存淘汰策略的命中率
+//
+// 用法：
+//
+//	cachesim [-capacity n,...] [trace.txt ...]
+//	cachesim [-capacity n,...] -zipf n [-keys n] [-s 1.1] [-seed n]
+//
+// 访问记录文件每行一次访问，取第一个空白分隔的字段作为键，忽略空行和 # 开头的行；
+// 多个文件依次拼接，省略时从标准输入读取。
+// 使用 -zipf 时不读取文件，而是生成 n 次服从 Zipf 分布的访问，用来快速对比各策略。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/cache"
+)
+
+// policy 一种淘汰策略
+type policy struct {
+	name string
+	new  func(capacity int) cache.Cache[string, struct{}]
+}
+
+var policies = []policy{
+	{"LRU", func(n int) cache.Cache[string, struct{}] { return cache.NewLRUCache[string, struct{}](n) }},
+	{"LFU", func(n int) cache.Cache[string, struct{}] { return cache.NewLFUCache[string, struct{}](n) }},
+	{"2Q", func(n int) cache.Cache[string, struct{}] { return cache.NewTwoQueueCache[string, struct{}](n) }},
+	{"ARC", func(n int) cache.Cache[string, struct{}] { return cache.NewARCCache[string, struct{}](n) }},
+	{"W-TinyLFU", func(n int) cache.Cache[string, struct{}] {
+		return cache.NewTinyLFUCacheWithHasher[string, struct{}](n, hashmap.SeededHasher[string](0))
+	}},
+}
+
+func main() {
+	capacities := flag.String("capacity", "1000", "缓存容量，多个容量用逗号分隔")
+	zipf := flag.Int("zipf", 0, "生成的 Zipf 访问次数，为 0 时读取访问记录文件")
+	keys := flag.Uint64("keys", 100000, "Zipf 访问中不同键的数量")
+	s := flag.Float64("s", 1.1, "Zipf 分布的参数 s，必须大于 1")
+	seed := flag.Int64("seed", 1, "Zipf 访问的随机数种子")
+	flag.Parse()
+
+	sizes, err := parseCapacities(*capacities)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var trace []string
+	if *zipf > 0 {
+		trace = zipfTrace(*zipf, *keys, *s, *seed)
+	} else {
+		trace, err = readTraces(flag.Args())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(trace) == 0 {
+		fmt.Fprintln(os.Stderr, "no accesses")
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "capacity\t")
+	for _, p := range policies {
+		fmt.Fprintf(w, "%s\t", p.name)
+	}
+	fmt.Fprintln(w)
+	for _, size := range sizes {
+		fmt.Fprintf(w, "%d\t", size)
+		for _, p := range policies {
+			stats := cache.Replay(p.new(size), trace)
+			fmt.Fprintf(w, "%.2f%%\t", 100*stats.HitRatio())
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Printf("%d accesses\n", len(trace))
+}
+
+// parseCapacities 解析逗号分隔的容量列表
+func parseCapacities(s string) ([]int, error) {
+	var sizes []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid capacity %q", field)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// readTraces 依次读取访问记录文件，没有文件时从标准输入读取
+func readTraces(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return cache.ReadTrace(os.Stdin)
+	}
+	var trace []string
+	for _, path := range paths {
+		keys, err := readTrace(path)
+		if err != nil {
+			return nil, err
+		}
+		trace = append(trace, keys...)
+	}
+	return trace, nil
+}
+
+// readTrace 读取一个访问记录文件
+func readTrace(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return cache.ReadTrace(f)
+}
+
+// zipfTrace 生成 n 次服从 Zipf 分布的访问
+func zipfTrace(n int, keys uint64, s float64, seed int64) []string {
+	z := rand.NewZipf(rand.New(rand.NewSource(seed)), s, 1, keys-1)
+	trace := make([]string, n)
+	for i := range trace {
+		trace[i] = strconv.FormatUint(z.Uint64(), 10)
+	}
+	return trace
+}
@@ -0,0 +1,241 @@
+package cache_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/cache"
+)
+
+func ExampleLRUCache() {
+	c := cache.NewLRUCacheWithOptions(cache.LRUOptions[string, int]{
+		MaxEntries: 3,
+		OnEvict: func(key string, value int, reason cache.EvictReason) {
+			fmt.Println("evict", key, value, reason)
+		},
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	// 访问 a 后，最久没有访问的是 b
+	c.Get("a")
+	c.Put("d", 4)
+	// Peek 不改变访问顺序
+	v, ok := c.Peek("c")
+	fmt.Println(v, ok)
+	fmt.Println(c.Keys())
+	c.Remove("a")
+	fmt.Println(c.Keys(), c.Len())
+	// Output:
+	// evict b 2 capacity
+	// 3 true
+	// [d a c]
+	// evict a 1 removed
+	// [d c] 2
+}
+
+func ExampleLRUOptions_cost() {
+	// 按字节数限制容量
+	c := cache.NewLRUCacheWithOptions(cache.LRUOptions[string, []byte]{
+		MaxCost: 10,
+		Cost: func(key string, value []byte) int64 {
+			return int64(len(key) + len(value))
+		},
+	})
+	c.Put("a", []byte("1234"))
+	c.Put("b", []byte("5678"))
+	fmt.Println(c.Keys(), c.Cost())
+	c.Put("c", []byte("90"))
+	fmt.Println(c.Keys(), c.Cost())
+	// 成本超过上限的键值对不会被保存
+	c.Put("big", make([]byte, 100))
+	fmt.Println(c.Keys(), c.Cost())
+	// Output:
+	// [b a] 10
+	// [c b] 8
+	// [c b] 8
+}
+
+func ExampleLRUCache_PutWithTTL() {
+	// 使用假的时钟
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := cache.NewLRUCacheWithOptions(cache.LRUOptions[string, string]{
+		MaxEntries: 10,
+		TTL:        time.Minute,
+		Now:        func() time.Time { return now },
+	})
+	c.Put("session", "alice")
+	c.PutWithTTL("token", "xyz", 10*time.Second)
+	c.PutWithTTL("config", "v1", 0)
+
+	now = now.Add(30 * time.Second)
+	_, ok := c.Get("token")
+	fmt.Println(ok, c.Len())
+
+	now = now.Add(time.Minute)
+	fmt.Println(c.RemoveExpired(), c.Keys())
+	// Output:
+	// false 2
+	// 1 [config]
+}
+
+func ExampleSyncLRUCache() {
+	c := cache.NewSyncLRUCache[int, int](100)
+	done := make(chan bool)
+	for g := 0; g < 4; g++ {
+		go func(g int) {
+			for i := 0; i < 100; i++ {
+				c.Put(g*100+i, i)
+				c.Get(g*100 + i/2)
+			}
+			done <- true
+		}(g)
+	}
+	for g := 0; g < 4; g++ {
+		<-done
+	}
+	fmt.Println(c.Len())
+	// Output:
+	// 100
+}
+
+func ExampleLFUCache() {
+	c := cache.NewLFUCache[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	// 淘汰访问次数最少的 b
+	c.Put("c", 3)
+	_, ok := c.Peek("b")
+	fmt.Println(ok, c.Frequency("a"), c.Frequency("c"))
+	// 次数相同时淘汰最久没有访问的：c 和 d 都只有 1 次，c 更早
+	c.Put("d", 4)
+	_, ok = c.Peek("c")
+	fmt.Println(ok, c.Len())
+	// Output:
+	// false 3 1
+	// false 2
+}
+
+func ExampleTwoQueueCache() {
+	c := cache.NewTwoQueueCache[int, int](4)
+	// 1、2 从 A1in 淘汰后不久又被访问，进入 Am
+	for _, key := range []int{1, 2, 3, 4, 5, 6, 1, 2} {
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, key)
+		}
+	}
+	// 一次扫描只会经过 A1in，不会挤掉 Am 中的 1、2
+	for key := 100; key < 120; key++ {
+		c.Put(key, key)
+	}
+	_, ok1 := c.Get(1)
+	_, ok2 := c.Get(2)
+	_, ok5 := c.Get(5)
+	fmt.Println(ok1, ok2, ok5, c.Len())
+	// Output:
+	// true true false 4
+}
+
+func ExampleARCCache() {
+	c := cache.NewARCCache[int, int](4)
+	for _, key := range []int{1, 2, 3, 4} {
+		c.Put(key, key)
+	}
+	// 1、2 被访问两次，进入 T2
+	c.Get(1)
+	c.Get(2)
+	c.Put(5, 5)
+	c.Put(6, 6)
+	// 刚被淘汰的 3 又被访问，命中 B1，T1 的目标大小变大
+	fmt.Println(c.Target())
+	c.Put(3, 3)
+	fmt.Println(c.Target(), c.Len())
+	_, ok := c.Peek(1)
+	fmt.Println(ok)
+	// Output:
+	// 0
+	// 1 4
+	// true
+}
+
+func ExampleTinyLFUCache() {
+	c := cache.NewTinyLFUCacheWithHasher[string, int](100, hashmap.SeededHasher[string](0))
+	// 热点键被访问多次
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 99; j++ {
+			key := "hot" + strconv.Itoa(j)
+			if _, ok := c.Get(key); !ok {
+				c.Put(key, j)
+			}
+		}
+	}
+	// 扫描的键频率太低，准入时比不过热点键，只会经过窗口
+	for j := 0; j < 1000; j++ {
+		c.Put("scan"+strconv.Itoa(j), j)
+	}
+	hits := 0
+	for j := 0; j < 99; j++ {
+		if _, ok := c.Peek("hot" + strconv.Itoa(j)); ok {
+			hits++
+		}
+	}
+	fmt.Println(hits, c.Len())
+	// Output:
+	// 99 100
+}
+
+func ExampleReplay() {
+	// 热点集合中穿插着一次大扫描
+	var trace []string
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 50; i++ {
+			trace = append(trace, "hot"+strconv.Itoa(i))
+		}
+		if round == 10 {
+			for i := 0; i < 500; i++ {
+				trace = append(trace, "scan"+strconv.Itoa(i))
+			}
+		}
+	}
+	caches := []struct {
+		name  string
+		cache cache.Cache[string, struct{}]
+	}{
+		{"LRU", cache.NewLRUCache[string, struct{}](100)},
+		{"LFU", cache.NewLFUCache[string, struct{}](100)},
+		{"2Q", cache.NewTwoQueueCache[string, struct{}](100)},
+		{"ARC", cache.NewARCCache[string, struct{}](100)},
+		{"W-TinyLFU", cache.NewTinyLFUCacheWithHasher[string, struct{}](100, hashmap.SeededHasher[string](0))},
+	}
+	for _, c := range caches {
+		stats := cache.Replay(c.cache, trace)
+		fmt.Printf("%s %d/%d %.3f\n", c.name, stats.Hits, stats.Requests, stats.HitRatio())
+	}
+	// Output:
+	// LRU 900/1500 0.600
+	// LFU 950/1500 0.633
+	// 2Q 900/1500 0.600
+	// ARC 950/1500 0.633
+	// W-TinyLFU 950/1500 0.633
+}
+
+func ExampleReplayTrace() {
+	trace := `# key timestamp
+a 1
+b 2
+
+a 3
+c 4
+a 5
+`
+	stats, err := cache.ReplayTrace(cache.NewLRUCache[string, struct{}](2), strings.NewReader(trace))
+	fmt.Println(stats.Hits, stats.Requests, err)
+	// Output:
+	// 2 5 <nil>
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+// LFU 缓存
+/*
+LFU（Least Frequently Used，最不经常使用）淘汰访问次数最少的键值对，访问次数相同时淘汰最久没有访问的。
+
+用堆按访问次数排序，每次访问都是 O(log n)。O(1) 的实现（Shah, Mitra, Matani 2010）：
+	按访问次数从小到大排列一个频率链表，每个频率节点下挂一个链表，保存访问次数正好是该频率的键值对
+	访问键值对时，把它从频率为 f 的节点移到频率为 f+1 的节点（不存在时在后面新建），f 节点空了就删除
+	淘汰时取第一个频率节点（次数最少）的链表尾部（最久没有访问）
+所有操作只涉及相邻的节点，都是 O(1)。两层链表都使用 deque.DoubleList，键值对记录自己的节点句柄。
+
+LFU 对扫描的抵抗力很强，但曾经很热的数据访问次数很高，冷下来以后也很难被淘汰。
+*/
+
+// freqNode 频率节点，items 中的键值对访问次数都是 freq
+type freqNode[K comparable, V any] struct {
+	freq  int
+	items deque.DoubleList[*lfuEntry[K, V]]
+}
+
+// lfuEntry 键值对
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  *deque.ListNode[*freqNode[K, V]] // 所在的频率节点
+	node  *deque.ListNode[*lfuEntry[K, V]] // 在频率节点链表中的节点
+}
+
+// LFUCache LFU 缓存，不是并发安全的
+type LFUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*lfuEntry[K, V]
+	freqs    deque.DoubleList[*freqNode[K, V]] // 频率链表，头部访问次数最少
+}
+
+// NewLFUCache 创建最多保存 capacity 个键值对的 LFU 缓存
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+	}
+}
+
+// Len 返回键值对数量
+func (c *LFUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Get 获取键值对，访问次数加 1
+func (c *LFUCache[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	c.increment(e)
+	return e.value, true
+}
+
+// Peek 获取键值对，不改变访问次数
+func (c *LFUCache[K, V]) Peek(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	return e.value, true
+}
+
+// Frequency 返回键的访问次数，不存在返回 0
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	e, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return e.freq.GetValue().freq
+}
+
+// Put 添加键值对，已存在时替换值并且访问次数加 1，新键值对的访问次数为 1
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.increment(e)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+	e := &lfuEntry[K, V]{key: key, value: value}
+	// 访问次数为 1 的频率节点只可能是第一个
+	first := c.freqs.First()
+	if first.IsNil() || first.GetValue().freq != 1 {
+		first = c.freqs.PushFront(&freqNode[K, V]{freq: 1})
+	}
+	e.freq = first
+	e.node = first.GetValue().items.PushFront(e)
+	c.items[key] = e
+}
+
+// increment 访问次数加 1，移到下一个频率节点
+func (c *LFUCache[K, V]) increment(e *lfuEntry[K, V]) {
+	cur := e.freq
+	next := cur.GetNext()
+	if next.IsNil() || next.GetValue().freq != cur.GetValue().freq+1 {
+		// 在当前频率节点之后插入新的频率节点
+		next = c.freqs.InsertAfter(&freqNode[K, V]{freq: cur.GetValue().freq + 1}, cur)
+	}
+	cur.GetValue().items.Remove(e.node)
+	e.freq = next
+	e.node = next.GetValue().items.PushFront(e)
+	c.removeIfEmpty(cur)
+}
+
+// removeIfEmpty 频率节点没有键值对时删除
+func (c *LFUCache[K, V]) removeIfEmpty(f *deque.ListNode[*freqNode[K, V]]) {
+	if f.GetValue().items.Len() == 0 {
+		c.freqs.Remove(f)
+	}
+}
+
+// evict 淘汰访问次数最少的键值对中最久没有访问的
+func (c *LFUCache[K, V]) evict() {
+	first := c.freqs.First()
+	if first.IsNil() {
+		return
+	}
+	c.delete(first.GetValue().items.Last().GetValue())
+}
+
+// delete 删除键值对
+func (c *LFUCache[K, V]) delete(e *lfuEntry[K, V]) {
+	e.freq.GetValue().items.Remove(e.node)
+	c.removeIfEmpty(e.freq)
+	delete(c.items, e.key)
+}
+
+// Remove 删除键值对，返回键是否存在
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.delete(e)
+	return true
+}
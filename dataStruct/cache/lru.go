@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+// LRU 缓存
+/*
+LRU（Least Recently Used，最近最少使用）缓存满了以后淘汰最久没有被访问的键值对。
+用哈希表加双向链表实现，所有操作都是 O(1)：
+	哈希表保存 键 -> 键值对，键值对记录自己的链表节点（节点句柄），O(1) 找到键值对和节点
+	双向链表按访问时间排序，头部是最近访问的，尾部是最久没有访问的
+	访问键值对时把节点移到链表头部，淘汰时删除链表尾部的节点
+链表直接使用 deque.DoubleList，按节点句柄删除和移动节点都是 O(1)。
+
+容量可以按数量限制（MaxEntries），也可以按成本限制（MaxCost，比如键值对占用的字节数），两者可以同时使用。
+每个键值对可以设置过期时间（TTL），过期的键值对在访问时惰性删除，也可以调用 RemoveExpired 主动清理。
+*/
+
+// EvictReason 键值对被移出缓存的原因
+type EvictReason int
+
+const (
+	EvictCapacity EvictReason = iota // 超出容量被淘汰
+	EvictExpired                     // 过期
+	EvictRemoved                     // 调用 Remove 删除
+)
+
+// String 返回原因的名称
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// LRUOptions LRU 缓存的配置，零值字段表示不限制或使用默认值
+type LRUOptions[K comparable, V any] struct {
+	MaxEntries int                                      // 最多的键值对数量，0 表示不限制
+	MaxCost    int64                                    // 最大成本之和，0 表示不限制
+	Cost       func(key K, value V) int64               // 计算键值对的成本，为 nil 时每个键值对成本为 1
+	TTL        time.Duration                            // 默认的过期时间，0 表示不过期
+	OnEvict    func(key K, value V, reason EvictReason) // 键值对被移出缓存时回调，替换值时不回调
+	Now        func() time.Time                         // 当前时间，为 nil 时使用 time.Now，测试时可以替换
+}
+
+// lruEntry 缓存中的键值对
+type lruEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	cost   int64
+	expire time.Time                        // 过期时间，零值表示不过期
+	node   *deque.ListNode[*lruEntry[K, V]] // 在链表中的节点
+}
+
+// eviction 等待回调的移出记录
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// LRUCache LRU 缓存，不是并发安全的，并发使用 SyncLRUCache
+type LRUCache[K comparable, V any] struct {
+	items   *hashmap.HashMap[K, *lruEntry[K, V]] // 键 -> 键值对
+	order   deque.DoubleList[*lruEntry[K, V]]    // 按访问时间排序，头部是最近访问的，尾部是最久没有访问的
+	cost    int64                                // 成本之和
+	opts    LRUOptions[K, V]                     // 配置
+	pending []eviction[K, V]                     // 等待回调的移出记录
+	hold    bool                                 // 为 true 时回调由 SyncLRUCache 在释放锁之后执行
+}
+
+// NewLRUCache 创建最多保存 capacity 个键值对的 LRU 缓存
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return NewLRUCacheWithOptions(LRUOptions[K, V]{MaxEntries: capacity})
+}
+
+// NewLRUCacheWithOptions 使用指定的配置创建 LRU 缓存
+func NewLRUCacheWithOptions[K comparable, V any](opts LRUOptions[K, V]) *LRUCache[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &LRUCache[K, V]{
+		items: hashmap.NewHashMap[K, *lruEntry[K, V]](opts.MaxEntries),
+		opts:  opts,
+	}
+}
+
+// Len 返回键值对数量，包括已经过期但还没有删除的
+func (c *LRUCache[K, V]) Len() int {
+	return c.items.Len()
+}
+
+// Cost 返回成本之和
+func (c *LRUCache[K, V]) Cost() int64 {
+	return c.cost
+}
+
+// Get 获取键值对，并标记为最近访问
+func (c *LRUCache[K, V]) Get(key K) (value V, ok bool) {
+	defer c.flush()
+	e := c.lookup(key)
+	if e == nil {
+		return
+	}
+	c.order.MoveToFront(e.node)
+	return e.value, true
+}
+
+// Peek 获取键值对，不改变访问顺序
+func (c *LRUCache[K, V]) Peek(key K) (value V, ok bool) {
+	defer c.flush()
+	e := c.lookup(key)
+	if e == nil {
+		return
+	}
+	return e.value, true
+}
+
+// Contains 判断键是否存在且没有过期，不改变访问顺序
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// lookup 查找没有过期的键值对，过期的直接删除
+func (c *LRUCache[K, V]) lookup(key K) *lruEntry[K, V] {
+	e, ok := c.items.Get(key)
+	if !ok {
+		return nil
+	}
+	if c.expired(e) {
+		c.remove(e, EvictExpired)
+		return nil
+	}
+	return e
+}
+
+// expired 判断键值对是否过期
+func (c *LRUCache[K, V]) expired(e *lruEntry[K, V]) bool {
+	return !e.expire.IsZero() && !c.opts.Now().Before(e.expire)
+}
+
+// Put 添加键值对，使用默认的过期时间
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.opts.TTL)
+}
+
+// PutWithTTL 添加键值对，ttl 后过期，ttl 为 0 表示不过期
+// 超出容量时从最久没有访问的键值对开始淘汰，成本超过 MaxCost 的键值对不会被保存，直接回调淘汰
+func (c *LRUCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	defer c.flush()
+	var expire time.Time
+	if ttl > 0 {
+		expire = c.opts.Now().Add(ttl)
+	}
+	cost := int64(1)
+	if c.opts.Cost != nil {
+		cost = c.opts.Cost(key, value)
+	}
+	// 成本超过上限，放入后也会马上被淘汰，不用淘汰其他键值对，键原来的值也一并删除
+	if c.opts.MaxCost > 0 && cost > c.opts.MaxCost {
+		if e, ok := c.items.Get(key); ok {
+			c.remove(e, EvictRemoved)
+		}
+		if c.opts.OnEvict != nil {
+			c.pending = append(c.pending, eviction[K, V]{key, value, EvictCapacity})
+		}
+		return
+	}
+	if e, ok := c.items.Get(key); ok {
+		// 键已经存在，替换值并标记为最近访问
+		c.cost += cost - e.cost
+		e.value, e.cost, e.expire = value, cost, expire
+		c.order.MoveToFront(e.node)
+	} else {
+		e = &lruEntry[K, V]{key: key, value: value, cost: cost, expire: expire}
+		c.items.Put(key, e)
+		e.node = c.order.PushFront(e)
+		c.cost += cost
+	}
+	// 超出容量，淘汰最久没有访问的键值对
+	for c.order.Len() > 0 && c.overflow() {
+		c.remove(c.order.Last().GetValue(), EvictCapacity)
+	}
+}
+
+// overflow 是否超出容量
+func (c *LRUCache[K, V]) overflow() bool {
+	return (c.opts.MaxEntries > 0 && c.items.Len() > c.opts.MaxEntries) ||
+		(c.opts.MaxCost > 0 && c.cost > c.opts.MaxCost)
+}
+
+// Remove 删除键值对，返回键是否存在
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	defer c.flush()
+	e, ok := c.items.Get(key)
+	if !ok {
+		return false
+	}
+	c.remove(e, EvictRemoved)
+	return true
+}
+
+// RemoveOldest 淘汰最久没有访问的键值对
+func (c *LRUCache[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	defer c.flush()
+	if c.order.Len() == 0 {
+		return
+	}
+	e := c.order.Last().GetValue()
+	c.remove(e, EvictCapacity)
+	return e.key, e.value, true
+}
+
+// RemoveExpired 删除所有过期的键值对，返回删除的数量，时间复杂度 O(n)
+func (c *LRUCache[K, V]) RemoveExpired() int {
+	defer c.flush()
+	n := 0
+	for node := c.order.Last(); !node.IsNil(); {
+		pre := node.GetPre()
+		if e := node.GetValue(); c.expired(e) {
+			c.remove(e, EvictExpired)
+			n++
+		}
+		node = pre
+	}
+	return n
+}
+
+// Keys 返回所有键，从最近访问到最久没有访问
+func (c *LRUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, c.items.Len())
+	for node := c.order.First(); !node.IsNil(); node = node.GetNext() {
+		keys = append(keys, node.GetValue().key)
+	}
+	return keys
+}
+
+// Purge 清空缓存，不回调
+func (c *LRUCache[K, V]) Purge() {
+	c.items = hashmap.NewHashMap[K, *lruEntry[K, V]](c.opts.MaxEntries)
+	c.order = deque.DoubleList[*lruEntry[K, V]]{}
+	c.cost = 0
+}
+
+// remove 从哈希表和链表中删除键值对，记录回调
+func (c *LRUCache[K, V]) remove(e *lruEntry[K, V], reason EvictReason) {
+	c.items.Delete(e.key)
+	c.order.Remove(e.node)
+	e.node = nil
+	c.cost -= e.cost
+	if c.opts.OnEvict != nil {
+		c.pending = append(c.pending, eviction[K, V]{e.key, e.value, reason})
+	}
+}
+
+// flush 执行等待中的回调，回调中可以再次访问缓存
+func (c *LRUCache[K, V]) flush() {
+	if c.hold {
+		return
+	}
+	for len(c.pending) > 0 {
+		pending := c.pending
+		c.pending = nil
+		for _, ev := range pending {
+			c.opts.OnEvict(ev.key, ev.value, ev.reason)
+		}
+	}
+}
+
+// takePending 取出等待中的回调，SyncLRUCache 在释放锁之后执行
+func (c *LRUCache[K, V]) takePending() []eviction[K, V] {
+	pending := c.pending
+	c.pending = nil
+	return pending
+}
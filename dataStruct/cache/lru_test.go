@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// 与简单的参考实现对比随机操作的结果
+func TestLRUCacheRandomOps(t *testing.T) {
+	const capacity = 50
+	c := NewLRUCache[int, int](capacity)
+	var order []int // 参考实现，下标 0 为最近访问的键
+	values := make(map[int]int)
+	touch := func(key int) {
+		for i, k := range order {
+			if k == key {
+				order = append(order[:i], order[i+1:]...)
+				break
+			}
+		}
+		order = append([]int{key}, order...)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(100)
+		switch r.Intn(3) {
+		case 0:
+			c.Put(key, i)
+			values[key] = i
+			touch(key)
+			if len(order) > capacity {
+				delete(values, order[capacity])
+				order = order[:capacity]
+			}
+		case 1:
+			v, ok := c.Get(key)
+			want, wantOk := values[key]
+			if ok != wantOk || v != want {
+				t.Fatalf("Get(%d) = %d, %v, want %d, %v", key, v, ok, want, wantOk)
+			}
+			if ok {
+				touch(key)
+			}
+		case 2:
+			c.Remove(key)
+			if _, ok := values[key]; ok {
+				delete(values, key)
+				for i, k := range order {
+					if k == key {
+						order = append(order[:i], order[i+1:]...)
+						break
+					}
+				}
+			}
+		}
+	}
+	keys := c.Keys()
+	if len(keys) != len(order) {
+		t.Fatalf("Keys() has %d keys, want %d", len(keys), len(order))
+	}
+	for i := range keys {
+		if keys[i] != order[i] {
+			t.Fatalf("Keys()[%d] = %d, want %d", i, keys[i], order[i])
+		}
+	}
+}
+
+// 并发访问，配合 -race 运行；回调中再次访问缓存不会死锁
+func TestSyncLRUCacheParallel(t *testing.T) {
+	var c *SyncLRUCache[int, int]
+	var evicted sync.Map
+	c = NewSyncLRUCacheWithOptions(LRUOptions[int, int]{
+		MaxEntries: 100,
+		OnEvict: func(key, value int, reason EvictReason) {
+			evicted.Store(key, c.Contains(key))
+		},
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Put(g*1000+i, i)
+				c.Get(g*1000 + i/2)
+				if i%10 == 0 {
+					c.Remove(g*1000 + i/3)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c.Len() != 100 {
+		t.Fatalf("Len() = %d, want 100", c.Len())
+	}
+}
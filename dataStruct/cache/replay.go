@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// 访问记录回放
+/*
+比较淘汰策略最直接的办法是在真实的访问记录上回放，统计命中率。
+回放时每个键先 Get，未命中再 Put，和一个普通的读穿透缓存一样。
+
+访问记录文件每行一次访问，取每行第一个空白分隔的字段作为键，
+空行以及 # 开头的行会被忽略，所以可以直接使用 "键 时间戳 ..." 格式的日志。
+*/
+
+// HitStats 回放的统计结果
+type HitStats struct {
+	Requests int // 访问次数
+	Hits     int // 命中次数
+}
+
+// HitRatio 返回命中率
+func (s HitStats) HitRatio() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Requests)
+}
+
+// access 回放一次访问
+func (s *HitStats) access(c Cache[string, struct{}], key string) {
+	s.Requests++
+	if _, ok := c.Get(key); ok {
+		s.Hits++
+		return
+	}
+	c.Put(key, struct{}{})
+}
+
+// Replay 按顺序回放访问记录
+func Replay(c Cache[string, struct{}], keys []string) HitStats {
+	var stats HitStats
+	for _, key := range keys {
+		stats.access(c, key)
+	}
+	return stats
+}
+
+// ReadTrace 读取访问记录文件，返回每次访问的键
+func ReadTrace(r io.Reader) ([]string, error) {
+	var keys []string
+	err := scanTrace(r, func(key string) {
+		keys = append(keys, key)
+	})
+	return keys, err
+}
+
+// ReplayTrace 边读取边回放访问记录文件，不需要把整个文件读入内存
+func ReplayTrace(c Cache[string, struct{}], r io.Reader) (HitStats, error) {
+	var stats HitStats
+	err := scanTrace(r, func(key string) {
+		stats.access(c, key)
+	})
+	return stats, err
+}
+
+// scanTrace 逐行读取访问记录文件，对每次访问的键调用 fn
+func scanTrace(r io.Reader, fn func(key string)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fn(strings.Fields(line)[0])
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncLRUCache 并发安全的 LRU 缓存
+// Get 也会修改链表顺序，所以读写都加互斥锁；淘汰回调在释放锁之后执行，回调中可以再次访问缓存
+type SyncLRUCache[K comparable, V any] struct {
+	lru  *LRUCache[K, V]
+	lock sync.Mutex
+}
+
+// NewSyncLRUCache 创建最多保存 capacity 个键值对的并发安全 LRU 缓存
+func NewSyncLRUCache[K comparable, V any](capacity int) *SyncLRUCache[K, V] {
+	return NewSyncLRUCacheWithOptions(LRUOptions[K, V]{MaxEntries: capacity})
+}
+
+// NewSyncLRUCacheWithOptions 使用指定的配置创建并发安全 LRU 缓存
+func NewSyncLRUCacheWithOptions[K comparable, V any](opts LRUOptions[K, V]) *SyncLRUCache[K, V] {
+	lru := NewLRUCacheWithOptions(opts)
+	lru.hold = true
+	return &SyncLRUCache[K, V]{lru: lru}
+}
+
+// unlock 释放锁，然后执行等待中的回调
+func (c *SyncLRUCache[K, V]) unlock() {
+	pending := c.lru.takePending()
+	c.lock.Unlock()
+	for _, ev := range pending {
+		c.lru.opts.OnEvict(ev.key, ev.value, ev.reason)
+	}
+}
+
+// Len 返回键值对数量
+func (c *SyncLRUCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// Cost 返回成本之和
+func (c *SyncLRUCache[K, V]) Cost() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Cost()
+}
+
+// Get 获取键值对，并标记为最近访问
+func (c *SyncLRUCache[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.Get(key)
+}
+
+// Peek 获取键值对，不改变访问顺序
+func (c *SyncLRUCache[K, V]) Peek(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.Peek(key)
+}
+
+// Contains 判断键是否存在且没有过期
+func (c *SyncLRUCache[K, V]) Contains(key K) bool {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.Contains(key)
+}
+
+// Put 添加键值对，使用默认的过期时间
+func (c *SyncLRUCache[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.unlock()
+	c.lru.Put(key, value)
+}
+
+// PutWithTTL 添加键值对，ttl 后过期
+func (c *SyncLRUCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.unlock()
+	c.lru.PutWithTTL(key, value, ttl)
+}
+
+// Remove 删除键值对，返回键是否存在
+func (c *SyncLRUCache[K, V]) Remove(key K) bool {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.Remove(key)
+}
+
+// RemoveOldest 淘汰最久没有访问的键值对
+func (c *SyncLRUCache[K, V]) RemoveOldest() (K, V, bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.RemoveOldest()
+}
+
+// RemoveExpired 删除所有过期的键值对，返回删除的数量
+func (c *SyncLRUCache[K, V]) RemoveExpired() int {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.lru.RemoveExpired()
+}
+
+// Keys 返回所有键，从最近访问到最久没有访问
+func (c *SyncLRUCache[K, V]) Keys() []K {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Keys()
+}
+
+// Purge 清空缓存，不回调
+func (c *SyncLRUCache[K, V]) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru.Purge()
+}
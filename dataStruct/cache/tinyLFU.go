@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/sketch"
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+// W-TinyLFU 缓存（Einziger, Friedman, Manes 2017，Caffeine 使用的策略）
+/*
+TinyLFU 是一种准入策略：缓存满了以后，新数据要与将被淘汰的数据比较访问频率，频率更高才能进入缓存。
+访问频率用 Count-Min Sketch 估计，不需要为不在缓存中的键保存任何东西；
+每记录 10 倍容量次访问后所有计数器减半（老化），让很久以前的热点逐渐冷下来。
+
+只有准入策略时，突发的新热点频率还不够高，进不了缓存，所以前面加一个很小的窗口 LRU（W，占容量的 1%）：
+	新数据先进入窗口，被挤出窗口时成为候选者
+	主缓存使用分段 LRU（SLRU）：试用段（probation，20%）和保护段（protected，80%）
+	主缓存满了时，候选者与试用段尾部的淘汰者比较频率，频率高的留在试用段，另一个被淘汰
+	试用段中的数据再次被访问时升级到保护段，保护段满了时尾部降级回试用段
+这样既能抵抗扫描（扫描的数据频率低，进不了主缓存），又能接纳新的热点。
+*/
+
+// W-TinyLFU 各部分的比例
+const (
+	tinyLFUWindowRatio    = 0.01 // 窗口占容量的比例
+	tinyLFUProtectedRatio = 0.8  // 保护段占主缓存的比例
+	tinyLFUSampleFactor   = 10   // 记录 容量*10 次访问后老化
+	tinyLFUWidthFactor    = 8    // Count-Min Sketch 每行计数器数量为容量的 8 倍，太小时扫描的键会把计数器抬高
+)
+
+// W-TinyLFU 中的三个队列
+const (
+	tinyWindow = iota
+	tinyProbation
+	tinyProtected
+)
+
+// tinyLFUEntry 键值对
+type tinyLFUEntry[K comparable, V any] struct {
+	key   K
+	value V
+	where int                                  // 所在的队列
+	node  *deque.ListNode[*tinyLFUEntry[K, V]] // 在所在队列中的节点
+}
+
+// TinyLFUCache W-TinyLFU 缓存，不是并发安全的
+type TinyLFUCache[K comparable, V any] struct {
+	windowSize    int                                      // 窗口大小
+	mainSize      int                                      // 主缓存大小
+	protectedSize int                                      // 保护段大小
+	items         map[K]*tinyLFUEntry[K, V]                // 所有键值对
+	lists         [3]deque.DoubleList[*tinyLFUEntry[K, V]] // 窗口、试用段、保护段
+	sketch        *sketch.CountMinSketch                   // 估计访问频率
+	hasher        hashmap.Hasher[K]                        // 计算键的哈希值
+	samples       int                                      // 上次老化后记录的访问次数
+	sampleSize    int                                      // 记录多少次访问后老化
+}
+
+// NewTinyLFUCache 创建最多保存 capacity 个键值对的 W-TinyLFU 缓存，键的哈希值使用随机种子
+func NewTinyLFUCache[K comparable, V any](capacity int) *TinyLFUCache[K, V] {
+	return NewTinyLFUCacheWithHasher[K, V](capacity, hashmap.DefaultHasher[K]())
+}
+
+// NewTinyLFUCacheWithHasher 创建使用指定哈希函数估计访问频率的 W-TinyLFU 缓存
+// 使用固定种子的哈希函数时，同样的访问序列得到同样的结果，便于回放比较
+func NewTinyLFUCacheWithHasher[K comparable, V any](capacity int, hasher hashmap.Hasher[K]) *TinyLFUCache[K, V] {
+	capacity = max(capacity, 1)
+	window := max(int(float64(capacity)*tinyLFUWindowRatio), 1)
+	main := capacity - window
+	return &TinyLFUCache[K, V]{
+		windowSize:    window,
+		mainSize:      main,
+		protectedSize: int(float64(main) * tinyLFUProtectedRatio),
+		items:         make(map[K]*tinyLFUEntry[K, V], capacity),
+		sketch:        sketch.NewCountMinSketch(uint64(max(tinyLFUWidthFactor*capacity, 16)), 4),
+		hasher:        hasher,
+		sampleSize:    capacity * tinyLFUSampleFactor,
+	}
+}
+
+// Len 返回键值对数量
+func (c *TinyLFUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// record 记录一次访问，达到采样次数后老化
+func (c *TinyLFUCache[K, V]) record(key K) {
+	c.sketch.AddHash(c.hasher.Hash(key), 1)
+	c.samples++
+	if c.samples >= c.sampleSize {
+		c.sketch.Halve()
+		c.samples /= 2
+	}
+}
+
+// frequency 估计键的访问频率
+func (c *TinyLFUCache[K, V]) frequency(key K) uint64 {
+	return c.sketch.EstimateHash(c.hasher.Hash(key))
+}
+
+// move 将节点移到指定队列的头部
+func (c *TinyLFUCache[K, V]) move(e *tinyLFUEntry[K, V], where int) {
+	c.lists[e.where].Remove(e.node)
+	e.node = c.lists[where].PushFront(e)
+	e.where = where
+}
+
+// back 返回队列尾部的键值对，队列为空时返回 nil
+func (c *TinyLFUCache[K, V]) back(where int) *tinyLFUEntry[K, V] {
+	if node := c.lists[where].Last(); !node.IsNil() {
+		return node.GetValue()
+	}
+	return nil
+}
+
+// Get 获取键值对，记录一次访问
+func (c *TinyLFUCache[K, V]) Get(key K) (value V, ok bool) {
+	c.record(key)
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+// touch 命中后调整位置：窗口和保护段中移到头部，试用段中升级到保护段
+func (c *TinyLFUCache[K, V]) touch(e *tinyLFUEntry[K, V]) {
+	switch e.where {
+	case tinyWindow, tinyProtected:
+		c.lists[e.where].MoveToFront(e.node)
+	case tinyProbation:
+		c.move(e, tinyProtected)
+		// 保护段满了，尾部降级回试用段
+		if c.lists[tinyProtected].Len() > c.protectedSize {
+			c.move(c.back(tinyProtected), tinyProbation)
+		}
+	}
+}
+
+// Peek 获取键值对，不记录访问
+func (c *TinyLFUCache[K, V]) Peek(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	return e.value, true
+}
+
+// Put 添加键值对，记录一次访问
+func (c *TinyLFUCache[K, V]) Put(key K, value V) {
+	c.record(key)
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		return
+	}
+	entry := &tinyLFUEntry[K, V]{key: key, value: value, where: tinyWindow}
+	entry.node = c.lists[tinyWindow].PushFront(entry)
+	c.items[key] = entry
+	if c.lists[tinyWindow].Len() <= c.windowSize {
+		return
+	}
+	// 窗口满了，尾部成为候选者
+	candidate := c.back(tinyWindow)
+	if c.lists[tinyProbation].Len()+c.lists[tinyProtected].Len() < c.mainSize {
+		// 主缓存还有空位，直接进入试用段
+		c.move(candidate, tinyProbation)
+		return
+	}
+	// 主缓存满了，候选者与淘汰者比较频率
+	victim := c.back(tinyProbation)
+	if victim == nil {
+		victim = c.back(tinyProtected)
+	}
+	if victim != nil && c.frequency(candidate.key) > c.frequency(victim.key) {
+		c.drop(victim)
+		c.move(candidate, tinyProbation)
+		return
+	}
+	c.drop(candidate)
+}
+
+// drop 删除键值对
+func (c *TinyLFUCache[K, V]) drop(e *tinyLFUEntry[K, V]) {
+	c.lists[e.where].Remove(e.node)
+	delete(c.items, e.key)
+}
+
+// Remove 删除键值对，返回键是否存在
+func (c *TinyLFUCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.drop(e)
+	return true
+}
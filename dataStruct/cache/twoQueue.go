@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+// 2Q 缓存（Johnson & Shasha 1994）
+/*
+LRU 中只被访问一次的数据（比如一次扫描）也会被放到头部，把真正的热点数据挤出去。
+2Q 把缓存分成三个队列：
+	A1in   先进先出队列，新数据先放在这里，默认占容量的 25%
+	A1out  幽灵队列，只保存从 A1in 淘汰的键，不保存值，默认保存容量 50% 个键
+	Am     LRU 队列，保存被证明是热点的数据
+访问 A1in 中的数据不改变顺序（短时间内的重复访问不算热点）；
+添加的键在 A1out 中时，说明它被淘汰后不久又被访问了，直接放入 Am；
+只被访问一次的数据从 A1in 淘汰后只在 A1out 留下键，不会进入 Am，所以扫描不会污染 Am。
+*/
+
+// 2Q 默认的队列比例
+const (
+	twoQueueInRatio  = 0.25 // A1in 占容量的比例
+	twoQueueOutRatio = 0.5  // A1out 保存的键数量占容量的比例
+)
+
+// twoQueueEntry 键值对
+type twoQueueEntry[K comparable, V any] struct {
+	key   K
+	value V
+	node  *deque.ListNode[*twoQueueEntry[K, V]] // 在 A1in 或 Am 中的节点
+}
+
+// TwoQueueCache 2Q 缓存，不是并发安全的
+type TwoQueueCache[K comparable, V any] struct {
+	capacity int
+	inSize   int                                    // A1in 的大小
+	outSize  int                                    // A1out 的大小
+	items    map[K]*twoQueueEntry[K, V]             // A1in 和 Am 中的键值对
+	ghosts   map[K]*deque.ListNode[K]               // A1out 中的键
+	in       deque.DoubleList[*twoQueueEntry[K, V]] // A1in
+	out      deque.DoubleList[K]                    // A1out
+	main     deque.DoubleList[*twoQueueEntry[K, V]] // Am
+}
+
+// NewTwoQueueCache 创建最多保存 capacity 个键值对的 2Q 缓存
+func NewTwoQueueCache[K comparable, V any](capacity int) *TwoQueueCache[K, V] {
+	capacity = max(capacity, 1)
+	return &TwoQueueCache[K, V]{
+		capacity: capacity,
+		inSize:   max(int(float64(capacity)*twoQueueInRatio), 1),
+		outSize:  max(int(float64(capacity)*twoQueueOutRatio), 1),
+		items:    make(map[K]*twoQueueEntry[K, V], capacity),
+		ghosts:   make(map[K]*deque.ListNode[K]),
+	}
+}
+
+// Len 返回键值对数量
+func (c *TwoQueueCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Get 获取键值对，在 Am 中时移到头部
+func (c *TwoQueueCache[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	// 节点在 A1in 中时不属于 Am，MoveToFront 什么也不做
+	c.main.MoveToFront(e.node)
+	return e.value, true
+}
+
+// Peek 获取键值对，不改变顺序
+func (c *TwoQueueCache[K, V]) Peek(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	return e.value, true
+}
+
+// Put 添加键值对
+func (c *TwoQueueCache[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.main.MoveToFront(e.node)
+		return
+	}
+	entry := &twoQueueEntry[K, V]{key: key, value: value}
+	// 最近被淘汰过，说明是热点，直接放入 Am
+	if g, ok := c.ghosts[key]; ok {
+		c.out.Remove(g)
+		delete(c.ghosts, key)
+		c.reclaim()
+		entry.node = c.main.PushFront(entry)
+		c.items[key] = entry
+		return
+	}
+	c.reclaim()
+	entry.node = c.in.PushFront(entry)
+	c.items[key] = entry
+}
+
+// reclaim 缓存满了时腾出一个位置
+// A1in 超过大小时淘汰 A1in 最早的，并把键放入 A1out，否则淘汰 Am 最久没有访问的
+func (c *TwoQueueCache[K, V]) reclaim() {
+	if len(c.items) < c.capacity {
+		return
+	}
+	if c.in.Len() >= c.inSize || c.main.Len() == 0 {
+		e := c.in.Last().GetValue()
+		c.in.Remove(e.node)
+		delete(c.items, e.key)
+		// 放入幽灵队列，超过大小时删除最早的键
+		c.ghosts[e.key] = c.out.PushFront(e.key)
+		if c.out.Len() > c.outSize {
+			g := c.out.Last()
+			c.out.Remove(g)
+			delete(c.ghosts, g.GetValue())
+		}
+		return
+	}
+	e := c.main.Last().GetValue()
+	c.main.Remove(e.node)
+	delete(c.items, e.key)
+}
+
+// Remove 删除键值对，返回键是否存在
+func (c *TwoQueueCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	if !c.in.Remove(e.node) {
+		c.main.Remove(e.node)
+	}
+	delete(c.items, key)
+	return true
+}
@@ -0,0 +1,184 @@
+package deque
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// 阻塞弹出
+/*
+BLPop/BRPop 在列表为空时阻塞，直到有新元素、ctx 被取消或者超时（使用 context.WithTimeout 设置超时）。
+与 Redis 的 BLPOP 一样，可以同时等待多个列表：按顺序检查每个列表，从第一个非空的列表弹出；
+都为空时在每个列表上排队等待，任意一个列表有新元素时被唤醒。
+
+公平性：每个列表有一个先进先出的等待队列，添加元素的操作在释放锁之前把元素依次交给等待最久的等待者，
+所以先开始等待的先拿到元素，新来的非阻塞 Pop 也抢不走已经交出的元素。
+有等待者时列表一定是空的（否则等待者已经被唤醒），所以交出的就是刚添加的元素。
+
+同一个等待者可能在多个列表上排队，用一个原子状态保证只被唤醒一次：
+唤醒者和取消等待都要先把状态从"等待中"改为"已完成"，只有改成功的一方能继续，
+唤醒失败的列表直接丢弃这个等待者，看下一个；取消失败说明元素已经交出，要把它取走。
+*/
+
+// waiter 阻塞弹出的等待者
+type waiter[T any] struct {
+	fromTail bool               // 从尾部弹出
+	done     atomic.Bool        // 是否已被唤醒或取消
+	ch       chan waitResult[T] // 唤醒时交出的元素，容量为 1，发送不会阻塞
+}
+
+// waitResult 交给等待者的元素
+type waitResult[T any] struct {
+	index int // 元素来自第几个列表
+	value T
+}
+
+// claim 把等待者的状态改为已完成，返回是否成功
+func (w *waiter[T]) claim() bool {
+	return w.done.CompareAndSwap(false, true)
+}
+
+// waitNode 等待者在某个列表的等待队列中的节点
+type waitNode[T any] struct {
+	waiter *waiter[T]
+	index  int // 列表是等待者等待的第几个列表
+	queued bool
+	pre    *waitNode[T]
+	nex    *waitNode[T]
+}
+
+// waitQueue 先进先出的等待队列
+type waitQueue[T any] struct {
+	head *waitNode[T]
+	tail *waitNode[T]
+	len  int
+}
+
+// push 加入队尾
+func (q *waitQueue[T]) push(node *waitNode[T]) {
+	node.queued = true
+	node.pre = q.tail
+	if q.tail == nil {
+		q.head = node
+	} else {
+		q.tail.nex = node
+	}
+	q.tail = node
+	q.len++
+}
+
+// remove 从队列中删除
+func (q *waitQueue[T]) remove(node *waitNode[T]) {
+	if !node.queued {
+		return
+	}
+	if node.pre == nil {
+		q.head = node.nex
+	} else {
+		node.pre.nex = node.nex
+	}
+	if node.nex == nil {
+		q.tail = node.pre
+	} else {
+		node.nex.pre = node.pre
+	}
+	node.pre, node.nex, node.queued = nil, nil, false
+	q.len--
+}
+
+// unlock 把元素交给等待者后释放锁，所有添加元素的操作都用它代替 list.lock.Unlock
+func (list *DoubleList[T]) unlock() {
+	list.serve()
+	list.lock.Unlock()
+}
+
+// serve 列表不为空时，按等待的先后顺序把元素交给等待者，调用时必须持有锁
+func (list *DoubleList[T]) serve() {
+	for list.len > 0 && list.waiters.head != nil {
+		node := list.waiters.head
+		list.waiters.remove(node)
+		w := node.waiter
+		// 已经被其他列表唤醒或者已经取消
+		if !w.claim() {
+			continue
+		}
+		end := list.head
+		if w.fromTail {
+			end = list.tail
+		}
+		v, _ := list.pop(end)
+		w.ch <- waitResult[T]{index: node.index, value: v}
+	}
+}
+
+// BLPop 弹出头部元素，列表为空时阻塞，直到有新元素或者 ctx 结束，ctx 结束时返回 ctx.Err()
+func (list *DoubleList[T]) BLPop(ctx context.Context) (T, error) {
+	_, v, err := blockingPop(ctx, false, []*DoubleList[T]{list})
+	return v, err
+}
+
+// BRPop 弹出尾部元素，列表为空时阻塞，直到有新元素或者 ctx 结束，ctx 结束时返回 ctx.Err()
+func (list *DoubleList[T]) BRPop(ctx context.Context) (T, error) {
+	_, v, err := blockingPop(ctx, true, []*DoubleList[T]{list})
+	return v, err
+}
+
+// BLPop 从第一个非空的列表弹出头部元素，返回列表的下标和元素
+// 都为空时阻塞，直到任意一个列表有新元素或者 ctx 结束，ctx 结束时返回 ctx.Err()
+func BLPop[T any](ctx context.Context, lists ...*DoubleList[T]) (int, T, error) {
+	return blockingPop(ctx, false, lists)
+}
+
+// BRPop 从第一个非空的列表弹出尾部元素，其他与 BLPop 相同
+func BRPop[T any](ctx context.Context, lists ...*DoubleList[T]) (int, T, error) {
+	return blockingPop(ctx, true, lists)
+}
+
+// blockingPop 阻塞弹出
+func blockingPop[T any](ctx context.Context, fromTail bool, lists []*DoubleList[T]) (index int, v T, err error) {
+	w := &waiter[T]{fromTail: fromTail, ch: make(chan waitResult[T], 1)}
+	nodes := make([]*waitNode[T], 0, len(lists))
+	// 注销在其他列表上的排队
+	defer func() {
+		for i, node := range nodes {
+			lists[i].lock.Lock()
+			lists[i].waiters.remove(node)
+			lists[i].lock.Unlock()
+		}
+	}()
+
+	// 按顺序检查每个列表，为空时排队
+	for i, list := range lists {
+		list.lock.Lock()
+		if list.len > 0 {
+			// 在前面的列表上排队后，可能已经被唤醒了
+			if !w.claim() {
+				list.lock.Unlock()
+				break
+			}
+			end := list.head
+			if fromTail {
+				end = list.tail
+			}
+			v, _ = list.pop(end)
+			list.lock.Unlock()
+			return i, v, nil
+		}
+		node := &waitNode[T]{waiter: w, index: i}
+		list.waiters.push(node)
+		nodes = append(nodes, node)
+		list.lock.Unlock()
+	}
+
+	select {
+	case r := <-w.ch:
+		return r.index, r.value, nil
+	case <-ctx.Done():
+		if w.claim() {
+			return -1, v, ctx.Err()
+		}
+		// 取消的同时已经被唤醒，元素已经交出，不能丢掉
+		r := <-w.ch
+		return r.index, r.value, nil
+	}
+}
@@ -0,0 +1,208 @@
+package deque
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitWaiters 等待列表上有 n 个等待者
+func waitWaiters[T any](t *testing.T, list *DoubleList[T], n int) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		list.lock.Lock()
+		got := list.waiters.len
+		list.lock.Unlock()
+		if got == n {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
+
+func TestBLPopWakeUp(t *testing.T) {
+	var list DoubleList[int]
+	done := make(chan int)
+	go func() {
+		v, err := list.BLPop(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		done <- v
+	}()
+	waitWaiters(t, &list, 1)
+	list.PushBack(42)
+	if v := <-done; v != 42 {
+		t.Fatalf("BLPop = %d, want 42", v)
+	}
+	if list.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", list.Len())
+	}
+}
+
+func TestBLPopTimeout(t *testing.T) {
+	var list DoubleList[int]
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := list.BRPop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BRPop error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// 超时后不再排队，之后添加的元素留在列表中
+	waitWaiters(t, &list, 0)
+	list.PushBack(1)
+	if list.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", list.Len())
+	}
+}
+
+// 先等待的先拿到元素
+func TestBLPopFIFO(t *testing.T) {
+	const n = 10
+	var list DoubleList[int]
+	results := make([]chan int, n)
+	for i := range results {
+		results[i] = make(chan int, 1)
+		go func(ch chan int) {
+			v, _ := list.BLPop(context.Background())
+			ch <- v
+		}(results[i])
+		waitWaiters(t, &list, i+1)
+	}
+	for i := 0; i < n; i++ {
+		list.PushBack(i)
+	}
+	for i, ch := range results {
+		if v := <-ch; v != i {
+			t.Fatalf("waiter %d got %d", i, v)
+		}
+	}
+}
+
+// 与 Redis 相同，一个命令添加多个元素后才唤醒等待者
+func TestBLPopAfterLPush(t *testing.T) {
+	var list DoubleList[string]
+	first, second := make(chan string, 1), make(chan string, 1)
+	for i, ch := range []chan string{first, second} {
+		go func(ch chan string) {
+			v, _ := list.BLPop(context.Background())
+			ch <- v
+		}(ch)
+		waitWaiters(t, &list, i+1)
+	}
+	list.LPush("a", "b", "c")
+	if v := <-first; v != "c" {
+		t.Errorf("first waiter got %q, want c", v)
+	}
+	if v := <-second; v != "b" {
+		t.Errorf("second waiter got %q, want b", v)
+	}
+	if got := list.LRange(0, -1); len(got) != 1 || got[0] != "a" {
+		t.Errorf("LRange(0, -1) = %v, want [a]", got)
+	}
+}
+
+func TestBLPopMultiple(t *testing.T) {
+	var a, b, c DoubleList[string]
+	b.RPush("b1", "b2")
+	c.RPush("c1")
+	// 从第一个非空的列表弹出
+	if i, v, err := BLPop(context.Background(), &a, &b, &c); i != 1 || v != "b1" || err != nil {
+		t.Fatalf("BLPop = %d, %q, %v, want 1, b1, nil", i, v, err)
+	}
+	if i, v, err := BRPop(context.Background(), &a, &c, &b); i != 1 || v != "c1" || err != nil {
+		t.Fatalf("BRPop = %d, %q, %v, want 1, c1, nil", i, v, err)
+	}
+	b.LPop()
+
+	// 都为空时等待任意一个列表
+	done := make(chan int)
+	go func() {
+		i, v, err := BLPop(context.Background(), &a, &b, &c)
+		if v != "c2" || err != nil {
+			t.Errorf("BLPop = %q, %v, want c2, nil", v, err)
+		}
+		done <- i
+	}()
+	waitWaiters(t, &a, 1)
+	waitWaiters(t, &c, 1)
+	c.PushBack("c2")
+	if i := <-done; i != 2 {
+		t.Fatalf("BLPop index = %d, want 2", i)
+	}
+	// 被唤醒后从其他列表的等待队列中注销
+	waitWaiters(t, &a, 0)
+	waitWaiters(t, &b, 0)
+	a.PushBack("a1")
+	if a.Len() != 1 {
+		t.Fatalf("a.Len() = %d, want 1", a.Len())
+	}
+}
+
+// 并发的生产者和带超时的消费者，配合 -race 运行：每个元素恰好被弹出一次或者留在列表中
+func TestBLPopParallel(t *testing.T) {
+	const producers, consumers, perProducer = 4, 8, 2000
+	var a, b DoubleList[int]
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	stop := make(chan struct{})
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c%3)*time.Millisecond)
+				var v int
+				var err error
+				if c%2 == 0 {
+					_, v, err = BLPop(ctx, &a, &b)
+				} else {
+					v, err = b.BRPop(ctx)
+				}
+				cancel()
+				if err == nil {
+					mu.Lock()
+					seen[v]++
+					mu.Unlock()
+				}
+			}
+		}(c)
+	}
+	var pw sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pw.Add(1)
+		go func(p int) {
+			defer pw.Done()
+			for i := 0; i < perProducer; i++ {
+				if i%2 == 0 {
+					a.PushBack(p*perProducer + i)
+				} else {
+					b.LPush(p*perProducer + i)
+				}
+			}
+		}(p)
+	}
+	pw.Wait()
+	close(stop)
+	wg.Wait()
+	for _, list := range []*DoubleList[int]{&a, &b} {
+		for v, ok := list.LPop(); ok; v, ok = list.LPop() {
+			seen[v]++
+		}
+	}
+	if len(seen) != producers*perProducer {
+		t.Fatalf("got %d distinct values, want %d", len(seen), producers*perProducer)
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("value %d popped %d times", v, n)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package deque
+
+// Deque 双端队列的公共操作，DoubleList 和 Quicklist 都实现了它
+type Deque[T any] interface {
+	Len() int
+	LPush(vs ...T) int
+	RPush(vs ...T) int
+	LPop() (T, bool)
+	RPop() (T, bool)
+	LIndex(i int) (T, bool)
+	LSet(i int, v T) error
+	LRange(start, stop int) []T
+	LTrim(start, stop int)
+}
+
+var (
+	_ Deque[string] = (*DoubleList[string])(nil)
+	_ Deque[string] = (*Quicklist)(nil)
+)
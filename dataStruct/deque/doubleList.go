@@ -1,7 +1,6 @@
-package main
+package deque
 
 import (
-	"fmt"
 	"sync"
 )
 
@@ -9,19 +8,22 @@ import (
 // 缓存数据库 Redis 的 列表List 基本类型就是用它来实现的
 // DoubleList 双端链表，双端队列
 
-// DoubleList 双端队列结构体
-type DoubleList struct {
-	head *ListNode  // 指向链表头部
-	tail *ListNode  // 指向链表尾部
-	len  int        // 列表长度
-	lock sync.Mutex // 为了进行并发安全的pop弹出操作
+// DoubleList 双端队列结构体，零值为空列表，可以直接使用
+type DoubleList[T any] struct {
+	head    *ListNode[T] // 指向链表头部
+	tail    *ListNode[T] // 指向链表尾部
+	len     int          // 列表长度
+	owner   *listOwner   // 节点所属列表的标记，见 owns
+	waiters waitQueue[T] // 阻塞弹出的等待者，见 BLPop
+	lock    sync.Mutex   // 为了进行并发安全的pop弹出操作
 }
 
 // ListNode 列表节点
-type ListNode struct {
-	pre   *ListNode // 前驱节点
-	nex   *ListNode // 后继节点
-	value string    // 值
+type ListNode[T any] struct {
+	pre   *ListNode[T] // 前驱节点
+	nex   *ListNode[T] // 后继节点
+	value T            // 值
+	owner *listOwner   // 所属列表的标记，节点被移除后为 nil
 }
 
 // 列表节点普通操作
@@ -29,37 +31,37 @@ type ListNode struct {
 // 有没有后驱和前驱节点，返回值等，时间复杂度都是 O(1)
 
 // GetValue 获取节点值
-func (node *ListNode) GetValue() string {
+func (node *ListNode[T]) GetValue() T {
 	return node.value
 }
 
 // GetPre 获取前驱节点
-func (node *ListNode) GetPre() *ListNode {
+func (node *ListNode[T]) GetPre() *ListNode[T] {
 	return node.pre
 }
 
 // GetNext 获取后继节点
-func (node *ListNode) GetNext() *ListNode {
+func (node *ListNode[T]) GetNext() *ListNode[T] {
 	return node.nex
 }
 
 // IsPre 是否存在前驱节点
-func (node *ListNode) IsPre() bool {
+func (node *ListNode[T]) IsPre() bool {
 	return node.pre != nil
 }
 
 // IsNext 是否存在后继节点
-func (node *ListNode) IsNext() bool {
+func (node *ListNode[T]) IsNext() bool {
 	return node.nex != nil
 }
 
 // IsNil 节点是否为空
-func (node *ListNode) IsNil() bool {
+func (node *ListNode[T]) IsNil() bool {
 	return node == nil
 }
 
 // Len 返回列表长度
-func (list *DoubleList) Len() int {
+func (list *DoubleList[T]) Len() int {
 	return list.len
 }
 
@@ -90,10 +92,10 @@ func (list *DoubleList) Len() int {
 // AddNodeFormHead 从头部开始，添加节点到第N+1个元素之前，
 // N=0表示添加到第一个元素之前，表示新节点成为新的头部，
 // N=1表示添加到第二个元素之前，以此类推
-func (list *DoubleList) AddNodeFormHead(n int, v string) {
+func (list *DoubleList[T]) AddNodeFormHead(n int, v T) {
 	// 并发加锁
 	list.lock.Lock()
-	defer list.lock.Unlock()
+	defer list.unlock()
 
 	// 如果索引超过或等于列表长度，一定找不到，直接panic
 	if n != 0 && n >= list.len {
@@ -106,8 +108,9 @@ func (list *DoubleList) AddNodeFormHead(n int, v string) {
 		node = node.nex
 	}
 	// 新节点
-	newNode := new(ListNode)
+	newNode := new(ListNode[T])
 	newNode.value = v
+	newNode.owner = list.id()
 	// 如果定位到的节点为空，表示列表为空，将新节点设置为新头部和新尾部
 	if node.IsNil() {
 		list.head = newNode
@@ -142,10 +145,10 @@ func (list *DoubleList) AddNodeFormHead(n int, v string) {
 // N=1表示添加到第二个元素之后，以此类推
 // 与从头部一样也存在三种情况，找到的结点为空，为尾部节点，不为尾部节点，处理方法一直
 
-func (list *DoubleList) AddNodeFromTail(n int, v string) {
+func (list *DoubleList[T]) AddNodeFromTail(n int, v T) {
 	// 并发加锁
 	list.lock.Lock()
-	defer list.lock.Unlock()
+	defer list.unlock()
 
 	// 如果索引超过或等于列表长度，一定找不到，直接panic
 	if n != 0 && n >= list.len {
@@ -158,8 +161,9 @@ func (list *DoubleList) AddNodeFromTail(n int, v string) {
 		node = node.pre
 	}
 	// 新节点
-	newNode := new(ListNode)
+	newNode := new(ListNode[T])
 	newNode.value = v
+	newNode.owner = list.id()
 	// 如果定位到的节点为空，表示列表为空，将新节点设置为新头部和新尾部
 	if node.IsNil() {
 		list.head = newNode
@@ -189,12 +193,12 @@ func (list *DoubleList) AddNodeFromTail(n int, v string) {
 }
 
 // First 返回列表链表头结点
-func (list *DoubleList) First() *ListNode {
+func (list *DoubleList[T]) First() *ListNode[T] {
 	return list.head
 }
 
 // Last 返回列表链表尾结点
-func (list *DoubleList) Last() *ListNode {
+func (list *DoubleList[T]) Last() *ListNode[T] {
 	return list.tail
 }
 
@@ -203,7 +207,7 @@ func (list *DoubleList) Last() *ListNode {
 // 否则从头部开始遍历，拿到节点。
 // 时间复杂度为：O(n)
 // IndexFromHead 从头部开始往后找，获取第N+1个位置的节点，索引从0开始
-func (list *DoubleList) IndexFromHead(n int) *ListNode {
+func (list *DoubleList[T]) IndexFromHead(n int) *ListNode[T] {
 	// 索引超过或等于列表长度，一定找不到，返回空指针
 	if n >= list.len {
 		return nil
@@ -222,7 +226,7 @@ func (list *DoubleList) IndexFromHead(n int) *ListNode {
 // 如果索引超出或等于列表长度，那么找不到节点，返回空。
 // 否则从尾部开始遍历，拿到节点。
 // 时间复杂度为：O(n)
-func(list *DoubleList) IndexFromTail(n int) *ListNode {
+func(list *DoubleList[T]) IndexFromTail(n int) *ListNode[T] {
 	if n >= list.len {
 		return nil
 	}
@@ -240,7 +244,7 @@ func(list *DoubleList) IndexFromTail(n int) *ListNode {
 // PopFromHead 从头部开始往后找，获取第N+1个位置的节点，并移除返回
 // 定位到的并要移除的节点有三种情况发生，移除的是头部，尾部或者中间节点
 // 主要的耗时用在定位节点上，其他的操作都是链表链接，可以知道时间复杂度为：O(n)
-func (list *DoubleList) PopFromHead(n int) *ListNode {
+func (list *DoubleList[T]) PopFromHead(n int) *ListNode[T] {
 	// 加并发锁
 	list.lock.Lock()
 	defer list.lock.Unlock()
@@ -276,6 +280,8 @@ func (list *DoubleList) PopFromHead(n int) *ListNode {
 	}
 	// 节点减一
 	list.len = list.len - 1
+	// 断开与列表的联系，移除后的节点不能再用于节点句柄操作
+	node.pre, node.nex, node.owner = nil, nil, nil
 	return node
 }
 
@@ -283,7 +289,7 @@ func (list *DoubleList) PopFromHead(n int) *ListNode {
 // PopFromTail 从尾部开始往前找，获取第N+1个位置的节点，并移除返回
 // 定位到的并要移除的节点有三种情况发生，移除的是尾部，头部或者中间节点
 // 主要的耗时用在定位节点上，其他的操作都是链表链接，可以知道时间复杂度为：O(n)
-func (list *DoubleList) PopFromTail(n int) *ListNode {
+func (list *DoubleList[T]) PopFromTail(n int) *ListNode[T] {
 	// 加并发锁
 	list.lock.Lock()
 	defer list.lock.Unlock()
@@ -319,54 +325,222 @@ func (list *DoubleList) PopFromTail(n int) *ListNode {
 	}
 	// 节点减一
 	list.len = list.len - 1
+	// 断开与列表的联系，移除后的节点不能再用于节点句柄操作
+	node.pre, node.nex, node.owner = nil, nil, nil
 	return node
 }
 
-func main() {
-	// 测试
-	list := new(DoubleList)
-	// 在列表头部插入新元素
-	list.AddNodeFormHead(0, "I")
-	list.AddNodeFormHead(0, "Love")
-	list.AddNodeFormHead(0, "you")
-	// 在列表尾部插入新元素
-	list.AddNodeFromTail(0, "may")
-	list.AddNodeFromTail(0, "happy")
-	// 在N之前后之后插入
-	list.AddNodeFromTail(list.Len()-1, "begin second")
-	list.AddNodeFormHead(list.Len()-1, "end second")
-
-	// 正常遍历，比较慢，因为内部会遍历拿到值返回
-	//for i := 0; i < list.len; i++ {
-	//	// 从头部开始索引
-	//	node := list.IndexFromHead(i)
-	//	// 节点为空不可能，因为list.Len()使得索引不会越界
-	//	if !node.IsNil() {
-	//		fmt.Println(node.GetValue())
-	//	}
-	//}
-	//fmt.Println("----------")
-
-	// 正常遍历，特别快，因为直接拿到的链表节点
-	// 先取出第一个元素
-	first := list.First()
-	for !first.IsNil() {
-		// 如果非空就一直遍历
-		fmt.Println(first.GetValue())
-		// 接着下一个节点
-		first = first.GetNext()
-	}
-	fmt.Println("----------")
-
-	// 元素一个个 POP 出来
-	for {
-		node := list.PopFromHead(0)
-		if node.IsNil() {
-			// 没有元素了，直接返回
-			break
-		}
-		fmt.Println(node.GetValue())
+// 节点句柄操作
+/*
+上面的操作都按下标定位节点，删除一个已知的节点也要先遍历，时间复杂度为 O(n)。
+下面的操作直接返回或接收节点（节点句柄），插入、删除、移动都是 O(1)：
+	PushFront/PushBack            在头部/尾部添加，返回新节点
+	InsertBefore/InsertAfter      在某个节点之前/之后添加，返回新节点
+	Remove                        删除节点
+	MoveToFront/MoveToBack        把节点移到头部/尾部
+	SpliceFront/SpliceBack        把另一个列表的所有节点整体接到头部/尾部
+
+传入的节点必须属于当前列表，否则不做任何操作（比如已经被删除的节点、其他列表的节点）。
+判断节点属于哪个列表，最简单的做法是节点保存列表的指针，
+但是拼接时被移动的节点都要修改这个指针，拼接就变成了 O(n)。
+所以这里节点保存的是一个标记 listOwner，每个列表有一个自己的标记：
+拼接时把被拼接列表的标记指向当前列表的标记，被拼接列表换一个新的标记，
+判断时沿着标记的指向找到最终的标记，它对应的列表就是节点所属的列表（与并查集相同），
+顺便把节点的标记直接指向最终的标记（路径压缩），所以判断的均摊时间复杂度接近 O(1)。
+*/
+
+// listOwner 节点所属列表的标记
+type listOwner struct {
+	next  *listOwner // 列表被拼接到其他列表后，指向其他列表的标记
+	valid bool       // 是否仍然是某个列表正在使用的标记
+}
+
+// id 返回列表的标记，零值列表第一次使用时创建
+func (list *DoubleList[T]) id() *listOwner {
+	if list.owner == nil {
+		list.owner = &listOwner{valid: true}
+	}
+	return list.owner
+}
+
+// owns 判断节点是否属于当前列表
+func (list *DoubleList[T]) owns(node *ListNode[T]) bool {
+	if node == nil || node.owner == nil || list.owner == nil {
+		return false
+	}
+	// 找到最终的标记
+	root := node.owner
+	for root.next != nil {
+		root = root.next
+	}
+	// 路径压缩
+	for o := node.owner; o != root; {
+		next := o.next
+		o.next = root
+		o = next
+	}
+	node.owner = root
+	return root == list.owner && root.valid
+}
+
+// insertBetween 把新节点链接到 pre 和 next 之间，pre 为 nil 表示成为新头部，next 为 nil 表示成为新尾部
+func (list *DoubleList[T]) insertBetween(node, pre, next *ListNode[T]) *ListNode[T] {
+	node.pre = pre
+	node.nex = next
+	node.owner = list.id()
+	if pre.IsNil() {
+		list.head = node
+	} else {
+		pre.nex = node
+	}
+	if next.IsNil() {
+		list.tail = node
+	} else {
+		next.pre = node
+	}
+	list.len = list.len + 1
+	return node
+}
+
+// unlink 把节点从链表中断开
+func (list *DoubleList[T]) unlink(node *ListNode[T]) {
+	if node.pre.IsNil() {
+		list.head = node.nex
+	} else {
+		node.pre.nex = node.nex
+	}
+	if node.nex.IsNil() {
+		list.tail = node.pre
+	} else {
+		node.nex.pre = node.pre
+	}
+	node.pre, node.nex, node.owner = nil, nil, nil
+	list.len = list.len - 1
+}
+
+// PushFront 在头部添加元素，返回新节点
+func (list *DoubleList[T]) PushFront(v T) *ListNode[T] {
+	list.lock.Lock()
+	defer list.unlock()
+	return list.insertBetween(&ListNode[T]{value: v}, nil, list.head)
+}
+
+// PushBack 在尾部添加元素，返回新节点
+func (list *DoubleList[T]) PushBack(v T) *ListNode[T] {
+	list.lock.Lock()
+	defer list.unlock()
+	return list.insertBetween(&ListNode[T]{value: v}, list.tail, nil)
+}
+
+// InsertBefore 在节点 mark 之前添加元素，返回新节点，mark 不属于列表时返回 nil
+func (list *DoubleList[T]) InsertBefore(v T, mark *ListNode[T]) *ListNode[T] {
+	list.lock.Lock()
+	defer list.unlock()
+	if !list.owns(mark) {
+		return nil
+	}
+	return list.insertBetween(&ListNode[T]{value: v}, mark.pre, mark)
+}
+
+// InsertAfter 在节点 mark 之后添加元素，返回新节点，mark 不属于列表时返回 nil
+func (list *DoubleList[T]) InsertAfter(v T, mark *ListNode[T]) *ListNode[T] {
+	list.lock.Lock()
+	defer list.unlock()
+	if !list.owns(mark) {
+		return nil
+	}
+	return list.insertBetween(&ListNode[T]{value: v}, mark, mark.nex)
+}
+
+// Remove 删除节点，返回节点是否属于列表
+func (list *DoubleList[T]) Remove(node *ListNode[T]) bool {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	if !list.owns(node) {
+		return false
 	}
-	fmt.Println("----------")
-	fmt.Println("len", list.Len())
-}
\ No newline at end of file
+	list.unlink(node)
+	return true
+}
+
+// MoveToFront 把节点移到头部，返回节点是否属于列表
+func (list *DoubleList[T]) MoveToFront(node *ListNode[T]) bool {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	if !list.owns(node) {
+		return false
+	}
+	if list.head != node {
+		list.unlink(node)
+		list.insertBetween(node, nil, list.head)
+	}
+	return true
+}
+
+// MoveToBack 把节点移到尾部，返回节点是否属于列表
+func (list *DoubleList[T]) MoveToBack(node *ListNode[T]) bool {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	if !list.owns(node) {
+		return false
+	}
+	if list.tail != node {
+		list.unlink(node)
+		list.insertBetween(node, list.tail, nil)
+	}
+	return true
+}
+
+// SpliceFront 把 other 的所有节点按原来的顺序接到头部，other 变为空列表，时间复杂度为 O(1)
+// other 原来的节点句柄仍然有效，之后属于当前列表
+func (list *DoubleList[T]) SpliceFront(other *DoubleList[T]) {
+	head, tail, n, owner := other.take()
+	if n == 0 {
+		return
+	}
+	list.lock.Lock()
+	defer list.unlock()
+	owner.next = list.id()
+	if list.head.IsNil() {
+		list.tail = tail
+	} else {
+		tail.nex = list.head
+		list.head.pre = tail
+	}
+	list.head = head
+	list.len = list.len + n
+}
+
+// SpliceBack 把 other 的所有节点按原来的顺序接到尾部，other 变为空列表，时间复杂度为 O(1)
+// other 原来的节点句柄仍然有效，之后属于当前列表
+func (list *DoubleList[T]) SpliceBack(other *DoubleList[T]) {
+	head, tail, n, owner := other.take()
+	if n == 0 {
+		return
+	}
+	list.lock.Lock()
+	defer list.unlock()
+	owner.next = list.id()
+	if list.tail.IsNil() {
+		list.head = head
+	} else {
+		list.tail.nex = head
+		head.pre = list.tail
+	}
+	list.tail = tail
+	list.len = list.len + n
+}
+
+// take 取出列表的整条链表和标记，列表变为空列表并换一个新的标记
+// 取出的标记不再有效，链接到新列表的标记之前，这些节点不属于任何列表
+func (list *DoubleList[T]) take() (head, tail *ListNode[T], n int, owner *listOwner) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	head, tail, n, owner = list.head, list.tail, list.len, list.owner
+	if n == 0 {
+		return
+	}
+	owner.valid = false
+	list.head, list.tail, list.len, list.owner = nil, nil, 0, nil
+	return
+}
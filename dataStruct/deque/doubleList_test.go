@@ -0,0 +1,126 @@
+package deque
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 多个列表之间随机插入、删除、移动、拼接，与切片实现的参考列表对比
+func TestDoubleListRandomOps(t *testing.T) {
+	const lists = 4
+	var ls [lists]DoubleList[int]
+	var refs [lists][]*ListNode[int] // 参考实现，保存每个列表从头到尾的节点
+	var removed []*ListNode[int]
+	// pick 随机选一个节点，可能是已删除的节点
+	pick := func(r *rand.Rand) *ListNode[int] {
+		all := append([]*ListNode[int](nil), removed...)
+		for _, ref := range refs {
+			all = append(all, ref...)
+		}
+		if len(all) == 0 {
+			return nil
+		}
+		return all[r.Intn(len(all))]
+	}
+	find := func(node *ListNode[int]) (int, int) {
+		for l, ref := range refs {
+			for i, n := range ref {
+				if n == node {
+					return l, i
+				}
+			}
+		}
+		return -1, -1
+	}
+	insert := func(ref []*ListNode[int], i int, node *ListNode[int]) []*ListNode[int] {
+		return append(ref[:i], append([]*ListNode[int]{node}, ref[i:]...)...)
+	}
+	r := rand.New(rand.NewSource(1))
+	for op := 0; op < 20000; op++ {
+		l := r.Intn(lists)
+		node := pick(r)
+		owner, i := find(node)
+		switch r.Intn(8) {
+		case 0:
+			refs[l] = insert(refs[l], 0, ls[l].PushFront(op))
+		case 1:
+			refs[l] = append(refs[l], ls[l].PushBack(op))
+		case 2:
+			got := ls[l].InsertBefore(op, node)
+			if (got != nil) != (owner == l) {
+				t.Fatalf("InsertBefore on list %d, node owner %d", l, owner)
+			}
+			if got != nil {
+				refs[l] = insert(refs[l], i, got)
+			}
+		case 3:
+			got := ls[l].InsertAfter(op, node)
+			if (got != nil) != (owner == l) {
+				t.Fatalf("InsertAfter on list %d, node owner %d", l, owner)
+			}
+			if got != nil {
+				refs[l] = insert(refs[l], i+1, got)
+			}
+		case 4:
+			if ls[l].Remove(node) != (owner == l) {
+				t.Fatalf("Remove on list %d, node owner %d", l, owner)
+			}
+			if owner == l {
+				refs[l] = append(refs[l][:i], refs[l][i+1:]...)
+				removed = append(removed, node)
+			}
+		case 5:
+			if ls[l].MoveToFront(node) != (owner == l) {
+				t.Fatalf("MoveToFront on list %d, node owner %d", l, owner)
+			}
+			if owner == l {
+				refs[l] = insert(append(refs[l][:i], refs[l][i+1:]...), 0, node)
+			}
+		case 6:
+			if ls[l].MoveToBack(node) != (owner == l) {
+				t.Fatalf("MoveToBack on list %d, node owner %d", l, owner)
+			}
+			if owner == l {
+				refs[l] = append(append(refs[l][:i], refs[l][i+1:]...), node)
+			}
+		case 7:
+			// 偶尔拼接，包括拼接自己
+			o := r.Intn(lists)
+			if r.Intn(2) == 0 {
+				ls[l].SpliceBack(&ls[o])
+				if o != l {
+					refs[l] = append(refs[l], refs[o]...)
+				}
+			} else {
+				ls[l].SpliceFront(&ls[o])
+				if o != l {
+					refs[l] = append(append([]*ListNode[int](nil), refs[o]...), refs[l]...)
+				}
+			}
+			if o != l {
+				refs[o] = nil
+			}
+		}
+		// 检查链表两个方向的顺序和长度
+		for l := range ls {
+			list, ref := &ls[l], refs[l]
+			if list.Len() != len(ref) {
+				t.Fatalf("op %d: list %d Len() = %d, want %d", op, l, list.Len(), len(ref))
+			}
+			node := list.First()
+			for i := range ref {
+				if node != ref[i] {
+					t.Fatalf("op %d: list %d node %d mismatch", op, l, i)
+				}
+				node = node.GetNext()
+			}
+			node = list.Last()
+			for i := len(ref) - 1; i >= 0; i-- {
+				if node != ref[i] {
+					t.Fatalf("op %d: list %d node %d mismatch from tail", op, l, i)
+				}
+				node = node.GetPre()
+			}
+		}
+	}
+}
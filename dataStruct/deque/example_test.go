@@ -0,0 +1,180 @@
+package deque_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+func ExampleDoubleList() {
+	list := new(deque.DoubleList[string])
+	// 在列表头部插入新元素
+	list.AddNodeFormHead(0, "I")
+	list.AddNodeFormHead(0, "Love")
+	list.AddNodeFormHead(0, "you")
+	// 在列表尾部插入新元素
+	list.AddNodeFromTail(0, "may")
+	list.AddNodeFromTail(0, "happy")
+	// 在N之前后之后插入
+	list.AddNodeFromTail(list.Len()-1, "begin second")
+	list.AddNodeFormHead(list.Len()-1, "end second")
+
+	// 正常遍历，特别快，因为直接拿到的链表节点
+	// 先取出第一个元素
+	first := list.First()
+	for !first.IsNil() {
+		// 如果非空就一直遍历
+		fmt.Println(first.GetValue())
+		// 接着下一个节点
+		first = first.GetNext()
+	}
+	fmt.Println("----------")
+
+	// 元素一个个 POP 出来
+	for {
+		node := list.PopFromHead(0)
+		if node.IsNil() {
+			// 没有元素了，直接返回
+			break
+		}
+		fmt.Println(node.GetValue())
+	}
+	fmt.Println("----------")
+	fmt.Println("len", list.Len())
+	// Output:
+	// you
+	// begin second
+	// Love
+	// I
+	// may
+	// end second
+	// happy
+	// ----------
+	// you
+	// begin second
+	// Love
+	// I
+	// may
+	// end second
+	// happy
+	// ----------
+	// len 0
+}
+
+// values 从头到尾输出列表的值
+func values[T any](list *deque.DoubleList[T]) []T {
+	var vs []T
+	for node := list.First(); !node.IsNil(); node = node.GetNext() {
+		vs = append(vs, node.GetValue())
+	}
+	return vs
+}
+
+func ExampleDoubleList_PushBack() {
+	var list deque.DoubleList[int]
+	two := list.PushBack(2)
+	list.PushBack(4)
+	list.PushFront(1)
+	// 直接在已知节点前后插入，不需要遍历
+	list.InsertAfter(3, two)
+	five := list.PushBack(5)
+	fmt.Println(values(&list))
+	list.MoveToFront(five)
+	list.MoveToBack(two)
+	fmt.Println(values(&list))
+	// 删除过的节点不再属于列表
+	fmt.Println(list.Remove(two), list.Remove(two), list.InsertBefore(0, two) == nil)
+	fmt.Println(values(&list), list.Len())
+	// Output:
+	// [1 2 3 4 5]
+	// [5 1 3 4 2]
+	// true false true
+	// [5 1 3 4] 4
+}
+
+func ExampleDoubleList_SpliceBack() {
+	var a, b deque.DoubleList[string]
+	a.PushBack("a1")
+	a.PushBack("a2")
+	b1 := b.PushBack("b1")
+	b.PushBack("b2")
+	// 整体移动 b 的节点，O(1)
+	a.SpliceBack(&b)
+	fmt.Println(values(&a), a.Len(), b.Len())
+	// b1 现在属于 a
+	fmt.Println(b.Remove(b1), a.MoveToFront(b1))
+	fmt.Println(values(&a))
+	// Output:
+	// [a1 a2 b1 b2] 4 0
+	// false true
+	// [b1 a1 a2 b2]
+}
+
+func ExampleDoubleList_LRange() {
+	var list deque.DoubleList[string]
+	list.RPush("a", "b", "c", "b", "d")
+	fmt.Println(list.LRange(0, -1), list.LRange(-2, 100))
+	deque.LInsert(&list, deque.After, "c", "x")
+	fmt.Println(deque.LRem(&list, 0, "b"), list.LRange(0, -1))
+	i, _ := deque.LPos(&list, "x")
+	v, _ := list.LIndex(-1)
+	fmt.Println(i, v)
+	list.LTrim(1, -2)
+	fmt.Println(list.LRange(0, -1))
+	// Output:
+	// [a b c b d] [b d]
+	// 2 [a c x d]
+	// 2 d
+	// [c x]
+}
+
+func ExampleBLPop() {
+	var jobs, urgent deque.DoubleList[string]
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		urgent.RPush("fix")
+	}()
+	// 两个列表都为空，阻塞到 urgent 有新元素
+	i, v, err := deque.BLPop(context.Background(), &jobs, &urgent)
+	fmt.Println(i, v, err)
+
+	// 超时
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = jobs.BLPop(ctx)
+	fmt.Println(err)
+	// Output:
+	// 1 fix <nil>
+	// context deadline exceeded
+}
+
+func ExampleQuicklist() {
+	// 每个块最多 4 个元素，两端各 1 个块不压缩
+	list := deque.NewQuicklistWithOptions(deque.QuicklistOptions{MaxChunkEntries: 4, CompressDepth: 1})
+	for i := 0; i < 10; i++ {
+		list.RPush(fmt.Sprintf("item-%d", i))
+	}
+	list.LPush("first")
+	v, _ := list.RPop()
+	fmt.Println(v, list.LRange(0, 2), list.Len())
+	list.LInsert(deque.Before, "item-5", "new")
+	fmt.Println(list.LRange(5, 7))
+	stats := list.Stats()
+	fmt.Println(stats.Len, stats.Chunks)
+
+	// 与 DoubleList 使用相同的接口
+	queues := []deque.Deque[string]{list, new(deque.DoubleList[string])}
+	for _, q := range queues {
+		q.RPush("x", "y")
+		q.LTrim(-2, -1)
+		fmt.Println(q.LRange(0, -1))
+	}
+	// Output:
+	// item-9 [first item-0 item-1] 10
+	// [item-4 new item-5]
+	// 11 5
+	// [x y]
+	// [x y]
+}
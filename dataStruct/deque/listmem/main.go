@@ -0,0 +1,118 @@
+// listmem 比较 DoubleList 和 Quicklist 保存大量短字符串时的内存占用
+//
+// 用法：
+//
+//	listmem [-n 1000000] [-chunk 8192,2048] [-depth 0,1] [keys.txt]
+//
+// 从文件按行读取元素（省略文件时生成 n 个形如 user:000042 的字符串），
+// 分别放入 DoubleList 以及各种块大小、压缩深度的 Quicklist，
+// 输出垃圾回收后实际增加的堆内存、平均每个元素占用的字节数，以及 Quicklist.Stats 的统计。
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/deque"
+)
+
+func main() {
+	n := flag.Int("n", 1000000, "没有文件时生成的元素数量")
+	chunks := flag.String("chunk", "8192,2048", "Quicklist 每个块最多的字节数，多个用逗号分隔")
+	depths := flag.String("depth", "0,1", "Quicklist 两端不压缩的块数，多个用逗号分隔，0 表示不压缩")
+	flag.Parse()
+
+	chunkSizes, err := parseInts(*chunks)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	compressDepths, err := parseInts(*depths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	run(values(*n, flag.Args()), chunkSizes, compressDepths)
+}
+
+// values 返回元素生成函数，元素在测量内存的过程中生成，DoubleList 中每个字符串单独分配的开销也被计算在内
+func values(n int, paths []string) func(yield func(string)) {
+	if len(paths) == 0 {
+		return func(yield func(string)) {
+			for i := 0; i < n; i++ {
+				yield(fmt.Sprintf("user:%06d", i))
+			}
+		}
+	}
+	return func(yield func(string)) {
+		for _, path := range paths {
+			f, err := os.Open(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				yield(scanner.Text())
+			}
+			f.Close()
+		}
+	}
+}
+
+// run 依次测量各种列表的内存占用并输出
+func run(each func(yield func(string)), chunkSizes, depths []int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "list\tlen\theap bytes\tbytes/elem\tchunks\tcompressed\testimated bytes")
+
+	var list deque.DoubleList[string]
+	heap := measure(func() { each(func(v string) { list.RPush(v) }) })
+	fmt.Fprintf(w, "DoubleList\t%d\t%d\t%.1f\t-\t-\t-\n", list.Len(), heap, float64(heap)/float64(max(list.Len(), 1)))
+	runtime.KeepAlive(&list)
+
+	for _, size := range chunkSizes {
+		for _, depth := range depths {
+			ql := deque.NewQuicklistWithOptions(deque.QuicklistOptions{MaxChunkBytes: size, CompressDepth: depth})
+			heap := measure(func() { each(func(v string) { ql.RPush(v) }) })
+			stats := ql.Stats()
+			fmt.Fprintf(w, "Quicklist(chunk=%d,depth=%d)\t%d\t%d\t%.1f\t%d\t%d\t%d\n",
+				size, depth, stats.Len, heap, float64(heap)/float64(max(stats.Len, 1)),
+				stats.Chunks, stats.CompressedChunks, stats.MemoryBytes)
+			runtime.KeepAlive(ql)
+		}
+	}
+	w.Flush()
+}
+
+// measure 返回 build 执行后，垃圾回收后增加的堆内存
+// 回收两次是为了清空 sync.Pool 中缓存的压缩器，它们不属于列表
+func measure(build func()) int64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	build()
+	runtime.GC()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	return int64(after.HeapAlloc) - int64(before.HeapAlloc)
+}
+
+// parseInts 解析逗号分隔的非负整数列表
+func parseInts(s string) ([]int, error) {
+	var ns []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value %q", field)
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
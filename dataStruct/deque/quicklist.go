@@ -0,0 +1,741 @@
+package deque
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+// Quicklist 快速列表
+/*
+DoubleList 每个元素一个节点，每个节点除了值还有前驱、后继等指针，保存大量很短的字符串时，
+指针和每个字符串单独分配的开销比数据本身还大。
+Redis 的列表用 quicklist 实现：一个双向链表，每个节点（块）是一段连续的字节数组，紧凑地保存多个元素。
+
+块中每个元素的格式（与 Redis 的 listpack 类似）：
+	长度(uvarint) 数据 回退长度
+回退长度是"长度+数据"占用的字节数，按 uvarint 的字节逆序保存，从元素的末尾往前就能读出来，
+所以块既可以从前往后遍历，也可以从后往前遍历，两端的弹出都不需要扫描整个块。
+
+块的大小：
+	MaxChunkBytes    每个块最多的字节数，默认 8KB（与 Redis 的 list-max-listpack-size -2 相同）
+	MaxChunkEntries  每个块最多的元素数，0 表示不限制
+超过大小的单个元素单独占一个块。在块的中间插入、删除需要移动块中的数据，时间复杂度为 O(块大小)；
+按下标定位时先按块跳过，时间复杂度为 O(块数 + 块大小)。
+
+压缩（与 Redis 的 list-compress-depth 相同）：
+列表通常只访问两端，CompressDepth 为 d 时，两端各 d 个块保持不压缩，中间的块使用 flate 压缩；
+访问中间的块时临时解压，修改后重新压缩；太小或者压缩后没有明显变小的块不压缩。
+*/
+
+// Quicklist 的默认参数
+const (
+	DefaultMaxChunkBytes = 8 << 10 // 每个块默认最多 8KB
+	minCompressBytes     = 48      // 小于该字节数的块不压缩
+	minCompressSaving    = 8       // 压缩后至少要减少的字节数
+)
+
+// QuicklistOptions Quicklist 的参数
+type QuicklistOptions struct {
+	MaxChunkBytes   int // 每个块最多的字节数，0 表示使用 DefaultMaxChunkBytes
+	MaxChunkEntries int // 每个块最多的元素数，0 表示不限制
+	CompressDepth   int // 两端不压缩的块数，0 表示不压缩
+}
+
+// quicklistNode 块
+type quicklistNode struct {
+	pre        *quicklistNode
+	nex        *quicklistNode
+	data       []byte // 紧凑保存的元素，压缩时为压缩后的数据
+	count      int    // 元素数量
+	size       int    // 未压缩的字节数
+	compressed bool   // 是否已压缩
+}
+
+// Quicklist 快速列表，元素为字符串，零值为使用默认参数的空列表，可以直接使用
+type Quicklist struct {
+	head   *quicklistNode // 头部块
+	tail   *quicklistNode // 尾部块
+	len    int            // 元素数量
+	chunks int            // 块数量
+	opts   QuicklistOptions
+	lock   sync.Mutex
+}
+
+// NewQuicklist 创建使用默认参数的快速列表
+func NewQuicklist() *Quicklist {
+	return new(Quicklist)
+}
+
+// NewQuicklistWithOptions 创建指定参数的快速列表
+func NewQuicklistWithOptions(opts QuicklistOptions) *Quicklist {
+	opts.MaxChunkBytes = max(opts.MaxChunkBytes, 0)
+	opts.MaxChunkEntries = max(opts.MaxChunkEntries, 0)
+	opts.CompressDepth = max(opts.CompressDepth, 0)
+	return &Quicklist{opts: opts}
+}
+
+// 元素编码
+
+// uvarintLen 返回 x 编码为 uvarint 的字节数
+func uvarintLen(x int) int {
+	n := 1
+	for ; x >= 0x80; x >>= 7 {
+		n++
+	}
+	return n
+}
+
+// entrySize 返回元素编码后的字节数
+func entrySize(v string) int {
+	n := uvarintLen(len(v)) + len(v)
+	return n + uvarintLen(n)
+}
+
+// appendEntry 在 dst 末尾追加编码后的元素
+func appendEntry(dst []byte, v string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(v)))
+	dst = append(dst, v...)
+	// 回退长度，uvarint 的字节逆序保存
+	n := uvarintLen(len(v)) + len(v)
+	var buf [binary.MaxVarintLen64]byte
+	back := buf[:binary.PutUvarint(buf[:], uint64(n))]
+	for i := len(back) - 1; i >= 0; i-- {
+		dst = append(dst, back[i])
+	}
+	return dst
+}
+
+// readEntry 读取从 off 开始的元素，返回元素和下一个元素的位置
+func readEntry(data []byte, off int) (string, int) {
+	n, k := binary.Uvarint(data[off:])
+	start := off + k
+	end := start + int(n)
+	return string(data[start:end]), end + uvarintLen(end-off)
+}
+
+// readEntryBack 读取在 end 结束的元素，返回元素和它开始的位置
+func readEntryBack(data []byte, end int) (string, int) {
+	// 从后往前读出回退长度
+	var n, shift int
+	i := end - 1
+	for ; data[i]&0x80 != 0; i-- {
+		n |= int(data[i]&0x7f) << shift
+		shift += 7
+	}
+	n |= int(data[i]) << shift
+	start := i - n
+	v, _ := readEntry(data, start)
+	return v, start
+}
+
+// 块操作
+
+// flate 的压缩器和解压器创建时要分配较大的内存，复用它们
+var (
+	flateWriters = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(nil, flate.BestSpeed)
+		return w
+	}}
+	flateReaders = sync.Pool{New: func() any {
+		return flate.NewReader(nil)
+	}}
+)
+
+// raw 返回未压缩的数据，块已压缩时临时解压，不改变块
+func (node *quicklistNode) raw() []byte {
+	if !node.compressed {
+		return node.data
+	}
+	r := flateReaders.Get().(io.ReadCloser)
+	defer flateReaders.Put(r)
+	r.(flate.Resetter).Reset(bytes.NewReader(node.data), nil)
+	data := make([]byte, node.size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		panic("deque: corrupted quicklist chunk: " + err.Error())
+	}
+	return data
+}
+
+// decompress 解压块，修改块之前调用
+func (node *quicklistNode) decompress() {
+	if node.compressed {
+		node.data = node.raw()
+		node.compressed = false
+	}
+}
+
+// compress 压缩块，太小或者压缩效果不明显时不压缩
+func (node *quicklistNode) compress() {
+	if node.compressed || node.size < minCompressBytes {
+		return
+	}
+	var buf bytes.Buffer
+	w := flateWriters.Get().(*flate.Writer)
+	defer flateWriters.Put(w)
+	w.Reset(&buf)
+	w.Write(node.data)
+	w.Close()
+	if buf.Len()+minCompressSaving > node.size {
+		return
+	}
+	node.data = bytes.Clone(buf.Bytes())
+	node.compressed = true
+}
+
+// values 解码块中的所有元素
+func (node *quicklistNode) values() []string {
+	data := node.raw()
+	vs := make([]string, 0, node.count)
+	for off := 0; off < len(data); {
+		var v string
+		v, off = readEntry(data, off)
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// setValues 用 vs 重新编码块，块必须未压缩
+func (node *quicklistNode) setValues(vs []string) {
+	data := node.data[:0]
+	for _, v := range vs {
+		data = appendEntry(data, v)
+	}
+	node.data = data
+	node.count = len(vs)
+	node.size = len(data)
+}
+
+// 列表操作
+
+// maxChunkBytes 返回每个块最多的字节数
+func (list *Quicklist) maxChunkBytes() int {
+	if list.opts.MaxChunkBytes == 0 {
+		return DefaultMaxChunkBytes
+	}
+	return list.opts.MaxChunkBytes
+}
+
+// fits 块中是否还能放下 n 个字节的元素，空块总能放下
+func (list *Quicklist) fits(node *quicklistNode, n int) bool {
+	if node.count == 0 {
+		return true
+	}
+	if list.opts.MaxChunkEntries > 0 && node.count >= list.opts.MaxChunkEntries {
+		return false
+	}
+	return node.size+n <= list.maxChunkBytes()
+}
+
+// linkAfter 把新块链接到 at 之后，at 为 nil 时成为头部
+func (list *Quicklist) linkAfter(node, at *quicklistNode) {
+	node.pre = at
+	if at == nil {
+		node.nex = list.head
+		list.head = node
+	} else {
+		node.nex = at.nex
+		at.nex = node
+	}
+	if node.nex == nil {
+		list.tail = node
+	} else {
+		node.nex.pre = node
+	}
+	list.chunks++
+}
+
+// unlinkNode 删除块
+func (list *Quicklist) unlinkNode(node *quicklistNode) {
+	if node.pre == nil {
+		list.head = node.nex
+	} else {
+		node.pre.nex = node.nex
+	}
+	if node.nex == nil {
+		list.tail = node.pre
+	} else {
+		node.nex.pre = node.pre
+	}
+	node.pre, node.nex = nil, nil
+	list.chunks--
+}
+
+// nearEnds 返回两端各 CompressDepth 个块，这些块不压缩
+func (list *Quicklist) nearEnds() []*quicklistNode {
+	depth := list.opts.CompressDepth
+	near := make([]*quicklistNode, 0, 2*depth)
+	for node, i := list.head, 0; node != nil && i < depth; node, i = node.nex, i+1 {
+		near = append(near, node)
+	}
+	for node, i := list.tail, 0; node != nil && i < depth; node, i = node.pre, i+1 {
+		near = append(near, node)
+	}
+	return near
+}
+
+// updateCompression 块的结构变化后，保证两端的块不压缩、中间的块压缩
+// 每次操作最多在两端增减两个块，所以只需要检查距离两端 depth 到 depth+2 的块，以及修改过的块 touched
+func (list *Quicklist) updateCompression(touched ...*quicklistNode) {
+	depth := list.opts.CompressDepth
+	if depth == 0 {
+		return
+	}
+	near := list.nearEnds()
+	for _, node := range near {
+		node.decompress()
+	}
+	candidates := touched
+	for node, i := list.head, 0; node != nil && i < depth+3; node, i = node.nex, i+1 {
+		if i >= depth {
+			candidates = append(candidates, node)
+		}
+	}
+	for node, i := list.tail, 0; node != nil && i < depth+3; node, i = node.pre, i+1 {
+		if i >= depth {
+			candidates = append(candidates, node)
+		}
+	}
+	for _, node := range candidates {
+		if node != nil && node.count > 0 && !slices.Contains(near, node) {
+			node.compress()
+		}
+	}
+}
+
+// pushFront 在头部添加元素
+func (list *Quicklist) pushFront(v string) {
+	n := entrySize(v)
+	if list.head != nil && list.fits(list.head, n) {
+		node := list.head
+		node.decompress()
+		node.data = slices.Insert(node.data, 0, appendEntry(make([]byte, 0, n), v)...)
+		node.count++
+		node.size += n
+	} else {
+		list.linkAfter(&quicklistNode{data: appendEntry(nil, v), count: 1, size: n}, nil)
+		list.updateCompression()
+	}
+	list.len++
+}
+
+// pushBack 在尾部添加元素
+func (list *Quicklist) pushBack(v string) {
+	n := entrySize(v)
+	if list.tail != nil && list.fits(list.tail, n) {
+		node := list.tail
+		node.decompress()
+		node.data = appendEntry(node.data, v)
+		node.count++
+		node.size += n
+	} else {
+		list.linkAfter(&quicklistNode{data: appendEntry(nil, v), count: 1, size: n}, list.tail)
+		list.updateCompression()
+	}
+	list.len++
+}
+
+// removeEntries 删除块中 [start, end) 字节之间的 count 个元素，块为空时删除块
+func (list *Quicklist) removeEntries(node *quicklistNode, start, end, count int) {
+	node.data = slices.Delete(node.data, start, end)
+	node.count -= count
+	node.size -= end - start
+	list.len -= count
+	if node.count == 0 {
+		list.unlinkNode(node)
+		list.updateCompression()
+	}
+}
+
+// locate 返回下标 i 所在的块以及 i 在块中的下标，i 必须在 [0, len) 之间，从距离较近的一端开始查找
+func (list *Quicklist) locate(i int) (*quicklistNode, int) {
+	if i < list.len/2 {
+		node := list.head
+		for i >= node.count {
+			i -= node.count
+			node = node.nex
+		}
+		return node, i
+	}
+	i = list.len - 1 - i
+	node := list.tail
+	for i >= node.count {
+		i -= node.count
+		node = node.pre
+	}
+	return node, node.count - 1 - i
+}
+
+// Len 返回元素数量
+func (list *Quicklist) Len() int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	return list.len
+}
+
+// PushFront 在头部添加元素
+func (list *Quicklist) PushFront(v string) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	list.pushFront(v)
+}
+
+// PushBack 在尾部添加元素
+func (list *Quicklist) PushBack(v string) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	list.pushBack(v)
+}
+
+// LPush 依次在头部添加元素，返回添加后的长度
+func (list *Quicklist) LPush(vs ...string) int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	for _, v := range vs {
+		list.pushFront(v)
+	}
+	return list.len
+}
+
+// RPush 依次在尾部添加元素，返回添加后的长度
+func (list *Quicklist) RPush(vs ...string) int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	for _, v := range vs {
+		list.pushBack(v)
+	}
+	return list.len
+}
+
+// LPop 弹出头部元素，列表为空时返回 false
+func (list *Quicklist) LPop() (string, bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	node := list.head
+	if node == nil {
+		return "", false
+	}
+	node.decompress()
+	v, end := readEntry(node.data, 0)
+	list.removeEntries(node, 0, end, 1)
+	return v, true
+}
+
+// RPop 弹出尾部元素，列表为空时返回 false
+func (list *Quicklist) RPop() (string, bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	node := list.tail
+	if node == nil {
+		return "", false
+	}
+	node.decompress()
+	v, start := readEntryBack(node.data, len(node.data))
+	list.removeEntries(node, start, len(node.data), 1)
+	return v, true
+}
+
+// index 把可能为负数的下标转换为 [0, len) 之间的下标，超出范围时返回 false
+func (list *Quicklist) index(i int) (int, bool) {
+	if i < 0 {
+		i += list.len
+	}
+	return i, i >= 0 && i < list.len
+}
+
+// bounds 把 LRange、LTrim 的 [start, stop] 截断到列表的范围内，结果为空时返回 false
+func (list *Quicklist) bounds(start, stop int) (int, int, bool) {
+	if start < 0 {
+		start += list.len
+	}
+	if stop < 0 {
+		stop += list.len
+	}
+	start = max(start, 0)
+	stop = min(stop, list.len-1)
+	return start, stop, start <= stop
+}
+
+// LIndex 返回下标为 i 的元素，下标超出范围时返回 false
+func (list *Quicklist) LIndex(i int) (string, bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	i, ok := list.index(i)
+	if !ok {
+		return "", false
+	}
+	node, i := list.locate(i)
+	data := node.raw()
+	off := 0
+	for ; i > 0; i-- {
+		_, off = readEntry(data, off)
+	}
+	v, _ := readEntry(data, off)
+	return v, true
+}
+
+// LSet 设置下标为 i 的元素，下标超出范围时返回 ErrIndexOutOfRange
+// 块因此超过大小时分成两半
+func (list *Quicklist) LSet(i int, v string) error {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	i, ok := list.index(i)
+	if !ok {
+		return ErrIndexOutOfRange
+	}
+	node, i := list.locate(i)
+	vs := node.values()
+	vs[i] = v
+	node.decompress()
+	node.setValues(vs)
+	if node.count > 1 && !list.fits(node, 0) {
+		list.split(node, vs, node.count/2)
+		return nil
+	}
+	list.updateCompression(node)
+	return nil
+}
+
+// split 把块从第 at 个元素开始分成两个块，vs 为块中的所有元素
+func (list *Quicklist) split(node *quicklistNode, vs []string, at int) {
+	right := new(quicklistNode)
+	right.setValues(vs[at:])
+	node.setValues(vs[:at])
+	list.linkAfter(right, node)
+	list.updateCompression(node, right)
+}
+
+// LRange 返回下标在 [start, stop] 之间的元素
+func (list *Quicklist) LRange(start, stop int) []string {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	start, stop, ok := list.bounds(start, stop)
+	if !ok {
+		return []string{}
+	}
+	vs := make([]string, 0, stop-start+1)
+	node, i := list.locate(start)
+	for ; len(vs) < cap(vs); node, i = node.nex, 0 {
+		data := node.raw()
+		off := 0
+		for j := 0; j < i; j++ {
+			_, off = readEntry(data, off)
+		}
+		for off < len(data) && len(vs) < cap(vs) {
+			var v string
+			v, off = readEntry(data, off)
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// LTrim 只保留下标在 [start, stop] 之间的元素，范围为空时清空列表
+func (list *Quicklist) LTrim(start, stop int) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	start, stop, ok := list.bounds(start, stop)
+	if !ok {
+		list.head, list.tail, list.len, list.chunks = nil, nil, 0, 0
+		return
+	}
+	// 尾部多余的元素，整块的直接删除
+	for n := list.len - 1 - stop; n > 0; {
+		node := list.tail
+		if node.count <= n {
+			n -= node.count
+			list.len -= node.count
+			list.unlinkNode(node)
+			continue
+		}
+		node.decompress()
+		vs := node.values()
+		node.setValues(vs[:node.count-n])
+		list.len -= n
+		n = 0
+	}
+	// 头部多余的元素
+	for n := start; n > 0; {
+		node := list.head
+		if node.count <= n {
+			n -= node.count
+			list.len -= node.count
+			list.unlinkNode(node)
+			continue
+		}
+		node.decompress()
+		vs := node.values()
+		node.setValues(vs[n:])
+		list.len -= n
+		n = 0
+	}
+	list.updateCompression()
+}
+
+// LInsert 在第一个等于 pivot 的元素之前或之后插入 value，返回插入后的长度
+// 找不到 pivot 时返回 -1，列表为空时返回 0；块已满时从插入的位置分成两个块
+func (list *Quicklist) LInsert(where Position, pivot, value string) int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	if list.len == 0 {
+		return 0
+	}
+	for node := list.head; node != nil; node = node.nex {
+		vs := node.values()
+		i := slices.Index(vs, pivot)
+		if i < 0 {
+			continue
+		}
+		if where == After {
+			i++
+		}
+		node.decompress()
+		vs = slices.Insert(vs, i, value)
+		node.setValues(vs)
+		list.len++
+		if node.count > 1 && !list.fits(node, 0) {
+			// 插入在块的一端时把新元素单独分出去，否则从插入的位置分开
+			at := i
+			if i == 0 {
+				at = 1
+			}
+			list.split(node, vs, at)
+		} else {
+			list.updateCompression(node)
+		}
+		return list.len
+	}
+	return -1
+}
+
+// LRem 删除等于 value 的元素，返回删除的数量
+// count>0 从头部开始删除 count 个，count<0 从尾部开始删除 -count 个，count=0 删除全部
+func (list *Quicklist) LRem(count int, value string) int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	fromTail := count < 0
+	if fromTail {
+		count = -count
+	}
+	removed := 0
+	var shrunk []*quicklistNode // 删除了部分元素的块
+	node := list.head
+	if fromTail {
+		node = list.tail
+	}
+	for node != nil && (count == 0 || removed < count) {
+		next := node.nex
+		if fromTail {
+			next = node.pre
+		}
+		vs := node.values()
+		kept := make([]string, 0, len(vs))
+		for j := range vs {
+			// 从尾部删除时从块的末尾开始
+			k := j
+			if fromTail {
+				k = len(vs) - 1 - j
+			}
+			if vs[k] == value && (count == 0 || removed < count) {
+				removed++
+				continue
+			}
+			kept = append(kept, vs[k])
+		}
+		if len(kept) < len(vs) {
+			if fromTail {
+				slices.Reverse(kept)
+			}
+			list.len -= len(vs) - len(kept)
+			if len(kept) == 0 {
+				list.unlinkNode(node)
+				list.updateCompression()
+			} else {
+				node.decompress()
+				node.setValues(kept)
+				shrunk = append(shrunk, node)
+			}
+		}
+		node = next
+	}
+	// 删除后块变小，尝试与相邻的块合并，避免产生大量很小的块
+	for i, node := range shrunk {
+		if node.pre == nil && list.head != node {
+			// 已经被合并到其他块中
+			shrunk[i] = nil
+			continue
+		}
+		if pre := node.pre; pre != nil && list.mergeNext(pre) {
+			node = pre
+			shrunk[i] = node
+		}
+		list.mergeNext(node)
+	}
+	list.updateCompression(shrunk...)
+	return removed
+}
+
+// mergeNext 合并后的块不超过大小时，把 node 的下一个块合并到 node 中
+func (list *Quicklist) mergeNext(node *quicklistNode) bool {
+	next := node.nex
+	if next == nil {
+		return false
+	}
+	if list.opts.MaxChunkEntries > 0 && node.count+next.count > list.opts.MaxChunkEntries {
+		return false
+	}
+	if node.size+next.size > list.maxChunkBytes() {
+		return false
+	}
+	node.decompress()
+	next.decompress()
+	node.data = append(node.data, next.data...)
+	node.count += next.count
+	node.size += next.size
+	list.unlinkNode(next)
+	return true
+}
+
+// LPos 返回第一个等于 value 的元素的下标，找不到时返回 false
+func (list *Quicklist) LPos(value string) (int, bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	base := 0
+	for node := list.head; node != nil; node = node.nex {
+		if i := slices.Index(node.values(), value); i >= 0 {
+			return base + i, true
+		}
+		base += node.count
+	}
+	return -1, false
+}
+
+// QuicklistStats 快速列表的内存使用情况
+type QuicklistStats struct {
+	Len              int // 元素数量
+	Chunks           int // 块数量
+	CompressedChunks int // 压缩的块数量
+	RawBytes         int // 所有块未压缩的字节数
+	DataBytes        int // 所有块实际分配的字节数（切片的容量）
+	MemoryBytes      int // 估计的总内存：块的结构体、块的数据以及列表本身
+}
+
+// Stats 返回内存使用情况
+func (list *Quicklist) Stats() QuicklistStats {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	stats := QuicklistStats{Len: list.len, Chunks: list.chunks}
+	for node := list.head; node != nil; node = node.nex {
+		if node.compressed {
+			stats.CompressedChunks++
+		}
+		stats.RawBytes += node.size
+		stats.DataBytes += cap(node.data)
+	}
+	stats.MemoryBytes = int(unsafe.Sizeof(*list)) + list.chunks*int(unsafe.Sizeof(quicklistNode{})) + stats.DataBytes
+	return stats
+}
@@ -0,0 +1,150 @@
+package deque
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkQuicklist 检查块的结构：元素和块的数量、每个块的大小、两端的块没有压缩
+func checkQuicklist(t *testing.T, list *Quicklist) {
+	t.Helper()
+	count, chunks := 0, 0
+	var pre *quicklistNode
+	for node := list.head; node != nil; pre, node = node, node.nex {
+		if node.pre != pre {
+			t.Fatalf("chunk %d: broken pre link", chunks)
+		}
+		if node.count == 0 {
+			t.Fatalf("chunk %d is empty", chunks)
+		}
+		if data := node.raw(); len(data) != node.size || len(node.values()) != node.count {
+			t.Fatalf("chunk %d: size %d, count %d, decoded %d bytes %d values", chunks, node.size, node.count, len(data), len(node.values()))
+		}
+		count += node.count
+		chunks++
+	}
+	if pre != list.tail || count != list.len || chunks != list.chunks {
+		t.Fatalf("len %d, chunks %d, counted %d, %d", list.len, list.chunks, count, chunks)
+	}
+	for _, node := range list.nearEnds() {
+		if node.compressed {
+			t.Fatalf("chunk near the ends is compressed")
+		}
+	}
+}
+
+// 与 DoubleList 对比随机操作的结果
+func TestQuicklistRandomOps(t *testing.T) {
+	options := []QuicklistOptions{
+		{},
+		{MaxChunkBytes: 64},
+		{MaxChunkEntries: 3},
+		{MaxChunkBytes: 128, CompressDepth: 1},
+		{MaxChunkEntries: 8, CompressDepth: 2},
+	}
+	for _, opts := range options {
+		t.Run(fmt.Sprintf("%+v", opts), func(t *testing.T) {
+			list := NewQuicklistWithOptions(opts)
+			var ref DoubleList[string]
+			r := rand.New(rand.NewSource(1))
+			// 重复的内容便于压缩，偶尔出现超过块大小的元素
+			value := func() string {
+				if r.Intn(50) == 0 {
+					return strings.Repeat("big", 100)
+				}
+				return strings.Repeat(string(rune('a'+r.Intn(4))), 1+r.Intn(20))
+			}
+			for op := 0; op < 10000; op++ {
+				switch r.Intn(12) {
+				case 0, 1, 10:
+					v := value()
+					list.LPush(v)
+					ref.LPush(v)
+				case 2, 3, 11:
+					v := value()
+					list.RPush(v)
+					ref.RPush(v)
+				case 4:
+					v, ok := list.LPop()
+					want, wantOk := ref.LPop()
+					if v != want || ok != wantOk {
+						t.Fatalf("LPop() = %q, %v, want %q, %v", v, ok, want, wantOk)
+					}
+				case 5:
+					v, ok := list.RPop()
+					want, wantOk := ref.RPop()
+					if v != want || ok != wantOk {
+						t.Fatalf("RPop() = %q, %v, want %q, %v", v, ok, want, wantOk)
+					}
+				case 6:
+					i, v := r.Intn(2*ref.Len()+1)-ref.Len(), value()
+					if err, want := list.LSet(i, v), ref.LSet(i, v); err != want {
+						t.Fatalf("LSet(%d) = %v, want %v", i, err, want)
+					}
+				case 7:
+					pivot, v := value(), value()
+					where := Position(r.Intn(2))
+					if n, want := list.LInsert(where, pivot, v), LInsert(&ref, where, pivot, v); n != want {
+						t.Fatalf("LInsert = %d, want %d", n, want)
+					}
+				case 8:
+					count, v := r.Intn(5)-2, value()
+					if n, want := list.LRem(count, v), LRem(&ref, count, v); n != want {
+						t.Fatalf("LRem(%d) = %d, want %d", count, n, want)
+					}
+				case 9:
+					if r.Intn(10) == 0 {
+						start, stop := r.Intn(5)-1, -1-r.Intn(5)
+						list.LTrim(start, stop)
+						ref.LTrim(start, stop)
+					} else {
+						v := value()
+						i, ok := list.LPos(v)
+						want, wantOk := LPos(&ref, v)
+						if i != want || ok != wantOk {
+							t.Fatalf("LPos(%q) = %d, %v, want %d, %v", v, i, ok, want, wantOk)
+						}
+					}
+				}
+				checkQuicklist(t, list)
+				if op%50 == 0 {
+					start, stop := r.Intn(20)-10, r.Intn(20)-10
+					if got, want := list.LRange(start, stop), ref.LRange(start, stop); !reflect.DeepEqual(got, want) {
+						t.Fatalf("LRange(%d, %d) = %v, want %v", start, stop, got, want)
+					}
+					i := r.Intn(2*ref.Len()+1) - ref.Len()
+					v, ok := list.LIndex(i)
+					want, wantOk := ref.LIndex(i)
+					if v != want || ok != wantOk {
+						t.Fatalf("LIndex(%d) = %q, %v, want %q, %v", i, v, ok, want, wantOk)
+					}
+				}
+			}
+			if got, want := list.LRange(0, -1), ref.LRange(0, -1); !reflect.DeepEqual(got, want) {
+				t.Fatalf("LRange(0, -1) mismatch")
+			}
+		})
+	}
+}
+
+// 中间的块被压缩
+func TestQuicklistCompression(t *testing.T) {
+	list := NewQuicklistWithOptions(QuicklistOptions{MaxChunkBytes: 1024, CompressDepth: 1})
+	for i := 0; i < 10000; i++ {
+		list.RPush(fmt.Sprintf("user:%06d", i))
+	}
+	stats := list.Stats()
+	if stats.CompressedChunks != stats.Chunks-2 {
+		t.Fatalf("%d of %d chunks compressed, want %d", stats.CompressedChunks, stats.Chunks, stats.Chunks-2)
+	}
+	if stats.DataBytes >= stats.RawBytes {
+		t.Fatalf("compressed %d bytes, raw %d bytes", stats.DataBytes, stats.RawBytes)
+	}
+	if v, _ := list.LIndex(5000); v != "user:005000" {
+		t.Fatalf("LIndex(5000) = %q", v)
+	}
+	checkQuicklist(t, list)
+}
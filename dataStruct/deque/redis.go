@@ -0,0 +1,328 @@
+package deque
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Redis 列表命令
+/*
+Redis 的列表命令在双端链表上的实现，语义与 Redis 相同：
+	LPush/RPush          在头部/尾部依次添加，LPUSH a b c 之后列表为 c b a
+	LPop/RPop            弹出头部/尾部
+	LIndex/LSet          按下标获取/设置
+	LRange/LTrim         获取/保留 [start, stop] 之间的元素，包括 stop
+	LInsert              在第一个等于 pivot 的元素之前或之后插入
+	LRem                 删除等于 value 的元素，count>0 从头部开始删除 count 个，count<0 从尾部开始，count=0 全部删除
+	LPos                 查找等于 value 的元素的下标
+	RPopLPush            弹出一个列表的尾部并添加到另一个列表的头部，两个列表相同时相当于旋转
+
+下标从 0 开始，负数表示从尾部开始，-1 为最后一个元素，-2 为倒数第二个，以此类推。
+LRange、LTrim 的下标超出范围时不会出错，而是截断到列表的范围内，start 大于 stop 时结果为空。
+
+按下标定位节点时从距离较近的一端开始遍历，时间复杂度为 O(min(i, n-i))。
+LInsert、LRem、LPos 需要比较元素，而方法不能给类型参数增加 comparable 约束，
+所以它们是以列表为第一个参数的函数，元素类型必须是 comparable。
+*/
+
+var (
+	// ErrIndexOutOfRange 下标超出范围
+	ErrIndexOutOfRange = errors.New("deque: index out of range")
+	// ErrNegativeOption LPos 的 Count 或 MaxLen 为负数
+	ErrNegativeOption = errors.New("deque: COUNT and MAXLEN can't be negative")
+)
+
+// Position LInsert 插入的位置
+type Position int
+
+const (
+	Before Position = iota // 在 pivot 之前插入
+	After                  // 在 pivot 之后插入
+)
+
+// nodeAt 返回下标为 i 的节点，i 必须在 [0, len) 之间，从距离较近的一端开始遍历
+func (list *DoubleList[T]) nodeAt(i int) *ListNode[T] {
+	if i < list.len/2 {
+		node := list.head
+		for ; i > 0; i-- {
+			node = node.nex
+		}
+		return node
+	}
+	node := list.tail
+	for i = list.len - 1 - i; i > 0; i-- {
+		node = node.pre
+	}
+	return node
+}
+
+// index 把可能为负数的下标转换为 [0, len) 之间的下标，超出范围时返回 false
+func (list *DoubleList[T]) index(i int) (int, bool) {
+	if i < 0 {
+		i += list.len
+	}
+	return i, i >= 0 && i < list.len
+}
+
+// bounds 把 LRange、LTrim 的 [start, stop] 截断到列表的范围内，结果为空时返回 false
+func (list *DoubleList[T]) bounds(start, stop int) (int, int, bool) {
+	if start < 0 {
+		start += list.len
+	}
+	if stop < 0 {
+		stop += list.len
+	}
+	start = max(start, 0)
+	stop = min(stop, list.len-1)
+	return start, stop, start <= stop
+}
+
+// LPush 依次在头部添加元素，返回添加后的长度
+func (list *DoubleList[T]) LPush(vs ...T) int {
+	list.lock.Lock()
+	defer list.unlock()
+	for _, v := range vs {
+		list.insertBetween(&ListNode[T]{value: v}, nil, list.head)
+	}
+	return list.len
+}
+
+// RPush 依次在尾部添加元素，返回添加后的长度
+func (list *DoubleList[T]) RPush(vs ...T) int {
+	list.lock.Lock()
+	defer list.unlock()
+	for _, v := range vs {
+		list.insertBetween(&ListNode[T]{value: v}, list.tail, nil)
+	}
+	return list.len
+}
+
+// LPop 弹出头部元素，列表为空时返回 false
+func (list *DoubleList[T]) LPop() (v T, ok bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	return list.pop(list.head)
+}
+
+// RPop 弹出尾部元素，列表为空时返回 false
+func (list *DoubleList[T]) RPop() (v T, ok bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	return list.pop(list.tail)
+}
+
+// pop 删除节点并返回它的值，节点为空时返回 false
+func (list *DoubleList[T]) pop(node *ListNode[T]) (v T, ok bool) {
+	if node.IsNil() {
+		return
+	}
+	list.unlink(node)
+	return node.value, true
+}
+
+// LIndex 返回下标为 i 的元素，下标超出范围时返回 false
+func (list *DoubleList[T]) LIndex(i int) (v T, ok bool) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	i, ok = list.index(i)
+	if !ok {
+		return
+	}
+	return list.nodeAt(i).value, true
+}
+
+// LSet 设置下标为 i 的元素，下标超出范围时返回 ErrIndexOutOfRange
+func (list *DoubleList[T]) LSet(i int, v T) error {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	i, ok := list.index(i)
+	if !ok {
+		return ErrIndexOutOfRange
+	}
+	list.nodeAt(i).value = v
+	return nil
+}
+
+// LRange 返回下标在 [start, stop] 之间的元素
+func (list *DoubleList[T]) LRange(start, stop int) []T {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	start, stop, ok := list.bounds(start, stop)
+	if !ok {
+		return []T{}
+	}
+	vs := make([]T, 0, stop-start+1)
+	for node := list.nodeAt(start); len(vs) < cap(vs); node = node.nex {
+		vs = append(vs, node.value)
+	}
+	return vs
+}
+
+// LTrim 只保留下标在 [start, stop] 之间的元素，范围为空时清空列表
+func (list *DoubleList[T]) LTrim(start, stop int) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	start, stop, ok := list.bounds(start, stop)
+	if !ok {
+		for list.len > 0 {
+			list.unlink(list.head)
+		}
+		return
+	}
+	// 先删除尾部多余的，再删除头部多余的
+	for i := list.len - 1; i > stop; i-- {
+		list.unlink(list.tail)
+	}
+	for i := 0; i < start; i++ {
+		list.unlink(list.head)
+	}
+}
+
+// RPopLPush 弹出 src 的尾部元素并添加到 dst 的头部，src 为空时返回 false
+// src 和 dst 相同时把尾部元素移到头部；节点直接移动，不会重新分配
+// src 和 dst 不同时同时持有两个列表的锁，移动是原子的，其他操作不会看到元素不在任何一个列表中
+func RPopLPush[T any](src, dst *DoubleList[T]) (v T, ok bool) {
+	if src == dst {
+		src.lock.Lock()
+		defer src.lock.Unlock()
+		node := src.tail
+		if node.IsNil() {
+			return
+		}
+		src.unlink(node)
+		src.insertBetween(node, nil, src.head)
+		return node.value, true
+	}
+	lockBoth(src, dst)
+	defer src.lock.Unlock()
+	defer dst.unlock()
+	node := src.tail
+	if node.IsNil() {
+		return
+	}
+	src.unlink(node)
+	dst.insertBetween(node, nil, dst.head)
+	return node.value, true
+}
+
+// lockBoth 给两个不同的列表加锁，按地址从小到大的顺序，
+// 两个方向相反的 RPopLPush 同时执行时也不会互相等待对方的锁
+func lockBoth[T any](a, b *DoubleList[T]) {
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		a, b = b, a
+	}
+	a.lock.Lock()
+	b.lock.Lock()
+}
+
+// LInsert 在第一个等于 pivot 的元素之前或之后插入 value，返回插入后的长度
+// 找不到 pivot 时返回 -1，列表为空时返回 0
+func LInsert[T comparable](list *DoubleList[T], where Position, pivot, value T) int {
+	list.lock.Lock()
+	defer list.unlock()
+	if list.len == 0 {
+		return 0
+	}
+	for node := list.head; !node.IsNil(); node = node.nex {
+		if node.value != pivot {
+			continue
+		}
+		if where == Before {
+			list.insertBetween(&ListNode[T]{value: value}, node.pre, node)
+		} else {
+			list.insertBetween(&ListNode[T]{value: value}, node, node.nex)
+		}
+		return list.len
+	}
+	return -1
+}
+
+// LRem 删除等于 value 的元素，返回删除的数量
+// count>0 从头部开始删除 count 个，count<0 从尾部开始删除 -count 个，count=0 删除全部
+func LRem[T comparable](list *DoubleList[T], count int, value T) int {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	removed := 0
+	fromTail := count < 0
+	if fromTail {
+		count = -count
+	}
+	node := list.head
+	if fromTail {
+		node = list.tail
+	}
+	for !node.IsNil() && (count == 0 || removed < count) {
+		next := node.nex
+		if fromTail {
+			next = node.pre
+		}
+		if node.value == value {
+			list.unlink(node)
+			removed++
+		}
+		node = next
+	}
+	return removed
+}
+
+// LPosOptions LPos 的选项，与 Redis 的 RANK、COUNT、MAXLEN 对应
+type LPosOptions struct {
+	Rank   int // 返回第 Rank 个匹配开始的结果，负数表示从尾部开始查找，0 视为 1
+	Count  int // 最多返回的数量，0 表示返回全部
+	MaxLen int // 最多比较的元素数量，0 表示不限制
+}
+
+// LPos 返回第一个等于 value 的元素的下标，找不到时返回 false
+func LPos[T comparable](list *DoubleList[T], value T) (int, bool) {
+	pos, _ := LPosWithOptions(list, value, LPosOptions{Count: 1})
+	if len(pos) == 0 {
+		return -1, false
+	}
+	return pos[0], true
+}
+
+// LPosWithOptions 按选项查找等于 value 的元素的下标，下标总是从头部开始计算
+func LPosWithOptions[T comparable](list *DoubleList[T], value T, opts LPosOptions) ([]int, error) {
+	if opts.Count < 0 || opts.MaxLen < 0 {
+		return nil, ErrNegativeOption
+	}
+	rank := opts.Rank
+	if rank == 0 {
+		rank = 1
+	}
+	fromTail := rank < 0
+	if fromTail {
+		rank = -rank
+	}
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	pos := []int{}
+	node := list.head
+	if fromTail {
+		node = list.tail
+	}
+	for i := 0; !node.IsNil() && (opts.MaxLen == 0 || i < opts.MaxLen); i++ {
+		if node.value == value {
+			// 跳过前 rank-1 个匹配
+			if rank > 1 {
+				rank--
+			} else {
+				pos = append(pos, i)
+				if len(pos) == opts.Count {
+					break
+				}
+			}
+		}
+		if fromTail {
+			node = node.pre
+		} else {
+			node = node.nex
+		}
+	}
+	if fromTail {
+		for j := range pos {
+			pos[j] = list.len - 1 - pos[j]
+		}
+	}
+	return pos, nil
+}
@@ -0,0 +1,229 @@
+package deque
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// 以下用例来自 Redis 文档中各命令的示例
+
+func TestLRange(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("one", "two", "three")
+	tests := []struct {
+		start, stop int
+		want        []string
+	}{
+		{0, 0, []string{"one"}},
+		{-3, 2, []string{"one", "two", "three"}},
+		{-100, 100, []string{"one", "two", "three"}},
+		{5, 10, []string{}},
+		{1, -1, []string{"two", "three"}},
+		{2, 1, []string{}},
+	}
+	for _, test := range tests {
+		if got := list.LRange(test.start, test.stop); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("LRange(%d, %d) = %v, want %v", test.start, test.stop, got, test.want)
+		}
+	}
+}
+
+func TestLInsert(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("Hello", "World")
+	if n := LInsert(&list, Before, "World", "There"); n != 3 {
+		t.Errorf("LInsert BEFORE = %d, want 3", n)
+	}
+	if n := LInsert(&list, After, "World", "!"); n != 4 {
+		t.Errorf("LInsert AFTER = %d, want 4", n)
+	}
+	if n := LInsert(&list, Before, "missing", "x"); n != -1 {
+		t.Errorf("LInsert with missing pivot = %d, want -1", n)
+	}
+	if got, want := list.LRange(0, -1), []string{"Hello", "There", "World", "!"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LRange(0, -1) = %v, want %v", got, want)
+	}
+	var empty DoubleList[string]
+	if n := LInsert(&empty, Before, "a", "b"); n != 0 {
+		t.Errorf("LInsert on empty list = %d, want 0", n)
+	}
+}
+
+func TestLRem(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("hello", "hello", "foo", "hello")
+	if n := LRem(&list, -2, "hello"); n != 2 {
+		t.Errorf("LRem(-2) = %d, want 2", n)
+	}
+	if got, want := list.LRange(0, -1), []string{"hello", "foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LRange(0, -1) = %v, want %v", got, want)
+	}
+
+	list.RPush("a", "hello", "b", "hello")
+	if n := LRem(&list, 1, "hello"); n != 1 {
+		t.Errorf("LRem(1) = %d, want 1", n)
+	}
+	if got, want := list.LRange(0, -1), []string{"foo", "a", "hello", "b", "hello"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LRange(0, -1) = %v, want %v", got, want)
+	}
+	if n := LRem(&list, 0, "hello"); n != 2 {
+		t.Errorf("LRem(0) = %d, want 2", n)
+	}
+	if got, want := list.LRange(0, -1), []string{"foo", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LRange(0, -1) = %v, want %v", got, want)
+	}
+}
+
+func TestLTrim(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("one", "two", "three")
+	list.LTrim(1, -1)
+	if got, want := list.LRange(0, -1), []string{"two", "three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LTrim(1, -1): %v, want %v", got, want)
+	}
+	// 范围为空时清空列表
+	list.LTrim(5, 10)
+	if list.Len() != 0 || list.First() != nil || list.Last() != nil {
+		t.Errorf("LTrim(5, 10): Len() = %d, want 0", list.Len())
+	}
+}
+
+func TestLSet(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("one", "two", "three")
+	if err := list.LSet(0, "four"); err != nil {
+		t.Fatal(err)
+	}
+	if err := list.LSet(-2, "five"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list.LRange(0, -1), []string{"four", "five", "three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LRange(0, -1) = %v, want %v", got, want)
+	}
+	if err := list.LSet(3, "six"); err != ErrIndexOutOfRange {
+		t.Errorf("LSet(3) error = %v, want %v", err, ErrIndexOutOfRange)
+	}
+}
+
+func TestLIndex(t *testing.T) {
+	var list DoubleList[string]
+	list.LPush("World")
+	list.LPush("Hello")
+	tests := []struct {
+		i    int
+		want string
+		ok   bool
+	}{
+		{0, "Hello", true},
+		{-1, "World", true},
+		{3, "", false},
+		{-3, "", false},
+	}
+	for _, test := range tests {
+		if got, ok := list.LIndex(test.i); got != test.want || ok != test.ok {
+			t.Errorf("LIndex(%d) = %q, %v, want %q, %v", test.i, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestLPos(t *testing.T) {
+	var list DoubleList[string]
+	list.RPush("a", "b", "c", "d", "1", "2", "3", "4", "3", "3", "3")
+	if i, ok := LPos(&list, "3"); i != 6 || !ok {
+		t.Errorf("LPos(3) = %d, %v, want 6, true", i, ok)
+	}
+	if i, ok := LPos(&list, "x"); i != -1 || ok {
+		t.Errorf("LPos(x) = %d, %v, want -1, false", i, ok)
+	}
+	tests := []struct {
+		value string
+		opts  LPosOptions
+		want  []int
+	}{
+		{"3", LPosOptions{Count: 0, Rank: 2}, []int{8, 9, 10}},
+		{"3", LPosOptions{Rank: -1}, []int{10, 9, 8, 6}},
+		{"3", LPosOptions{Rank: -1, Count: 2}, []int{10, 9}},
+		{"3", LPosOptions{Count: 2}, []int{6, 8}},
+		{"3", LPosOptions{MaxLen: 7}, []int{6}},
+		{"3", LPosOptions{MaxLen: 6}, []int{}},
+		{"3", LPosOptions{Rank: 5}, []int{}},
+	}
+	for _, test := range tests {
+		got, err := LPosWithOptions(&list, test.value, test.opts)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("LPosWithOptions(%s, %+v) = %v, %v, want %v", test.value, test.opts, got, err, test.want)
+		}
+	}
+	if _, err := LPosWithOptions(&list, "3", LPosOptions{Count: -1}); err != ErrNegativeOption {
+		t.Errorf("negative COUNT error = %v, want %v", err, ErrNegativeOption)
+	}
+}
+
+func TestRPopLPush(t *testing.T) {
+	var list, other DoubleList[string]
+	list.RPush("one", "two", "three")
+	if v, ok := RPopLPush(&list, &other); v != "three" || !ok {
+		t.Errorf("RPopLPush = %q, %v, want three, true", v, ok)
+	}
+	if got, want := list.LRange(0, -1), []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("list = %v, want %v", got, want)
+	}
+	if got, want := other.LRange(0, -1), []string{"three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("other = %v, want %v", got, want)
+	}
+	// 同一个列表相当于旋转
+	RPopLPush(&list, &list)
+	if got, want := list.LRange(0, -1), []string{"two", "one"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rotated list = %v, want %v", got, want)
+	}
+	var empty DoubleList[string]
+	if _, ok := RPopLPush(&empty, &list); ok || list.Len() != 2 {
+		t.Errorf("RPopLPush from empty list = %v, Len() = %d", ok, list.Len())
+	}
+}
+
+// 两个列表之间来回移动元素，同时持有两个列表的锁观察，元素总数不变
+func TestRPopLPushAtomic(t *testing.T) {
+	const n, rounds = 8, 10000
+	var a, b DoubleList[int]
+	for i := range n {
+		a.RPush(i)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range rounds {
+			RPopLPush(&a, &b)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range rounds {
+			RPopLPush(&b, &a)
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			if got := a.Len() + b.Len(); got != n {
+				t.Fatalf("Len() = %d, want %d", got, n)
+			}
+			return
+		default:
+		}
+		lockBoth(&a, &b)
+		got := a.len + b.len
+		a.lock.Unlock()
+		b.lock.Unlock()
+		if got != n {
+			t.Fatalf("observed %d elements, want %d", got, n)
+		}
+	}
+}
@@ -1,26 +1,41 @@
-package main
+package linkedlist
 
 import "fmt"
 
 // 双向链表
 
-// Node 定义结点
-type Node struct {
-	Value int
-	Previous *Node
-	Next *Node
+// DoubleNode 定义结点
+type DoubleNode struct {
+	Value    int
+	Previous *DoubleNode
+	Next     *DoubleNode
 }
 
-// 初始化头节点
-var head = new(Node)
+// DoubleLinkList 双向链表，head 为头节点
+type DoubleLinkList struct {
+	head *DoubleNode
+}
+
+// NewDoubleLinkList 初始化空的双向链表
+func NewDoubleLinkList() *DoubleLinkList {
+	return &DoubleLinkList{}
+}
 
-// 添加结点
-func addNode(t *Node, v int) int {
-	if head == nil {
-		t = &Node{v, nil, nil}
-		head = t
+// Head 获取头节点
+func (l *DoubleLinkList) Head() *DoubleNode {
+	return l.head
+}
+
+// AddNode 添加结点，结点已存在返回 -1
+func (l *DoubleLinkList) AddNode(v int) int {
+	if l.head == nil {
+		l.head = &DoubleNode{v, nil, nil}
 		return 0
 	}
+	return l.addNode(l.head, v)
+}
+
+func (l *DoubleLinkList) addNode(t *DoubleNode, v int) int {
 	if v == t.Value {
 		fmt.Println("节点已存在!")
 		return -1
@@ -30,15 +45,16 @@ func addNode(t *Node, v int) int {
 	if t.Next == nil {
 		// 与单链表不通的是每个结点还要维护前驱结点指针
 		temp := t
-		t.Next = &Node{v, temp, nil}
+		t.Next = &DoubleNode{v, temp, nil}
 		return -2
 	}
 	// 如果当前节点下一个结点不为空
-	return addNode(t.Next, v)
+	return l.addNode(t.Next, v)
 }
 
-// 正向遍历链表
-func traverse(t *Node) {
+// Traverse 正向遍历链表
+func (l *DoubleLinkList) Traverse() {
+	t := l.head
 	if t == nil {
 		fmt.Println("-> 空链表!")
 		return
@@ -51,8 +67,9 @@ func traverse(t *Node) {
 	fmt.Println()
 }
 
-// 反向遍历
-func reverse(t *Node) {
+// Reverse 反向遍历
+func (l *DoubleLinkList) Reverse() {
+	t := l.head
 	if t == nil {
 		fmt.Println("-> 空链表！")
 		return
@@ -70,66 +87,21 @@ func reverse(t *Node) {
 	fmt.Println()
 }
 
-// 获取链表的长度
-func size(t *Node) int {
-	if t == nil {
-		fmt.Println("空链表!")
-		return 0
-	}
-
+// Size 获取链表的长度
+func (l *DoubleLinkList) Size() int {
 	n := 0
-	for t != nil {
+	for t := l.head; t != nil; t = t.Next {
 		n++
-		t = t.Next
 	}
 	return n
 }
 
-// 查找节点
-
-func lookupNode(t *Node, v int) bool {
-	if head == nil {
-		return false
-	}
-
-	if v == t.Value {
-		return true
-	}
-
-	if t.Next == nil {
-		return false
-	}
-	return lookupNode(t.Next, v)
-}
-
-func main() {
-	fmt.Println(head)
-	head = nil
-	// 遍历链表
-	traverse(head)
-	// 新增结点
-	addNode(head, 1)
-	// 再次遍历
-	traverse(head)
-	// 继续添加结点
-	addNode(head, 10)
-	addNode(head, 5)
-	addNode(head, 100)
-	// 再次遍历
-	traverse(head)
-	// 添加已存在的结点
-	addNode(head, 100)
-	fmt.Println("链表长度：", size(head))
-	// 再次遍历
-	traverse(head)
-	// 反向遍历
-	reverse(head)
-
-	// 查找已存在节点
-	if lookupNode(head, 200) {
-		fmt.Println("该节点已存在!")
-	} else {
-		fmt.Println("该节点不存在!")
+// LookupNode 查找节点
+func (l *DoubleLinkList) LookupNode(v int) bool {
+	for t := l.head; t != nil; t = t.Next {
+		if v == t.Value {
+			return true
+		}
 	}
+	return false
 }
-
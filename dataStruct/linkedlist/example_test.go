@@ -0,0 +1,94 @@
+package linkedlist_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/linkedlist"
+)
+
+func ExampleSingleLinkList() {
+	l := linkedlist.NewSingleLinkList()
+	// 遍历链表
+	l.Traverse()
+	// 添加结点
+	l.AddNode(1)
+	l.AddNode(2)
+	l.AddNode(121)
+	l.AddNode(5)
+	l.AddNode(45)
+	// 添加已存在结点
+	l.AddNode(5)
+	l.Traverse()
+	fmt.Println(l.Size(), l.LookupNode(5), l.LookupNode(-100))
+	// Output:
+	// -> 空链表!
+	// 结点已存在： 5
+	// 1 ->2 ->121 ->5 ->45 ->
+	// 5 true false
+}
+
+func ExampleDoubleLinkList() {
+	l := linkedlist.NewDoubleLinkList()
+	l.Traverse()
+	l.AddNode(1)
+	l.AddNode(10)
+	l.AddNode(5)
+	l.AddNode(100)
+	// 添加已存在的结点
+	l.AddNode(100)
+	fmt.Println("链表长度：", l.Size())
+	l.Traverse()
+	// 反向遍历
+	l.Reverse()
+	fmt.Println(l.LookupNode(200))
+	// Output:
+	// -> 空链表!
+	// 节点已存在!
+	// 链表长度： 4
+	// 1 ->10 ->5 ->100 ->
+	// 100 ->5 ->10 ->1 ->
+	// false
+}
+
+func ExampleReverseList() {
+	head := &linkedlist.ListNode{Value: 1}
+	for i := 2; i <= 6; i++ {
+		linkedlist.AddListNode(head, i)
+	}
+	linkedlist.Traverse(head)
+	linkedlist.Traverse(linkedlist.ReverseList(head))
+	// Output:
+	// 1 ->2 ->3 ->4 ->5 ->6 ->
+	// 6 ->5 ->4 ->3 ->2 ->1 ->
+}
+
+func ExampleDetectCycle() {
+	head := &linkedlist.ListNode{Value: 1}
+	for i := 2; i <= 5; i++ {
+		linkedlist.AddListNode(head, i)
+	}
+	fmt.Println(linkedlist.IsCycle(head), linkedlist.DetectCycle(head))
+	// 尾结点指向第 3 个结点，形成环
+	tail := head
+	for tail.Next != nil {
+		tail = tail.Next
+	}
+	tail.Next = head.Next.Next
+	fmt.Println(linkedlist.IsCycle(head), linkedlist.DetectCycle(head).Value)
+	// Output:
+	// false <nil>
+	// true 3
+}
+
+func ExampleNew() {
+	r := linkedlist.New(5)
+	// 给每个节点赋值
+	for i := 0; i < 5; i++ {
+		r.Value = i
+		r = r.Next()
+	}
+	// 环形链表只有 5 个有值节点加上头节点
+	fmt.Println(r.Value, r.Move(3).Value, r.Move(-1).Value)
+	// Output:
+	// 0 2 4
+}
@@ -1,25 +1,26 @@
-package main
+package linkedlist
 
 import "fmt"
 
-// 快慢指针
-
+// ListNode 定义链表结点，快慢指针与链表翻转共用
 type ListNode struct {
 	Value int
-	Next *ListNode
+	Next  *ListNode
 }
 
-// 判断是否有环,true有，false无
-func isCycle(t *ListNode) bool {
+// 快慢指针
+
+// IsCycle 判断是否有环,true有，false无
+func IsCycle(t *ListNode) bool {
 	// 如果为空或者只有一个结点，肯定无环
 	if t == nil || t.Next == nil {
 		return false
 	}
-	slow := t // 慢指针
+	slow := t      // 慢指针
 	fast := t.Next // 快指针
 	// 不重合执行循环
-	for slow != fast{
-		if fast == nil || fast.Next == nil {//到链表尾部无环
+	for slow != fast {
+		if fast == nil || fast.Next == nil { //到链表尾部无环
 			return false
 		}
 		// 慢指针走一步，快指针走两边，为什么要1、2,因为这样时间复杂度最短
@@ -30,21 +31,21 @@ func isCycle(t *ListNode) bool {
 	return true
 }
 
-// 有环，判断首次相遇时，slow的位置
-func detectCycle(t *ListNode) *ListNode {
+// DetectCycle 有环，返回环的入口结点，无环返回 nil
+func DetectCycle(t *ListNode) *ListNode {
 	fast, slow := t, t
 	// 获取首次相遇时，slow的位置
-	for fast != nil || fast.Next != nil {
+	for {
+		// 如果快指针走到尽头没环
+		if fast == nil || fast.Next == nil {
+			return nil
+		}
 		fast = fast.Next.Next
 		slow = slow.Next
 		// 如果相等保留当前位置
 		if slow == fast {
 			break
 		}
-		// 如果快指针走到尽头没环
-		if fast == nil || fast.Next == nil {
-			return nil
-		}
 	}
 	// 快指针重新出发，相遇位置就是入口位置
 	fast = t
@@ -55,10 +56,9 @@ func detectCycle(t *ListNode) *ListNode {
 	return slow
 }
 
-// 链表新增
-func addListNode(t *ListNode, v int) int {
+// AddListNode 链表新增，t 不能为空
+func AddListNode(t *ListNode, v int) int {
 	if t == nil {
-		t = &ListNode{v, nil}
 		return 0
 	}
 
@@ -71,12 +71,12 @@ func addListNode(t *ListNode, v int) int {
 		t.Next = &ListNode{v, nil}
 		return -2
 	}
-	return addListNode(t.Next, v)
+	return AddListNode(t.Next, v)
 
 }
 
-// traverse 链表遍历
-func traverse(t *ListNode) {
+// Traverse 链表遍历
+func Traverse(t *ListNode) {
 	if t == nil {
 		fmt.Println("-> 空链表!")
 		return
@@ -87,16 +87,3 @@ func traverse(t *ListNode) {
 	}
 	fmt.Println()
 }
-
-func main() {
-	var head = new(ListNode)
-	fmt.Println(head)
-	traverse(head)
-	addListNode(head, 1)
-	addListNode(head,2)
-	addListNode(head, 3)
-	traverse(head)
-	ok := isCycle(head)
-	slow := detectCycle(head)
-	fmt.Println(ok, slow)
-}
\ No newline at end of file
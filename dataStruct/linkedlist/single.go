@@ -1,26 +1,40 @@
-package main
+package linkedlist
 
 import "fmt"
 
 // 单向链表
 
-// Node 定义结点
-type Node struct{
+// SingleNode 定义结点
+type SingleNode struct {
 	Value int
-	Next *Node
+	Next  *SingleNode
 }
 
-// 初始化头节点
-var head = new(Node)
+// SingleLinkList 单向链表，head 为头节点
+type SingleLinkList struct {
+	head *SingleNode
+}
+
+// NewSingleLinkList 初始化空的单向链表
+func NewSingleLinkList() *SingleLinkList {
+	return &SingleLinkList{}
+}
+
+// Head 获取头节点
+func (l *SingleLinkList) Head() *SingleNode {
+	return l.head
+}
 
-// 添加结点
-func addNode(t *Node, v int) int {
-	if head == nil {
-		t = &Node{v, nil}
-		head = t
+// AddNode 添加结点，结点已存在返回 -1
+func (l *SingleLinkList) AddNode(v int) int {
+	if l.head == nil {
+		l.head = &SingleNode{v, nil}
 		return 0
 	}
+	return l.addNode(l.head, v)
+}
 
+func (l *SingleLinkList) addNode(t *SingleNode, v int) int {
 	if v == t.Value {
 		fmt.Println("结点已存在：", v)
 		return -1
@@ -28,15 +42,16 @@ func addNode(t *Node, v int) int {
 
 	// 如果当前节点下一个结点为空
 	if t.Next == nil {
-		t.Next = &Node{v, nil}
+		t.Next = &SingleNode{v, nil}
 		return -2
 	}
 	// 如果当前节点的下一个结点不为空
-	return addNode(t.Next, v)
+	return l.addNode(t.Next, v)
 }
 
-// 遍历链表
-func traverse(t *Node) {
+// Traverse 遍历链表
+func (l *SingleLinkList) Traverse() {
+	t := l.head
 	if t == nil {
 		fmt.Println("-> 空链表!")
 		return
@@ -48,75 +63,21 @@ func traverse(t *Node) {
 	fmt.Println()
 }
 
-// 查找结点
-func lookupNode(t *Node, v int) bool {
-	if head == nil {
-		t = &Node{v, nil}
-		head = t
-		return false
+// LookupNode 查找结点
+func (l *SingleLinkList) LookupNode(v int) bool {
+	for t := l.head; t != nil; t = t.Next {
+		if v == t.Value {
+			return true
+		}
 	}
-
-	if v == t.Value {
-		return true
-	}
-
-	if t.Next == nil {
-		return false
-	}
-
-	return lookupNode(t.Next, v)
+	return false
 }
 
-// 获取链表长度
-func size(t *Node) int {
-	if t == nil {
-		fmt.Println("-> 空链表!")
-		return 0
-	}
-
+// Size 获取链表长度
+func (l *SingleLinkList) Size() int {
 	i := 0
-	for t != nil {
+	for t := l.head; t != nil; t = t.Next {
 		i++
-		t = t.Next
 	}
 	return i
 }
-
-// 入口函数
-func main() {
-	fmt.Println(head)
-	head = nil
-	// 遍历链表
-	traverse(head)
-	// 添加结点
-	addNode(head,1)
-	addNode(head,2)
-	// 再次遍历
-	traverse(head)
-	// 添加更多结点
-	addNode(head,121)
-	addNode(head, 5)
-	addNode(head, 45)
-	// 添加已存在结点
-	addNode(head, 5)
-	// 再次遍历
-	traverse(head)
-
-	// 查找已存在结点
-	if lookupNode(head, 5) {
-		fmt.Println("该节点已存在")
-	}else {
-		fmt.Println("该节点不存在")
-	}
-
-	// 查找不存在结点
-	if lookupNode(head, -100) {
-		fmt.Println("该节点已存在")
-	}else {
-		fmt.Println("该节点不存在")
-	}
-
-}
-
-
-
@@ -1,4 +1,4 @@
-package main
+package queue
 
 import "sync"
 
@@ -8,8 +8,8 @@ import "sync"
 这里只实现入队，和出队操作，其他操作和栈一样
 */
 
-// 数组队列，先进先出
-type arrayQueue struct {
+// ArrayQueue 数组队列，先进先出
+type ArrayQueue struct {
 	array []string  // 底层切片
 	size int		// 队列的元素数量
 	lock sync.Mutex	// 为了并发安全使用的锁
@@ -17,7 +17,7 @@ type arrayQueue struct {
 
 // Push 入队
 // 直接将元素放在数组最后面即可，和栈一样，时间复杂度为：O(n)
-func (queue *arrayQueue) Push(v string) {
+func (queue *ArrayQueue) Push(v string) {
 	queue.lock.Lock()
 	defer queue.lock.Unlock()
 	// 放入切片中，后进的元素放在数组最后面
@@ -26,8 +26,8 @@ func (queue *arrayQueue) Push(v string) {
 	queue.size = queue.size + 1
 }
 
-// 出队
-func (queue *arrayQueue) Remove() string {
+// Remove 出队
+func (queue *ArrayQueue) Remove() string {
 	queue.lock.Lock()
 	defer queue.lock.Unlock()
 	// 队中元素已空
@@ -38,7 +38,7 @@ func (queue *arrayQueue) Remove() string {
 	v := queue.array[0]
 	// 两种方法
 	// 1、 原数组缩容，但缩容后继的空间不会被释放
-	queue.array = queue.array[1:]
+	// queue.array = queue.array[1:]
 	// 2、 创建新的数组，移动次数过多
 	newArray := make([]string, queue.size-1, queue.size-1)
 	for i := 1; i < queue.size; i++ {
@@ -51,6 +51,17 @@ func (queue *arrayQueue) Remove() string {
 	queue.size = queue.size - 1
 	return v
 }
+
+// Size 队列大小
+func (queue *ArrayQueue) Size() int {
+	return queue.size
+}
+
+// IsEmpty 队列是否为空
+func (queue *ArrayQueue) IsEmpty() bool {
+	return queue.size == 0
+}
+
 /*
 出队，把数组的第一个元素的值返回，并对数据进行空间挪位
 挪位有两种：
@@ -0,0 +1,76 @@
+package queue_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/queue"
+)
+
+func ExampleArrayQueue() {
+	q := new(queue.ArrayQueue)
+	q.Push("cat")
+	q.Push("dog")
+	q.Push("hen")
+	fmt.Println("size:", q.Size())
+	fmt.Println("pop:", q.Remove())
+	fmt.Println("size:", q.Size())
+	q.Push("drag")
+	fmt.Println("pop:", q.Remove())
+	// Output:
+	// size: 3
+	// pop: cat
+	// size: 2
+	// pop: dog
+}
+
+func ExampleLinkQueue() {
+	q := new(queue.LinkQueue)
+	q.Push("cat")
+	q.Push("dog")
+	q.Push("hen")
+	fmt.Println("size:", q.Size())
+	fmt.Println("pop:", q.Remove())
+	fmt.Println("pop:", q.Remove())
+	fmt.Println("size:", q.Size())
+	// Output:
+	// size: 3
+	// pop: cat
+	// pop: dog
+	// size: 1
+}
+
+func ExampleLinkedQueue() {
+	q := queue.NewLinkedQueue()
+	// 入队列
+	q.Push(1)
+	fmt.Println("Size:", q.Size())
+	q.Traverse()
+	// 出队列
+	if v, ok := q.Pop(); ok {
+		fmt.Println("Pop:", v)
+	}
+	// 批量入队列
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+	q.Traverse()
+	if v, ok := q.Pop(); ok {
+		fmt.Println("Pop:", v)
+	}
+	fmt.Println("Size:", q.Size())
+	q.Traverse()
+	// Output:
+	// Size: 1
+	// 1 ->
+	// Pop: 1
+	// 4 ->3 ->2 ->1 ->0 ->
+	// Pop: 0
+	// Size: 4
+	// 4 ->3 ->2 ->1 ->
+}
+
+func ExampleJosephus() {
+	fmt.Println(queue.Josephus(10, 3))
+	// Output:
+	// [3 6 9 2 7 1 8 5 10 4]
+}
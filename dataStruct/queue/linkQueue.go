@@ -1,4 +1,4 @@
-package main
+package queue
 
 import "sync"
 
@@ -61,4 +61,14 @@ func (queue *LinkQueue) Remove() string {
 	queue.size = queue.size - 1
 
 	return v
-}
\ No newline at end of file
+}
+
+// Size 队列大小
+func (queue *LinkQueue) Size() int {
+	return queue.size
+}
+
+// IsEmpty 队列是否为空
+func (queue *LinkQueue) IsEmpty() bool {
+	return queue.size == 0
+}
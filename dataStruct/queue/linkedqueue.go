@@ -1,43 +1,56 @@
-package main
+package queue
 
 import "fmt"
 
-// Node 定义链式队列
+// Node 定义链式队列结点
 type Node struct {
 	Value int
-	Next *Node
+	Next  *Node
 }
 
-// 初始化队列
-var size = 0
-var queue = new(Node)
+// LinkedQueue 链式队列，从队头插入，从队尾删除
+type LinkedQueue struct {
+	queue *Node // 队头
+	size  int   // 队列元素数量
+}
+
+// NewLinkedQueue 初始化队列
+func NewLinkedQueue() *LinkedQueue {
+	return &LinkedQueue{}
+}
+
+// Size 队列大小
+func (q *LinkedQueue) Size() int {
+	return q.size
+}
 
 // Push 入队（从对头插入）
-func Push(t *Node, v int) bool {
+func (q *LinkedQueue) Push(v int) bool {
 	// 如果队列为空
-	if queue == nil {
-		queue = &Node{v, nil}
-		size++
+	if q.queue == nil {
+		q.queue = &Node{v, nil}
+		q.size++
 		return true
 	}
 	// 队列不为空
-	t = &Node{v, nil}
-	t.Next = queue
-	queue = t
-	size++
+	t := &Node{v, nil}
+	t.Next = q.queue
+	q.queue = t
+	q.size++
 	return true
 }
 
 // Pop 出队列（从队尾删除）
-func Pop(t *Node) (int, bool) {
-	if size == 0 {
+func (q *LinkedQueue) Pop() (int, bool) {
+	t := q.queue
+	if q.size == 0 {
 		fmt.Println("空队列!")
 		return 0, false
 	}
 	// 如果只有一个元素
-	if size == 1 {
-		queue = nil
-		size--
+	if q.size == 1 {
+		q.queue = nil
+		q.size--
 		return t.Value, true
 	}
 	// 否则迭代队列，直到队尾
@@ -48,58 +61,20 @@ func Pop(t *Node) (int, bool) {
 	}
 	v := temp.Next.Value
 	temp.Next = nil
-	size--
+	q.size--
 	return v, true
 
 }
 
 // Traverse 遍历队列
-func Traverse(t *Node) {
-	if size == 0 {
+func (q *LinkedQueue) Traverse() {
+	if q.size == 0 {
 		fmt.Println("空队列!")
 		return
 	}
-	for t != nil {
+	for t := q.queue; t != nil; t = t.Next {
 		fmt.Printf("%d ->", t.Value)
-		t = t.Next
 	}
 	fmt.Println()
 
 }
-
-func main() {
-	queue = nil
-	// 入队列
-	Push(queue, 1)
-	fmt.Println("Size:", size)
-	// 遍历
-	Traverse(queue)
-	// 出队列
-	v, b := Pop(queue)
-	if b {
-		fmt.Println("Pop:", v)
-	}
-	fmt.Println("Size:", size)
-
-	// 批量入队列
-	for i := 0; i < 5; i++ {
-		Push(queue, i)
-	}
-	//再次遍历
-	Traverse(queue)
-	fmt.Println("Size:", size)
-	// 出队
-	v, b = Pop(queue)
-	if b {
-		fmt.Println("Pop:", v)
-	}
-	fmt.Println("Size:", size)
-	// 再次出队
-	v, b = Pop(queue)
-	if b {
-		fmt.Println("Pop:", v)
-	}
-	fmt.Println("Size:", size)
-	// 再次遍历
-	Traverse(queue)
-}
\ No newline at end of file
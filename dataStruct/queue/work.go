@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package queue
 
 /*
 例题：约瑟夫环是一个数学的应用问题，具体为:
@@ -10,13 +8,15 @@ import "fmt"
 依此规律重复下去，直到圆桌周围的人全部出列
 */
 
-func ring(n, m int) {
+// Josephus 约瑟夫环，从编号 1 开始报数，返回依次出列的编号
+func Josephus(n, m int) []int {
 	var cqueue []int
 	// 全部入队列
-	for i := 1; i < n; i++ {
+	for i := 1; i <= n; i++ {
 		cqueue = append(cqueue, i)
 	}
 
+	out := make([]int, 0, n) // 出列的顺序
 	element := 0  // 记录出队列的元素
 	//k := 2        // 报数起始变量 ，指定的k
 	i := 1        // 计算变量 i < k
@@ -40,11 +40,8 @@ func ring(n, m int) {
 		}else {
 			// 输出元素，重新开始
 			i = 1
-			fmt.Println(element)
+			out = append(out, element)
 		}
 	}
+	return out
 }
-
-func main() {
-	ring(10, 3)
-}
\ No newline at end of file
@@ -0,0 +1,128 @@
+package set_test
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/set"
+)
+
+func ExampleSet() {
+	// 初始化一个容量为5的不可重复集合
+	s := set.NewSet[int](5)
+
+	s.Add(1)
+	s.Add(1)
+	s.Add(2)
+	list := s.List()
+	sort.Ints(list)
+	fmt.Println("list of all items", list)
+
+	s.Clear()
+	if s.IsEmpty() {
+		fmt.Println("empty")
+	}
+
+	s.Add(1)
+	s.Add(2)
+	s.Add(3)
+	if s.Has(2) {
+		fmt.Println("2 does exist")
+	}
+
+	s.Remove(2)
+	s.Remove(3)
+	fmt.Println("list of all items", s.List())
+	// Output:
+	// list of all items [1 2]
+	// empty
+	// 2 does exist
+	// list of all items [1]
+}
+
+func ExampleSet_Union() {
+	a := set.NewSetOf(1, 2, 3, 4)
+	b := set.NewSetOf(3, 4, 5)
+	fmt.Println(set.Sorted(a.Union(b)))
+	fmt.Println(set.Sorted(a.Intersect(b)))
+	fmt.Println(set.Sorted(a.Difference(b)))
+	fmt.Println(set.Sorted(a.SymmetricDifference(b)))
+	// Output:
+	// [1 2 3 4 5]
+	// [3 4]
+	// [1 2]
+	// [1 2 5]
+}
+
+func ExampleSet_IsSubset() {
+	a := set.NewSetOf("go", "rust")
+	b := set.NewSetOf("go", "rust", "zig")
+	fmt.Println(a.IsSubset(b), a.IsSuperset(b), b.IsSuperset(a))
+	c := b.Clone()
+	c.Remove("zig")
+	fmt.Println(c.Equal(a), b.Len())
+	// Output:
+	// true false true
+	// true 3
+}
+
+func ExampleSet_All() {
+	words := set.NewSetOf("banana", "apple", "cherry", "apple")
+	n := 0
+	for w := range words.All() {
+		n += len(w)
+	}
+	fmt.Println(n)
+	// 按长度排序，长度相同时按字母排序
+	fmt.Println(words.SortedList(func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(a, b))
+	}))
+	// 从迭代器创建集合
+	lengths := set.Collect(func(yield func(int) bool) {
+		for w := range words.All() {
+			if !yield(len(w)) {
+				return
+			}
+		}
+	})
+	fmt.Println(set.Sorted(lengths))
+	// Output:
+	// 17
+	// [apple banana cherry]
+	// [5 6]
+}
+
+func ExampleSortedSet() {
+	z := set.NewSortedSet()
+	z.ZAdd(0, set.Z{Member: "alice", Score: 90}, set.Z{Member: "bob", Score: 75}, set.Z{Member: "carol", Score: 82})
+	// 只在新分值更大时更新
+	n, _ := z.ZAdd(set.ZAddGT|set.ZAddCH, set.Z{Member: "bob", Score: 70}, set.Z{Member: "carol", Score: 95})
+	fmt.Println(n)
+	z.ZIncrBy("bob", 10)
+	rank, _ := z.ZRevRank("carol")
+	fmt.Println(rank, z.ZRange(0, -1))
+	// 分值在 (80, 90] 之间
+	r, _ := set.ParseScoreRange("(80", "90")
+	fmt.Println(z.ZRangeByScore(r), z.ZCount(r))
+	fmt.Println(z.ZPopMax(1), z.ZCard())
+	// Output:
+	// 1
+	// 0 [{bob 85} {alice 90} {carol 95}]
+	// [{bob 85} {alice 90}] 2
+	// [{carol 95}] 2
+}
+
+func ExampleSortedSet_ZRangeByLex() {
+	z := set.NewSortedSet()
+	for _, m := range []string{"apple", "banana", "blueberry", "cherry", "date"} {
+		z.ZAdd(0, set.Z{Member: m})
+	}
+	// 以 b 开头的成员
+	r, _ := set.ParseLexRange("[b", "(c")
+	fmt.Println(z.ZRangeByLex(r))
+	fmt.Println(z.ZRemRangeByRank(0, 1), z.ZRange(0, -1))
+	// Output:
+	// [banana blueberry]
+	// 2 [{blueberry 0} {cherry 0} {date 0}]
+}
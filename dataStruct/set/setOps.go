@@ -0,0 +1,130 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// 集合运算
+/*
+	并集 Union                 A ∪ B，属于 A 或属于 B 的元素
+	交集 Intersect             A ∩ B，同时属于 A 和 B 的元素
+	差集 Difference            A - B，属于 A 但不属于 B 的元素
+	对称差 SymmetricDifference  A △ B，只属于其中一个集合的元素，等于 (A-B) ∪ (B-A)
+	子集 IsSubset               A ⊆ B，A 的元素都属于 B
+	超集 IsSuperset             A ⊇ B，B 的元素都属于 A
+运算结果都是新的集合，不修改参与运算的集合。
+
+交集只需要遍历较小的集合，逐个检查是否在较大的集合中，时间复杂度为 O(min(m, n))；
+同样，差集 A-B 只需要遍历 A，子集判断先比较大小，再遍历 A。
+
+并发安全：两个集合的锁不同时持有，先在一个集合的读锁下复制需要遍历的元素，再在另一个集合的读锁下检查，
+这样 a.Union(b) 和 b.Union(a) 同时执行也不会死锁，集合与自己运算也没有问题。
+*/
+
+// size 在读锁下返回集合大小
+func (s *Set[T]) size() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.m)
+}
+
+// filter 返回 items 中在集合中（keep 为 true）或不在集合中（keep 为 false）的元素组成的新集合
+func (s *Set[T]) filter(items []T, keep bool) *Set[T] {
+	result := NewSet[T](0)
+	s.RLock()
+	defer s.RUnlock()
+	for _, item := range items {
+		if _, ok := s.m[item]; ok == keep {
+			result.m[item] = struct{}{}
+		}
+	}
+	result.len = len(result.m)
+	return result
+}
+
+// Clone 复制集合
+func (s *Set[T]) Clone() *Set[T] {
+	return NewSetOf(s.List()...)
+}
+
+// Union 并集，时间复杂度为 O(m+n)
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := s.Clone()
+	for _, item := range other.List() {
+		result.m[item] = struct{}{}
+	}
+	result.len = len(result.m)
+	return result
+}
+
+// Intersect 交集，遍历较小的集合，时间复杂度为 O(min(m, n))
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.size() > large.size() {
+		small, large = large, small
+	}
+	return large.filter(small.List(), true)
+}
+
+// Difference 差集 s - other，遍历 s，时间复杂度为 O(m)
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	return other.filter(s.List(), false)
+}
+
+// SymmetricDifference 对称差，只属于其中一个集合的元素，时间复杂度为 O(m+n)
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := s.Difference(other)
+	for item := range other.Difference(s).m {
+		result.m[item] = struct{}{}
+	}
+	result.len = len(result.m)
+	return result
+}
+
+// IsSubset s 的元素是否都属于 other，空集是任何集合的子集
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.size() > other.size() {
+		return false
+	}
+	items := s.List()
+	other.RLock()
+	defer other.RUnlock()
+	for _, item := range items {
+		if _, ok := other.m[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset other 的元素是否都属于 s
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal 两个集合的元素是否完全相同
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.size() == other.size() && s.IsSubset(other)
+}
+
+// All 返回遍历所有元素的迭代器，顺序不确定
+// 遍历的是调用时元素的快照，遍历过程中可以修改集合
+func (s *Set[T]) All() iter.Seq[T] {
+	return slices.Values(s.List())
+}
+
+// SortedList 将集合转换为按 cmp 排序的列表，cmp 的约定与 slices.SortFunc 相同
+func (s *Set[T]) SortedList(cmp func(a, b T) int) []T {
+	list := s.List()
+	slices.SortFunc(list, cmp)
+	return list
+}
+
+// Sorted 将元素可排序的集合转换为从小到大排序的列表
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	list := s.List()
+	slices.Sort(list)
+	return list
+}
@@ -0,0 +1,77 @@
+package set
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// 与逐个元素判断的结果对比
+func TestSetOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		a, b := NewSet[int](0), NewSet[int](0)
+		for i := r.Intn(30); i > 0; i-- {
+			a.Add(r.Intn(40))
+		}
+		for i := r.Intn(30); i > 0; i-- {
+			b.Add(r.Intn(40))
+		}
+		union, inter := a.Union(b), a.Intersect(b)
+		diff, sym := a.Difference(b), a.SymmetricDifference(b)
+		subset := true
+		for x := 0; x < 40; x++ {
+			inA, inB := a.Has(x), b.Has(x)
+			if union.Has(x) != (inA || inB) || inter.Has(x) != (inA && inB) ||
+				diff.Has(x) != (inA && !inB) || sym.Has(x) != (inA != inB) {
+				t.Fatalf("round %d: wrong result for %d", round, x)
+			}
+			if inA && !inB {
+				subset = false
+			}
+		}
+		if a.IsSubset(b) != subset || b.IsSuperset(a) != subset {
+			t.Fatalf("round %d: IsSubset = %v, want %v", round, a.IsSubset(b), subset)
+		}
+		if a.Equal(b) != (subset && a.Len() == b.Len()) || !a.Equal(a.Clone()) {
+			t.Fatalf("round %d: Equal mismatch", round)
+		}
+		if union.Len() != len(union.List()) || inter.Len() != len(inter.List()) {
+			t.Fatalf("round %d: Len mismatch", round)
+		}
+	}
+}
+
+// 两个集合互相运算的同时修改集合，配合 -race 运行，不会死锁
+func TestSetOpsParallel(t *testing.T) {
+	a, b := NewSetOf(1, 2, 3), NewSetOf(3, 4, 5)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				a.Union(b)
+				a.Intersect(b)
+				a.IsSubset(b)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b.SymmetricDifference(a)
+				b.Difference(a)
+				b.Equal(a)
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				a.Add(g*1000 + i)
+				b.Add(i)
+				a.Remove(g*1000 + i - 1)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,406 @@
+package set
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hashmap"
+)
+
+// 有序集合 SortedSet（Redis 的 ZSET）
+/*
+有序集合的每个成员都有一个分值，成员不重复，按分值从小到大排序，分值相同时按成员的字典序排序。
+与 Redis 一样同时使用两个结构：
+	哈希表 HashMap 成员 -> 分值，O(1) 查询成员的分值
+	跳表 zSkipList 按 (分值, 成员) 排序，O(log n) 插入、删除、按分值查找以及按排名查找
+修改分值时先用哈希表查出旧分值，再在跳表中找到节点。
+
+命令与 Redis 相同：
+	ZAdd              添加或更新，支持 NX（只添加）、XX（只更新）、GT/LT（新分值更大/更小时才更新）、CH（返回值包括更新的数量）
+	ZIncrBy           增加分值，成员不存在时视为 0
+	ZRank/ZRevRank    从小到大/从大到小的排名，从 0 开始
+	ZRange            按排名范围获取，支持负数下标
+	ZRangeByScore     按分值范围获取，边界可以是开区间，ZCount 返回数量
+	ZRangeByLex       所有成员分值相同时按字典序范围获取
+	ZPopMin/ZPopMax   弹出分值最小/最大的成员
+	ZRemRangeByRank   按排名范围删除
+单个成员的操作都是 O(log n)，范围操作为 O(log n + m)，m 为范围内的成员数量。
+*/
+
+var (
+	// ErrIncompatibleFlags ZAdd 的选项冲突
+	ErrIncompatibleFlags = errors.New("set: XX and NX, or GT, LT and NX options at the same time are not compatible")
+	// ErrNaN 分值不是数字
+	ErrNaN = errors.New("set: resulting score is not a number (NaN)")
+	// ErrInvalidRange 范围的格式不正确
+	ErrInvalidRange = errors.New("set: min or max is not valid")
+)
+
+// Z 有序集合的成员和分值
+type Z struct {
+	Member string
+	Score  float64
+}
+
+// ZAddFlag ZAdd 的选项，可以组合使用
+type ZAddFlag int
+
+const (
+	ZAddNX ZAddFlag = 1 << iota // 只添加新成员，不更新已存在的成员
+	ZAddXX                      // 只更新已存在的成员，不添加新成员
+	ZAddGT                      // 新分值大于当前分值时才更新，不影响添加
+	ZAddLT                      // 新分值小于当前分值时才更新，不影响添加
+	ZAddCH                      // 返回值为添加和分值被修改的成员总数，默认只返回添加的数量
+)
+
+// SortedSet 有序集合，并发安全
+type SortedSet struct {
+	dict *hashmap.HashMap[string, float64] // 成员 -> 分值
+	zsl  *zSkipList                        // 按 (分值, 成员) 排序
+	lock sync.RWMutex
+}
+
+// NewSortedSet 新建空的有序集合
+func NewSortedSet() *SortedSet {
+	return &SortedSet{
+		dict: hashmap.NewHashMap[string, float64](0),
+		zsl:  newZSkipList(),
+	}
+}
+
+// ZCard 返回成员数量
+func (z *SortedSet) ZCard() int {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	return z.zsl.length
+}
+
+// ZScore 返回成员的分值，成员不存在时返回 false
+func (z *SortedSet) ZScore(member string) (float64, bool) {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	return z.dict.Get(member)
+}
+
+// ZAdd 按选项添加或更新成员，返回添加的数量（有 ZAddCH 时包括分值被修改的数量）
+// 同一次调用中重复的成员依次处理，与 Redis 相同
+func (z *SortedSet) ZAdd(flags ZAddFlag, members ...Z) (int, error) {
+	nx, xx := flags&ZAddNX != 0, flags&ZAddXX != 0
+	gt, lt := flags&ZAddGT != 0, flags&ZAddLT != 0
+	if nx && xx || (gt && lt) || (nx && (gt || lt)) {
+		return 0, ErrIncompatibleFlags
+	}
+	for _, m := range members {
+		if math.IsNaN(m.Score) {
+			return 0, ErrNaN
+		}
+	}
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	added, changed := 0, 0
+	for _, m := range members {
+		cur, ok := z.dict.Get(m.Member)
+		if !ok {
+			if xx {
+				continue
+			}
+			z.dict.Put(m.Member, m.Score)
+			z.zsl.insert(m.Score, m.Member)
+			added++
+			continue
+		}
+		if nx || (gt && m.Score <= cur) || (lt && m.Score >= cur) || m.Score == cur {
+			continue
+		}
+		z.zsl.updateScore(cur, m.Member, m.Score)
+		z.dict.Put(m.Member, m.Score)
+		changed++
+	}
+	if flags&ZAddCH != 0 {
+		return added + changed, nil
+	}
+	return added, nil
+}
+
+// ZIncrBy 成员的分值增加 delta，成员不存在时从 0 开始，返回新分值
+// 结果为 NaN（比如 +inf 加 -inf）时不修改并返回 ErrNaN
+func (z *SortedSet) ZIncrBy(member string, delta float64) (float64, error) {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	cur, ok := z.dict.Get(member)
+	score := cur + delta
+	if math.IsNaN(score) {
+		return 0, ErrNaN
+	}
+	if ok {
+		z.zsl.updateScore(cur, member, score)
+	} else {
+		z.zsl.insert(score, member)
+	}
+	z.dict.Put(member, score)
+	return score, nil
+}
+
+// ZRem 删除成员，返回删除的数量
+func (z *SortedSet) ZRem(members ...string) int {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	removed := 0
+	for _, member := range members {
+		if score, ok := z.dict.Get(member); ok {
+			z.zsl.delete(score, member)
+			z.dict.Delete(member)
+			removed++
+		}
+	}
+	return removed
+}
+
+// ZRank 返回成员从小到大的排名，从 0 开始，成员不存在时返回 false
+func (z *SortedSet) ZRank(member string) (int, bool) {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	score, ok := z.dict.Get(member)
+	if !ok {
+		return -1, false
+	}
+	return z.zsl.rank(score, member) - 1, true
+}
+
+// ZRevRank 返回成员从大到小的排名，从 0 开始，成员不存在时返回 false
+func (z *SortedSet) ZRevRank(member string) (int, bool) {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	score, ok := z.dict.Get(member)
+	if !ok {
+		return -1, false
+	}
+	return z.zsl.length - z.zsl.rank(score, member), true
+}
+
+// rankBounds 把 [start, stop] 截断到 [0, length) 之间，负数表示从尾部开始，结果为空时返回 false
+func (z *SortedSet) rankBounds(start, stop int) (int, int, bool) {
+	n := z.zsl.length
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	start = max(start, 0)
+	stop = min(stop, n-1)
+	return start, stop, start <= stop
+}
+
+// collect 从节点 x 开始往后取 n 个成员
+func collect(x *zNode, n int) []Z {
+	zs := make([]Z, 0, n)
+	for ; x != nil && len(zs) < n; x = x.level[0].forward {
+		zs = append(zs, Z{x.member, x.score})
+	}
+	return zs
+}
+
+// ZRange 返回排名在 [start, stop] 之间的成员，从小到大，负数表示从尾部开始
+func (z *SortedSet) ZRange(start, stop int) []Z {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	start, stop, ok := z.rankBounds(start, stop)
+	if !ok {
+		return []Z{}
+	}
+	return collect(z.zsl.byRank(start+1), stop-start+1)
+}
+
+// ScoreRange 分值范围，Min、Max 可以是正负无穷
+type ScoreRange struct {
+	Min, Max                   float64
+	MinExclusive, MaxExclusive bool // 是否不包括边界
+}
+
+// gteMin 分值是否满足下界
+func (r ScoreRange) gteMin(score float64) bool {
+	if r.MinExclusive {
+		return score > r.Min
+	}
+	return score >= r.Min
+}
+
+// lteMax 分值是否满足上界
+func (r ScoreRange) lteMax(score float64) bool {
+	if r.MaxExclusive {
+		return score < r.Max
+	}
+	return score <= r.Max
+}
+
+// ParseScoreRange 按 Redis 的格式解析分值范围，比如 "(1" "5"、"-inf" "+inf"，( 表示不包括边界
+func ParseScoreRange(min, max string) (ScoreRange, error) {
+	var r ScoreRange
+	var err1, err2 error
+	r.Min, r.MinExclusive, err1 = parseScoreBound(min)
+	r.Max, r.MaxExclusive, err2 = parseScoreBound(max)
+	if err1 != nil || err2 != nil {
+		return r, ErrInvalidRange
+	}
+	return r, nil
+}
+
+// parseScoreBound 解析一个分值边界
+func parseScoreBound(s string) (float64, bool, error) {
+	exclusive := len(s) > 0 && s[0] == '('
+	if exclusive {
+		s = s[1:]
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) {
+		return 0, false, ErrInvalidRange
+	}
+	return v, exclusive, nil
+}
+
+// scoreRange 返回范围内的第一个节点和成员数量
+func (z *SortedSet) scoreRange(r ScoreRange) (*zNode, int) {
+	first, firstRank := z.zsl.firstMatch(func(x *zNode) bool { return r.gteMin(x.score) })
+	if first == nil || !r.lteMax(first.score) {
+		return nil, 0
+	}
+	_, lastRank := z.zsl.lastMatch(func(x *zNode) bool { return r.lteMax(x.score) })
+	return first, lastRank - firstRank + 1
+}
+
+// ZRangeByScore 返回分值在范围内的成员，从小到大
+func (z *SortedSet) ZRangeByScore(r ScoreRange) []Z {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	first, n := z.scoreRange(r)
+	return collect(first, n)
+}
+
+// ZCount 返回分值在范围内的成员数量，O(log n)
+func (z *SortedSet) ZCount(r ScoreRange) int {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	_, n := z.scoreRange(r)
+	return n
+}
+
+// LexRange 字典序范围，MinUnbounded/MaxUnbounded 表示没有下界/上界（Redis 的 - 和 +）
+type LexRange struct {
+	Min, Max                   string
+	MinExclusive, MaxExclusive bool
+	MinUnbounded, MaxUnbounded bool
+}
+
+// gteMin 成员是否满足下界
+func (r LexRange) gteMin(member string) bool {
+	switch {
+	case r.MinUnbounded:
+		return true
+	case r.MinExclusive:
+		return member > r.Min
+	default:
+		return member >= r.Min
+	}
+}
+
+// lteMax 成员是否满足上界
+func (r LexRange) lteMax(member string) bool {
+	switch {
+	case r.MaxUnbounded:
+		return true
+	case r.MaxExclusive:
+		return member < r.Max
+	default:
+		return member <= r.Max
+	}
+}
+
+// ParseLexRange 按 Redis 的格式解析字典序范围，比如 "[a" "(c"、"-" "+"，[ 包括边界，( 不包括边界
+// 下界为 + 或上界为 - 时范围为空
+func ParseLexRange(min, max string) (LexRange, error) {
+	var r LexRange
+	empty := false
+	for i, s := range []string{min, max} {
+		var bound string
+		var exclusive, unbounded bool
+		switch {
+		case s == "-" || s == "+":
+			unbounded = (i == 0) == (s == "-")
+			empty = empty || !unbounded
+		case len(s) > 0 && (s[0] == '[' || s[0] == '('):
+			bound, exclusive = s[1:], s[0] == '('
+		default:
+			return r, ErrInvalidRange
+		}
+		if i == 0 {
+			r.Min, r.MinExclusive, r.MinUnbounded = bound, exclusive, unbounded
+		} else {
+			r.Max, r.MaxExclusive, r.MaxUnbounded = bound, exclusive, unbounded
+		}
+	}
+	if empty {
+		// 下界为 + 或上界为 -，构造一个空范围
+		return LexRange{Min: "", Max: "", MinExclusive: true, MaxExclusive: true}, nil
+	}
+	return r, nil
+}
+
+// ZRangeByLex 返回成员在字典序范围内的成员，要求所有成员的分值相同，否则结果不确定（与 Redis 相同）
+func (z *SortedSet) ZRangeByLex(r LexRange) []string {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	first, firstRank := z.zsl.firstMatch(func(x *zNode) bool { return r.gteMin(x.member) })
+	if first == nil || !r.lteMax(first.member) {
+		return []string{}
+	}
+	_, lastRank := z.zsl.lastMatch(func(x *zNode) bool { return r.lteMax(x.member) })
+	members := make([]string, 0, lastRank-firstRank+1)
+	for x := first; x != nil && len(members) < cap(members); x = x.level[0].forward {
+		members = append(members, x.member)
+	}
+	return members
+}
+
+// ZPopMin 弹出分值最小的 count 个成员，从小到大
+func (z *SortedSet) ZPopMin(count int) []Z {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	zs := make([]Z, 0, min(max(count, 0), z.zsl.length))
+	for len(zs) < cap(zs) {
+		x := z.zsl.header.level[0].forward
+		zs = append(zs, Z{x.member, x.score})
+		z.zsl.delete(x.score, x.member)
+		z.dict.Delete(x.member)
+	}
+	return zs
+}
+
+// ZPopMax 弹出分值最大的 count 个成员，从大到小
+func (z *SortedSet) ZPopMax(count int) []Z {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	zs := make([]Z, 0, min(max(count, 0), z.zsl.length))
+	for len(zs) < cap(zs) {
+		x := z.zsl.tail
+		zs = append(zs, Z{x.member, x.score})
+		z.zsl.delete(x.score, x.member)
+		z.dict.Delete(x.member)
+	}
+	return zs
+}
+
+// ZRemRangeByRank 删除排名在 [start, stop] 之间的成员，负数表示从尾部开始，返回删除的数量
+func (z *SortedSet) ZRemRangeByRank(start, stop int) int {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	start, stop, ok := z.rankBounds(start, stop)
+	if !ok {
+		return 0
+	}
+	return z.zsl.deleteRangeByRank(start+1, stop+1, func(x *zNode) {
+		z.dict.Delete(x.member)
+	})
+}
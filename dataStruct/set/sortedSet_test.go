@@ -0,0 +1,263 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkZSkipList 检查跳表的顺序、后退指针、跨度和长度
+func checkZSkipList(t *testing.T, zsl *zSkipList) {
+	t.Helper()
+	var nodes []*zNode
+	var prev *zNode
+	for x := zsl.header.level[0].forward; x != nil; x = x.level[0].forward {
+		if x.backward != prev {
+			t.Fatalf("wrong backward of %q", x.member)
+		}
+		if prev != nil && !zBefore(prev, x.score, x.member) {
+			t.Fatalf("%q is not before %q", prev.member, x.member)
+		}
+		nodes = append(nodes, x)
+		prev = x
+	}
+	if len(nodes) != zsl.length || zsl.tail != prev {
+		t.Fatalf("length %d, tail %v, want %d", zsl.length, zsl.tail, len(nodes))
+	}
+	rankOf := map[*zNode]int{zsl.header: 0}
+	for i, x := range nodes {
+		rankOf[x] = i + 1
+	}
+	for x := zsl.header; x != nil; x = x.level[0].forward {
+		for i, l := range x.level {
+			want := zsl.length - rankOf[x]
+			if l.forward != nil {
+				want = rankOf[l.forward] - rankOf[x]
+			}
+			if i < zsl.level && l.span != want {
+				t.Fatalf("span of %q at level %d is %d, want %d", x.member, i, l.span, want)
+			}
+		}
+	}
+}
+
+// 与排好序的切片对比
+func TestSortedSetRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	z := NewSortedSet()
+	ref := map[string]float64{}
+	sorted := func() []Z {
+		zs := make([]Z, 0, len(ref))
+		for m, s := range ref {
+			zs = append(zs, Z{m, s})
+		}
+		slices.SortFunc(zs, func(a, b Z) int {
+			return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(a.Member, b.Member))
+		})
+		return zs
+	}
+	for i := 0; i < 5000; i++ {
+		member := fmt.Sprint("m", r.Intn(200))
+		score := float64(r.Intn(50))
+		switch op := r.Intn(10); {
+		case op < 4:
+			if _, err := z.ZAdd(0, Z{member, score}); err != nil {
+				t.Fatal(err)
+			}
+			ref[member] = score
+		case op < 5:
+			got, _ := z.ZIncrBy(member, score-25)
+			ref[member] += score - 25
+			if got != ref[member] {
+				t.Fatalf("ZIncrBy = %v, want %v", got, ref[member])
+			}
+		case op < 7:
+			_, ok := ref[member]
+			if n := z.ZRem(member); n == 1 != ok {
+				t.Fatalf("ZRem(%q) = %d", member, n)
+			}
+			delete(ref, member)
+		case op < 8:
+			start, stop := r.Intn(20)-10, r.Intn(20)-10
+			want := sorted()
+			s, e, ok := z.rankBounds(start, stop)
+			n := z.ZRemRangeByRank(start, stop)
+			if !ok {
+				if n != 0 {
+					t.Fatalf("ZRemRangeByRank(%d, %d) = %d, want 0", start, stop, n)
+				}
+				break
+			}
+			if n != e-s+1 {
+				t.Fatalf("ZRemRangeByRank(%d, %d) = %d, want %d", start, stop, n, e-s+1)
+			}
+			for _, m := range want[s : e+1] {
+				delete(ref, m.Member)
+			}
+		case op < 9:
+			var got []Z
+			if r.Intn(2) == 0 {
+				got = z.ZPopMin(2)
+			} else {
+				got = z.ZPopMax(2)
+				slices.Reverse(got)
+			}
+			want := sorted()
+			if len(got) > 0 && got[0] != want[0] {
+				want = want[len(want)-len(got):]
+			}
+			if !slices.Equal(got, want[:len(got)]) {
+				t.Fatalf("pop = %v, want %v", got, want[:len(got)])
+			}
+			for _, m := range got {
+				delete(ref, m.Member)
+			}
+		default:
+			rg := ScoreRange{Min: score, Max: score + float64(r.Intn(10)), MinExclusive: r.Intn(2) == 0, MaxExclusive: r.Intn(2) == 0}
+			var want []Z
+			for _, m := range sorted() {
+				if rg.gteMin(m.Score) && rg.lteMax(m.Score) {
+					want = append(want, m)
+				}
+			}
+			if got := z.ZRangeByScore(rg); len(got) != len(want) || (len(want) > 0 && !slices.Equal(got, want)) {
+				t.Fatalf("ZRangeByScore(%+v) = %v, want %v", rg, got, want)
+			}
+			if n := z.ZCount(rg); n != len(want) {
+				t.Fatalf("ZCount(%+v) = %d, want %d", rg, n, len(want))
+			}
+		}
+		checkZSkipList(t, z.zsl)
+		want := sorted()
+		if z.ZCard() != len(want) || z.dict.Len() != len(want) {
+			t.Fatalf("ZCard = %d, dict %d, want %d", z.ZCard(), z.dict.Len(), len(want))
+		}
+		if i%50 == 0 {
+			if got := z.ZRange(0, -1); !slices.Equal(got, want) {
+				t.Fatalf("ZRange = %v, want %v", got, want)
+			}
+			for rank, m := range want {
+				if got, _ := z.ZRank(m.Member); got != rank {
+					t.Fatalf("ZRank(%q) = %d, want %d", m.Member, got, rank)
+				}
+				if got, _ := z.ZRevRank(m.Member); got != len(want)-1-rank {
+					t.Fatalf("ZRevRank(%q) = %d, want %d", m.Member, got, len(want)-1-rank)
+				}
+			}
+		}
+	}
+}
+
+// Redis 文档中 ZADD 选项的例子
+func TestSortedSetZAddFlags(t *testing.T) {
+	z := NewSortedSet()
+	if n, _ := z.ZAdd(0, Z{"one", 1}, Z{"uno", 1}, Z{"two", 2}, Z{"three", 3}); n != 4 {
+		t.Fatalf("ZAdd = %d, want 4", n)
+	}
+	tests := []struct {
+		flags ZAddFlag
+		z     Z
+		n     int
+		score float64
+	}{
+		{ZAddNX, Z{"one", 10}, 0, 1},
+		{ZAddXX, Z{"four", 4}, 0, math.NaN()},
+		{ZAddXX | ZAddCH, Z{"one", 5}, 1, 5},
+		{ZAddGT | ZAddCH, Z{"one", 3}, 0, 5},
+		{ZAddGT | ZAddCH, Z{"one", 6}, 1, 6},
+		{ZAddLT | ZAddCH, Z{"two", 7}, 0, 2},
+		{ZAddLT, Z{"two", 0}, 0, 0},
+		{ZAddGT | ZAddCH, Z{"five", 5}, 1, 5},
+		{ZAddCH, Z{"two", 0}, 0, 0},
+	}
+	for _, tt := range tests {
+		n, err := z.ZAdd(tt.flags, tt.z)
+		if err != nil || n != tt.n {
+			t.Fatalf("ZAdd(%b, %v) = %d, %v, want %d", tt.flags, tt.z, n, err, tt.n)
+		}
+		score, ok := z.ZScore(tt.z.Member)
+		if ok != !math.IsNaN(tt.score) || (ok && score != tt.score) {
+			t.Fatalf("ZScore(%q) = %v, %v, want %v", tt.z.Member, score, ok, tt.score)
+		}
+	}
+	for _, flags := range []ZAddFlag{ZAddNX | ZAddXX, ZAddGT | ZAddLT, ZAddNX | ZAddGT, ZAddNX | ZAddLT} {
+		if _, err := z.ZAdd(flags, Z{"one", 1}); err != ErrIncompatibleFlags {
+			t.Fatalf("ZAdd(%b) error = %v", flags, err)
+		}
+	}
+	if _, err := z.ZAdd(0, Z{"nan", math.NaN()}); err != ErrNaN {
+		t.Fatalf("ZAdd(NaN) error = %v", err)
+	}
+	z.ZAdd(0, Z{"inf", math.Inf(1)})
+	if _, err := z.ZIncrBy("inf", math.Inf(-1)); err != ErrNaN {
+		t.Fatalf("ZIncrBy(-inf) error = %v", err)
+	}
+	checkZSkipList(t, z.zsl)
+}
+
+func TestParseRange(t *testing.T) {
+	z := NewSortedSet()
+	z.ZAdd(0, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	scores := []struct {
+		min, max string
+		want     []string
+	}{
+		{"-inf", "+inf", []string{"one", "two", "three"}},
+		{"1", "2", []string{"one", "two"}},
+		{"(1", "2", []string{"two"}},
+		{"(1", "(2", nil},
+		{"3", "1", nil},
+	}
+	for _, tt := range scores {
+		r, err := ParseScoreRange(tt.min, tt.max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, m := range z.ZRangeByScore(r) {
+			got = append(got, m.Member)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("ZRangeByScore(%s, %s) = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+	for _, bad := range [][2]string{{"a", "1"}, {"1", "(x"}, {"nan", "1"}} {
+		if _, err := ParseScoreRange(bad[0], bad[1]); err != ErrInvalidRange {
+			t.Fatalf("ParseScoreRange(%q) error = %v", bad, err)
+		}
+	}
+
+	z = NewSortedSet()
+	for _, m := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		z.ZAdd(0, Z{m, 0})
+	}
+	lex := []struct {
+		min, max string
+		want     []string
+	}{
+		{"-", "[c", []string{"a", "b", "c"}},
+		{"-", "(c", []string{"a", "b"}},
+		{"[aaa", "(g", []string{"b", "c", "d", "e", "f"}},
+		{"(e", "+", []string{"f", "g"}},
+		{"+", "-", nil},
+		{"-", "-", nil},
+		{"[c", "[a", nil},
+	}
+	for _, tt := range lex {
+		r, err := ParseLexRange(tt.min, tt.max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := z.ZRangeByLex(r); len(got) != len(tt.want) || (len(got) > 0 && !slices.Equal(got, tt.want)) {
+			t.Fatalf("ZRangeByLex(%s, %s) = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+	for _, bad := range [][2]string{{"a", "+"}, {"-", ""}} {
+		if _, err := ParseLexRange(bad[0], bad[1]); err != ErrInvalidRange {
+			t.Fatalf("ParseLexRange(%q) error = %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package set
+
+import (
+	"math/rand/v2"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/algorithm/findAlgorithm/hash"
+)
+
+// 有序集合使用的跳表（与 Redis 的 zskiplist 相同）
+/*
+跳表是多层的有序链表：最底层包含所有节点，每个节点以概率 p 出现在上一层，
+查找时从最高层开始，能往前就往前，不能往前就下降一层，期望时间复杂度为 O(log n)。
+
+节点按 (分值, 成员) 排序，分值相同时按成员的字典序排序。
+每一层的指针都记录了跨度 span，即这个指针跳过了底层的多少个节点，
+查找时把经过的跨度加起来就是节点的排名，按排名查找也是 O(log n)。
+底层还有后退指针 backward，可以从尾部往前遍历。
+
+层数上限 32，p = 0.25，足够保存 2^64 个元素。
+*/
+
+// 跳表的参数
+const (
+	zSkipListMaxLevel = 32   // 最大层数
+	zSkipListP        = 0.25 // 节点出现在上一层的概率
+)
+
+// zNode 跳表节点
+type zNode struct {
+	member   string
+	score    float64
+	backward *zNode   // 底层的前一个节点
+	level    []zLevel // 每一层的后继节点和跨度
+}
+
+// zLevel 节点在某一层的后继节点和跨度
+type zLevel struct {
+	forward *zNode
+	span    int
+}
+
+// zSkipList 跳表
+type zSkipList struct {
+	header *zNode // 头节点，不保存数据，有 zSkipListMaxLevel 层
+	tail   *zNode // 尾节点
+	length int    // 节点数量
+	level  int    // 当前最高层数
+	rng    *rand.Rand
+}
+
+// newZSkipList 创建空跳表
+func newZSkipList() *zSkipList {
+	return &zSkipList{
+		header: &zNode{level: make([]zLevel, zSkipListMaxLevel)},
+		level:  1,
+		rng:    rand.New(rand.NewPCG(hash.RandomSeed(), hash.RandomSeed())),
+	}
+}
+
+// randomLevel 随机生成新节点的层数，层数为 k 的概率为 (1-p)*p^(k-1)
+func (zsl *zSkipList) randomLevel() int {
+	level := 1
+	for level < zSkipListMaxLevel && zsl.rng.Float64() < zSkipListP {
+		level++
+	}
+	return level
+}
+
+// zBefore 节点是否排在 (score, member) 之前
+func zBefore(x *zNode, score float64, member string) bool {
+	return x.score < score || (x.score == score && x.member < member)
+}
+
+// zLess (score, member) 是否排在节点之前
+func zLess(score float64, member string, x *zNode) bool {
+	return score < x.score || (score == x.score && member < x.member)
+}
+
+// findUpdate 找到每一层中排在 (score, member) 之前的最后一个节点，以及这些节点的排名
+func (zsl *zSkipList) findUpdate(score float64, member string) (update [zSkipListMaxLevel]*zNode, rank [zSkipListMaxLevel]int) {
+	x := zsl.header
+	for i := zsl.level - 1; i >= 0; i-- {
+		if i < zsl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.level[i].forward != nil && zBefore(x.level[i].forward, score, member) {
+			rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+	return
+}
+
+// insert 插入节点，调用者保证成员不存在
+func (zsl *zSkipList) insert(score float64, member string) *zNode {
+	update, rank := zsl.findUpdate(score, member)
+	level := zsl.randomLevel()
+	if level > zsl.level {
+		// 新增的层，头节点的跨度为整个跳表
+		for i := zsl.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = zsl.header
+			update[i].level[i].span = zsl.length
+		}
+		zsl.level = level
+	}
+	x := &zNode{member: member, score: score, level: make([]zLevel, level)}
+	for i := 0; i < level; i++ {
+		x.level[i].forward = update[i].level[i].forward
+		update[i].level[i].forward = x
+		// update[i] 到 x 之间隔了 rank[0]-rank[i] 个节点
+		x.level[i].span = update[i].level[i].span - (rank[0] - rank[i])
+		update[i].level[i].span = rank[0] - rank[i] + 1
+	}
+	// 更高的层跨过了新节点，跨度加一
+	for i := level; i < zsl.level; i++ {
+		update[i].level[i].span++
+	}
+	if update[0] != zsl.header {
+		x.backward = update[0]
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x
+	} else {
+		zsl.tail = x
+	}
+	zsl.length++
+	return x
+}
+
+// deleteNode 删除节点，update 为 findUpdate 的结果
+func (zsl *zSkipList) deleteNode(x *zNode, update *[zSkipListMaxLevel]*zNode) {
+	for i := 0; i < zsl.level; i++ {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		zsl.tail = x.backward
+	}
+	for zsl.level > 1 && zsl.header.level[zsl.level-1].forward == nil {
+		zsl.level--
+	}
+	zsl.length--
+}
+
+// delete 删除 (score, member) 对应的节点，返回是否找到
+func (zsl *zSkipList) delete(score float64, member string) bool {
+	update, _ := zsl.findUpdate(score, member)
+	x := update[0].level[0].forward
+	if x == nil || x.score != score || x.member != member {
+		return false
+	}
+	zsl.deleteNode(x, &update)
+	return true
+}
+
+// updateScore 修改成员的分值，新分值不改变位置时直接修改，否则删除后重新插入
+func (zsl *zSkipList) updateScore(score float64, member string, newScore float64) {
+	update, _ := zsl.findUpdate(score, member)
+	x := update[0].level[0].forward
+	if (x.backward == nil || x.backward.score < newScore) &&
+		(x.level[0].forward == nil || x.level[0].forward.score > newScore) {
+		x.score = newScore
+		return
+	}
+	zsl.deleteNode(x, &update)
+	zsl.insert(newScore, member)
+}
+
+// rank 返回 (score, member) 的排名，从 1 开始，不存在时返回 0
+func (zsl *zSkipList) rank(score float64, member string) int {
+	x := zsl.header
+	rank := 0
+	for i := zsl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !zLess(score, member, x.level[i].forward) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+		if x != zsl.header && x.score == score && x.member == member {
+			return rank
+		}
+	}
+	return 0
+}
+
+// byRank 返回排名为 rank 的节点，rank 从 1 开始，超出范围时返回 nil
+func (zsl *zSkipList) byRank(rank int) *zNode {
+	x := zsl.header
+	traversed := 0
+	for i := zsl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span <= rank {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		if traversed == rank && x != zsl.header {
+			return x
+		}
+	}
+	return nil
+}
+
+// firstMatch 返回第一个满足 atLeastMin 的节点及其排名，atLeastMin 单调（前面为 false、后面为 true），没有时返回 nil
+func (zsl *zSkipList) firstMatch(atLeastMin func(x *zNode) bool) (*zNode, int) {
+	x := zsl.header
+	rank := 0
+	for i := zsl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !atLeastMin(x.level[i].forward) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+	}
+	return x.level[0].forward, rank + 1
+}
+
+// lastMatch 返回最后一个满足 atMostMax 的节点及其排名，atMostMax 单调（前面为 true、后面为 false），没有时返回 nil
+func (zsl *zSkipList) lastMatch(atMostMax func(x *zNode) bool) (*zNode, int) {
+	x := zsl.header
+	rank := 0
+	for i := zsl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && atMostMax(x.level[i].forward) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+	}
+	if x == zsl.header {
+		return nil, 0
+	}
+	return x, rank
+}
+
+// deleteRangeByRank 删除排名在 [start, end] 之间的节点，排名从 1 开始，对每个被删除的节点调用 deleted，返回删除的数量
+func (zsl *zSkipList) deleteRangeByRank(start, end int, deleted func(x *zNode)) int {
+	var update [zSkipListMaxLevel]*zNode
+	x := zsl.header
+	traversed := 0
+	for i := zsl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span < start {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+	traversed++
+	x = x.level[0].forward
+	removed := 0
+	for x != nil && traversed <= end {
+		next := x.level[0].forward
+		zsl.deleteNode(x, &update)
+		deleted(x)
+		removed++
+		traversed++
+		x = next
+	}
+	return removed
+}
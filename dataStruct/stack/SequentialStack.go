@@ -1,9 +1,8 @@
-package main
+package stack
 
 import "fmt"
 
-// 顺序栈
-
+// Stack 顺序栈
 type Stack struct {
 	Value []int
 }
@@ -39,29 +38,7 @@ func (s *Stack) Traverse() {
 	fmt.Println()
 }
 
-func main() {
-	stack := Stack{}
-	fmt.Println(stack)
-	// 遍历
-	stack.Traverse()
-	// 入栈
-	stack.Push(1)
-	// 再次遍历
-	stack.Traverse()
-	// 多次入栈
-	stack.Push(2)
-	stack.Push(3)
-	stack.Push(4)
-	// 遍历
-	stack.Traverse()
-	//出栈
-	v1, _ := stack.Pop()
-	fmt.Println("弹出：",v1)
-	// 遍历
-	stack.Traverse()
-	v2, _ := stack.Pop()
-	v3, _ := stack.Pop()
-	fmt.Println("弹出：",v2, v3)
-	// 遍历
-	stack.Traverse()
-}
\ No newline at end of file
+// Len 栈的元素数量
+func (s *Stack) Len() int {
+	return len(s.Value)
+}
@@ -3,17 +3,16 @@
 	但存在元素在数组空间中大量移动的操作，增删效率低。
 链表实现：只支持顺序访问，在某些遍历操作中查询速度慢，但增删元素快。
 */
-package main
+package stack
 
 import (
-	"fmt"
 	"sync"
 )
 
 // 实现数组栈 ,数组形式的下压栈，后进先出:
 // 主要使用可变长数组来实现。
 
-// 定义数据结构
+// ArrayStack 定义数据结构
 type ArrayStack struct {
 	array []string  // 底层切片
 	size int  // 栈的元素数量
@@ -92,17 +91,3 @@ func (stack *ArrayStack) Size() int {
 func (stack *ArrayStack) IsEmpty() bool {
 	return stack.size == 0
 }
-
-// 测试
-func main() {
-	arrayStack := new(ArrayStack)
-	arrayStack.Push("cat")
-	arrayStack.Push("dog")
-	arrayStack.Push("hen")
-	fmt.Println("size:", arrayStack.Size())
-	fmt.Println("pop:", arrayStack.Pop())
-	fmt.Println("pop:", arrayStack.Pop())
-	fmt.Println("size:", arrayStack.Size())
-	arrayStack.Push("drag")
-	fmt.Println("pop:", arrayStack.Pop())
-}
\ No newline at end of file
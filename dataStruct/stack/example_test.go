@@ -0,0 +1,121 @@
+package stack_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/stack"
+)
+
+func ExampleStack() {
+	s := stack.Stack{}
+	// 遍历
+	s.Traverse()
+	// 多次入栈
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+	s.Push(4)
+	s.Traverse()
+	//出栈
+	v1, _ := s.Pop()
+	fmt.Println("弹出：", v1)
+	v2, _ := s.Pop()
+	v3, _ := s.Pop()
+	fmt.Println("弹出：", v2, v3)
+	s.Traverse()
+	// Output:
+	// 空栈!
+	// 1 ->2 ->3 ->4 ->
+	// 弹出： 4
+	// 弹出： 3 2
+	// 1 ->
+}
+
+func ExampleArrayStack() {
+	arrayStack := new(stack.ArrayStack)
+	arrayStack.Push("cat")
+	arrayStack.Push("dog")
+	arrayStack.Push("hen")
+	fmt.Println("size:", arrayStack.Size())
+	fmt.Println("pop:", arrayStack.Pop())
+	fmt.Println("pop:", arrayStack.Pop())
+	fmt.Println("size:", arrayStack.Size())
+	arrayStack.Push("drag")
+	fmt.Println("pop:", arrayStack.Pop())
+	// Output:
+	// size: 3
+	// pop: hen
+	// pop: dog
+	// size: 1
+	// pop: drag
+}
+
+func ExampleLinkStack() {
+	linkStack := new(stack.LinkStack)
+	linkStack.Push("cat")
+	linkStack.Push("dog")
+	linkStack.Push("hen")
+	fmt.Println("size:", linkStack.Size())
+	fmt.Println("pop:", linkStack.Pop())
+	fmt.Println("pop:", linkStack.Pop())
+	fmt.Println("size:", linkStack.Size())
+	linkStack.Push("drag")
+	fmt.Println("pop:", linkStack.Pop())
+	// Output:
+	// size: 3
+	// pop: hen
+	// pop: dog
+	// size: 1
+	// pop: drag
+}
+
+func ExampleNodeStack() {
+	s := stack.NewNodeStack()
+	// 读取空栈
+	if _, ok := s.Pop(); !ok {
+		fmt.Println("Pop() 失败!")
+	}
+	s.Push(100)
+	s.Push(200)
+	// 批量进栈
+	for i := 0; i < 5; i++ {
+		s.Push(i)
+	}
+	s.Traverse()
+	// 批量出栈
+	for {
+		v, ok := s.Pop()
+		if !ok {
+			// 如果已经是空栈，则退出循环
+			break
+		}
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output:
+	// Pop() 失败!
+	// 4 ->3 ->2 ->1 ->0 ->200 ->100 ->
+	// 4 3 2 1 0 200 100
+}
+
+func ExampleIsLegalByStack() {
+	fmt.Println(stack.IsLegalByStack("{[()()]}"), stack.IsLegal("{[()()]}"))
+	fmt.Println(stack.IsLegalByStack("{([)]}"), stack.IsLegal("{([)]}"))
+	// Output:
+	// true true
+	// false false
+}
+
+func ExampleReverseKGroup() {
+	var node *stack.LinkNodes
+	for i := 1; i <= 6; i++ {
+		node = node.AddLinkNodes(i)
+	}
+	node.Traverse()
+	stack.ReverseKGroup(node, 3).Traverse()
+	fmt.Println(stack.ReverseKGroupArray([]int{1, 2, 3, 4, 5, 6}, 2))
+	// Output:
+	// 1 ->2 ->3 ->4 ->5 ->6 ->
+	// 3 ->2 ->1 ->6 ->5 ->4 ->
+	// [2 1 4 3 6 5]
+}
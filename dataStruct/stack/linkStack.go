@@ -1,7 +1,6 @@
-package main
+package stack
 
 import (
-	"fmt"
 	"sync"
 )
 
@@ -99,18 +98,3 @@ func (stack *LinkStack) Size() int {
 func (stack *LinkStack) IsEmpty() bool {
 	return stack.size == 0
 }
-
-// 测试
-func main() {
-	linkStack := new(LinkStack)
-	linkStack.Push("cat")
-	linkStack.Push("dog")
-	linkStack.Push("hen")
-	fmt.Println("size:", linkStack.Size())
-	fmt.Println("pop:", linkStack.Pop())
-	fmt.Println("pop:", linkStack.Pop())
-	fmt.Println("size:", linkStack.Size())
-	linkStack.Push("drag")
-	fmt.Println("pop:", linkStack.Pop())
-}
-
@@ -1,4 +1,4 @@
-package main
+package stack
 
 import "fmt"
 
@@ -7,95 +7,68 @@ import "fmt"
 // Node 定义链表栈结点
 type Node struct {
 	Value int
-	Next *Node
+	Next  *Node
 }
 
-// 初始化结构(空栈)
-var size = 0
-var stack = new(Node)
+// NodeStack 链式栈，stack 为栈顶结点
+type NodeStack struct {
+	stack *Node
+	size  int
+}
+
+// NewNodeStack 初始化结构(空栈)
+func NewNodeStack() *NodeStack {
+	return &NodeStack{}
+}
+
+// Size 栈的元素数量
+func (s *NodeStack) Size() int {
+	return s.size
+}
 
 // Push 进栈
-func Push(v int) bool {
+func (s *NodeStack) Push(v int) bool {
 	// 空栈的话直接放入头节点即可
-	if stack == nil {
-		stack = &Node{v, nil}
-		size = 1
+	if s.stack == nil {
+		s.stack = &Node{v, nil}
+		s.size = 1
 		return true
 	}
 	// 否则将插入节点作为栈的头节点
 	temp := &Node{v, nil}
-	temp.Next = stack
-	stack = temp
-	size++
+	temp.Next = s.stack
+	s.stack = temp
+	s.size++
 	return true
 }
 
 // Pop 出栈
-func Pop(t *Node) (int, bool) {
+func (s *NodeStack) Pop() (int, bool) {
+	t := s.stack
 	// 空栈
-	if size == 0 {
+	if s.size == 0 {
 		return 0, false
 	}
 	// 只有一个结点
-	if size == 1 {
-		size = 0
-		stack = nil
+	if s.size == 1 {
+		s.size = 0
+		s.stack = nil
 		return t.Value, true
 	}
 	// 有多个节点，将栈的头节点指针指向下一个结点，并返回之前的头节点数据
-	stack = stack.Next
-	size--
+	s.stack = s.stack.Next
+	s.size--
 	return t.Value, true
 }
 
 // Traverse 栈的遍历
-func Traverse(t *Node) {
-	if size == 0 {
+func (s *NodeStack) Traverse() {
+	if s.size == 0 {
 		fmt.Println("空栈")
 		return
 	}
-	for t != nil {
+	for t := s.stack; t != nil; t = t.Next {
 		fmt.Printf("%d ->", t.Value)
-		t = t.Next
 	}
 	fmt.Println()
 }
-
-func main() {
-	stack = nil
-	// 读取空栈
-	v, b := Pop(stack)
-	if b {
-		fmt.Print(v, " ")
-	} else {
-		fmt.Println("Pop() 失败!")
-	}
-	// 进栈
-	Push(100)
-	// 遍历栈
-	Traverse(stack)
-	// 再次进栈
-	Push(200)
-	// 再次遍历
-	Traverse(stack)
-	// 批量进栈
-	for i := 0; i < 10; i++ {
-		Push(i)
-	}
-	// 再次遍历
-	Traverse(stack)
-	// 批量出栈
-	for i := 0; i < 15; i++ {
-		v, b = Pop(stack)
-		if b {
-			fmt.Print(v, "")
-		}else {
-			// 如果已经是空栈，则退出循环
-			break
-		}
-	}
-	fmt.Println()
-	// 再次遍历栈
-
-}
-
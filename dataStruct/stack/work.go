@@ -1,4 +1,4 @@
-package main
+package stack
 
 import (
 	"fmt"
@@ -28,7 +28,8 @@ func isPair(p, curr string) int {
 	}
 }
 
-func isLegal(s string) string {
+// IsLegal 使用切片模拟栈判断括号字符串是否合法
+func IsLegal(s string) bool {
 	var stack []string
 	for i := 0; i < len(s); i++ {
 		currs := s[i]
@@ -38,23 +39,24 @@ func isLegal(s string) string {
 			stack = append(stack, curr)
 		} else {
 			if len(stack) == 0 {
-				return "非法"
+				return false
 			}
 			// 出栈
 			p := stack[len(stack)-1]
 			stack = stack[:len(stack)-1]
 			if isPair(p, curr) == 0 {
-				return "非法"
+				return false
 			}
 		}
 	}
 	if len(stack) == 0 {
-		return "合法"
+		return true
 	} else {
-		return "非法"
+		return false
 	}
 }
 
+// IsLegalByStack 遇到左括号时将对应的右括号入栈，遇到右括号时与栈顶比较
 func IsLegalByStack(str string) bool {
 	stack := make([]string, 0)
 
@@ -64,26 +66,24 @@ func IsLegalByStack(str string) bool {
 		switch val {
 		case "{":
 			stack = append(stack, "}")
+			continue
 		case "[":
 			stack = append(stack, "]")
+			continue
 		case "(":
 			stack = append(stack, ")")
+			continue
 		}
 		// 还没遍历完，栈就没元素了，栈为空，说明没有匹配到相应的左边元素
-		if len(stack) == 0 {
+		// 右括号与栈顶元素不相等，也不匹配
+		if len(stack) == 0 || val != stack[len(stack)-1] {
 			return false
 		}
 		// 右括号且与栈顶元素相等，出栈
-		if len(stack) > 0 && val == stack[len(stack)-1] {
-			stack = stack[:len(stack)-1]
-		}
+		stack = stack[:len(stack)-1]
 	}
 	// 遍历完毕，栈内元素为空，说明都匹配消除了
-	if len(stack) == 0 {
-		return true
-	}
-	return false
-
+	return len(stack) == 0
 }
 
 /*
@@ -93,15 +93,17 @@ func IsLegalByStack(str string) bool {
 列如：链表为1->2->3->4->5->6, k=3 则打印321654
 */
 
+// LinkedStack 存放 int 的链表栈
 type LinkedStack struct {
 	root *LinkNodes
 	size int
 	lock sync.Mutex
 }
+
+// LinkNodes 链表结点
 type LinkNodes struct {
 	Next  *LinkNodes
 	Value int
-	Lock  sync.Mutex
 }
 
 // Push 入栈
@@ -113,7 +115,7 @@ func (stack *LinkedStack) Push(v int) {
 		stack.root.Value = v
 	} else {
 		preNode := stack.root
-		newNode := &LinkNodes{preNode, v, sync.Mutex{}}
+		newNode := &LinkNodes{preNode, v}
 		stack.root = newNode
 	}
 	stack.size = stack.size + 1
@@ -140,25 +142,22 @@ func (stack *LinkedStack) Size() int {
 	return stack.size
 }
 
-// AddLinkNodes 添加LinkNodes的元素
+// AddLinkNodes 在链表尾部添加元素，返回链表头
+// node 为空时新建链表
 func (node *LinkNodes) AddLinkNodes(v int) *LinkNodes {
-	node.Lock.Lock()
-	defer node.Lock.Unlock()
 	if node == nil {
-		node = &LinkNodes{nil, v, sync.Mutex{}}
-		return node
+		return &LinkNodes{nil, v}
 	}
-	for node.Next != nil {
-		node = node.Next
+	tail := node
+	for tail.Next != nil {
+		tail = tail.Next
 	}
-	node.Next = &LinkNodes{nil, v, sync.Mutex{}}
+	tail.Next = &LinkNodes{nil, v}
 	return node
 }
 
 // GetLinkNodesLen 获取LinkNodes的大小
 func (node *LinkNodes) GetLinkNodesLen() int {
-	node.Lock.Lock()
-	defer node.Lock.Unlock()
 	n := 0
 	for node != nil {
 		n++
@@ -169,8 +168,6 @@ func (node *LinkNodes) GetLinkNodesLen() int {
 
 // GetIndexNodeValue 获取LinkNodes指定节点值
 func (node *LinkNodes) GetIndexNodeValue(n int) int {
-	node.Lock.Lock()
-	defer node.Lock.Unlock()
 	if node == nil {
 		return 0
 	}
@@ -182,8 +179,6 @@ func (node *LinkNodes) GetIndexNodeValue(n int) int {
 
 // Traverse 遍历链表
 func (node *LinkNodes) Traverse() {
-	node.Lock.Lock()
-	defer node.Lock.Unlock()
 	if node == nil {
 		fmt.Println("-> 空链表!")
 		return
@@ -195,43 +190,33 @@ func (node *LinkNodes) Traverse() {
 	fmt.Println()
 }
 
-func raveress(node *LinkNodes, count int) *LinkedStack {
-	var newStack *LinkedStack
-	var resultStack *LinkedStack
-	lenth := node.GetLinkNodesLen()
+// ReverseKGroup 链表每 count 个结点一组进行反转，返回反转后的新链表
+// 按指定个数入栈，满 count 个后全部出栈追加到新链表
+func ReverseKGroup(node *LinkNodes, count int) *LinkNodes {
+	newStack := new(LinkedStack)
+	var result *LinkNodes
 	n := 0
-	for i := 0; i < lenth; i++ {
+	for ; node != nil; node = node.Next {
 		// 按指定的反转个数入栈
-		fmt.Println(lenth)
-		newStack.Push(node.GetIndexNodeValue(i))
+		newStack.Push(node.Value)
 		n++
 		// 判断标记数是否等于指定反转个数，不等于继续入栈，等于出栈
 		if n < count {
 			continue
-		} else {
-			// 入栈个数大于指定的反转个数，出栈
-			for j := 0; j < count; j++ {
-				pop := newStack.Pop()
-				// 出栈元素追加到存储翻转后元素的新切片
-				resultStack.Push(pop)
-			}
-			n = 0
 		}
+		for j := 0; j < count; j++ {
+			// 出栈元素追加到存储翻转后元素的新链表
+			result = result.AddLinkNodes(newStack.Pop())
+		}
+		n = 0
 	}
-	// 返回按指定个数翻转后的新切片
-	return resultStack
-}
-
-func main() {
-	s := "{[()()]}"
-	s1 := "{[(]}"
-	fmt.Println(IsLegalByStack(s))
-	fmt.Println(IsLegalByStack(s1))
-	node := new(LinkNodes)
-	for i := 1; i <= 6; i++ {
-		node.AddLinkNodes(i)
-	}
-	node.Traverse()
-	newNod := raveress(node, 3)
-	fmt.Println(newNod)
+	// 剩余不足 count 个的元素保持原有顺序
+	rest := make([]int, 0, n)
+	for newStack.Size() > 0 {
+		rest = append(rest, newStack.Pop())
+	}
+	for i := len(rest) - 1; i >= 0; i-- {
+		result = result.AddLinkNodes(rest[i])
+	}
+	return result
 }
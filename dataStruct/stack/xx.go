@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package stack
 
 /*
 例2
@@ -9,7 +7,8 @@ import "fmt"
 列如：数组为[1,2,3,4,5,6], k=3 则打印321654
 */
 
-func raveres(nums []int, count int) []int {
+// ReverseKGroupArray 数组每 count 个元素一组进行反转
+func ReverseKGroupArray(nums []int, count int) []int {
 	var newNum []int
 	var resultArray []int
 	lenth := len(nums)
@@ -35,9 +34,3 @@ func raveres(nums []int, count int) []int {
 	// 返回按指定个数翻转后的新切片
 	return resultArray
 }
-
-func main() {
-	nums := []int{1,2,3,4,5,6}
-	b := raveres(nums, 2)
-	fmt.Printf("%#v\n", b)
-}
\ No newline at end of file
@@ -0,0 +1,23 @@
+package strings_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/strings"
+)
+
+func ExampleIsSubStr() {
+	fmt.Println(strings.IsSubStr("google", "goodgodogle"))
+	fmt.Println(strings.IsSubStr("google", "goodgoogle"))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleReverseWords() {
+	fmt.Println(strings.ReverseWords("the sky is blue"))
+	fmt.Println(strings.Reverse("hello"))
+	// Output:
+	// blue is sky the
+	// olleh
+}
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package strings
 
 // 字符串
 /*
@@ -15,10 +13,13 @@ import "fmt"
 //查找 s 中是否有字符与 t 的第一个字符相等
 */
 
-func isStrSub(sub, src string) int {
-	isFind := 0
+// IsSubStr 朴素模式匹配，判断 sub 是否为 src 的子串
+func IsSubStr(sub, src string) bool {
+	if len(sub) == 0 {
+		return true
+	}
 	// 只需要变量主串，与模式串之间差值的长度，去掉无效的比较节省时间
-	for i :=0; i < (len(src) - len(sub) + 1); i++ {
+	for i := 0; i < (len(src) - len(sub) + 1); i++ {
 		// 判断首位是否相等
 		if src[i] == sub[0] {
 			jc := 0 //标记相等的位置
@@ -30,60 +31,50 @@ func isStrSub(sub, src string) int {
 				}
 				jc = j
 			}
-			if jc == len(sub) - 1 {
-				isFind = 1
+			if jc == len(sub)-1 && src[i+jc] == sub[jc] {
+				return true
 			}
 		}
 	}
-	return isFind
+	return false
 }
 
-
-// 递归打印斐波那契数列
-func fblsit(num int) int {
-	if num == 1 {
-		return 0
-	}
-	if num == 2 {
-		return 1
-	}
-	return fblsit(num-1) + fblsit(num-2)
-}
-
-
-func main() {
-	src := "goodgodogle"
-	sub := "google"
-
-	fmt.Println(isStrSub(sub, src))
-	s := "the sky is blue"
-	fmt.Println(fblsit(4))
-	reverseStr(s)
-
-}
-
-
-func sum (num int) int {
-	return sum(num-1) + num
-}
-
-// 字符串反转 "the sky is blue" >> "blue is sky the"
-func reverseStr(srcStr string)  {
+// ReverseWords 字符串按单词反转 "the sky is blue" >> "blue is sky the"
+func ReverseWords(srcStr string) string {
 	var stack []string
 	targetStr := ""
 
-	for _, v := range srcStr{
+	for _, v := range srcStr {
 		if v != ' ' {
 			targetStr = targetStr + string(v)
-		}else {
-			stack = append(stack, targetStr)
+		} else {
+			if targetStr != "" {
+				stack = append(stack, targetStr)
+			}
 			targetStr = ""
 		}
 	}
+	// 最后一个单词入栈
+	if targetStr != "" {
+		stack = append(stack, targetStr)
+	}
+	result := ""
 	for len(stack) != 0 {
 		a := stack[len(stack)-1]
-		fmt.Printf("%s-", a)
 		stack = stack[:len(stack)-1]
+		result += a
+		if len(stack) != 0 {
+			result += " "
+		}
 	}
+	return result
+}
 
-}
\ No newline at end of file
+// Reverse 按字符反转字符串
+func Reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
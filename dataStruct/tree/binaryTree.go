@@ -1,4 +1,4 @@
-package main
+package tree
 
 import "fmt"
 
@@ -76,20 +76,3 @@ func PostOrder(tree *TreeNode) {
 	// 最后打印根节点
 	fmt.Printf("%s-", tree.Data)
 }
-
-// 测试
-func main() {
-	t := &TreeNode{Data: "A"}
-	t.Left = &TreeNode{Data: "B"}
-	t.Right = &TreeNode{Data: "C"}
-	t.Left.Left = &TreeNode{Data: "D"}
-	t.Left.Right = &TreeNode{Data: "E"}
-	t.Right.Left = &TreeNode{Data: "F"}
-
-	fmt.Println("先序排序：")
-	PreOrder(t)
-	fmt.Println("\n中序排序：")
-	MidOrder(t)
-	fmt.Println("\n后序排序")
-	PostOrder(t)
-}
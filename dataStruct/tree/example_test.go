@@ -0,0 +1,44 @@
+package tree_test
+
+import (
+	"fmt"
+
+	"github.com/teng-tt/dataStructAlgorithmOfGo/dataStruct/tree"
+)
+
+func newTree() *tree.TreeNode {
+	t := &tree.TreeNode{Data: "A"}
+	t.Left = &tree.TreeNode{Data: "B"}
+	t.Right = &tree.TreeNode{Data: "C"}
+	t.Left.Left = &tree.TreeNode{Data: "D"}
+	t.Left.Right = &tree.TreeNode{Data: "E"}
+	t.Right.Left = &tree.TreeNode{Data: "F"}
+	return t
+}
+
+func ExamplePreOrder() {
+	t := newTree()
+	fmt.Println("先序排序：")
+	tree.PreOrder(t)
+	fmt.Println("\n中序排序：")
+	tree.MidOrder(t)
+	fmt.Println("\n后序排序")
+	tree.PostOrder(t)
+	fmt.Println()
+	// Output:
+	// 先序排序：
+	// A-B-D-E-C-F-
+	// 中序排序：
+	// D-B-E-A-F-C-
+	// 后序排序
+	// D-E-B-F-C-A-
+}
+
+func ExampleLayerOrder() {
+	fmt.Println("层次排序")
+	tree.LayerOrder(newTree())
+	fmt.Println()
+	// Output:
+	// 层次排序
+	// A B C D E F
+}
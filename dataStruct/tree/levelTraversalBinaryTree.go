@@ -1,4 +1,4 @@
-package main
+package tree
 
 import (
 	"fmt"
@@ -16,38 +16,33 @@ import (
 重复2，直到队列里面没有元素
 */
 
-// TreeNode 详细代码实现如下
-type TreeNode struct {
-	Data string
-	Left *TreeNode
-	Right *TreeNode
-}
+// 详细代码实现如下，TreeNode 复用 binaryTree.go 中的定义
 
-// LinkNode 链表节点
-type LinkNode struct {
-	Next *LinkNode
+// linkNode 链表节点
+type linkNode struct {
+	Next *linkNode
 	Value *TreeNode
 }
 
-// LinkQueue 链表队列，先进先出
-type LinkQueue struct {
-	root *LinkNode
+// linkQueue 链表队列，先进先出
+type linkQueue struct {
+	root *linkNode
 	size int
 	lock sync.Mutex
 }
 
 // Push 入队
-func (queue *LinkQueue) Push(v *TreeNode) {
+func (queue *linkQueue) Push(v *TreeNode) {
 	queue.lock.Lock()
 	defer queue.lock.Unlock()
 	// 如果栈顶为空，那么增加节点
 	if queue.root == nil {
-		queue.root = new(LinkNode)
+		queue.root = new(linkNode)
 		queue.root.Value = v
 	}else {
 		// 否则新元素插入链表的末尾
 		// 新节点
-		newNode := new(LinkNode)
+		newNode := new(linkNode)
 		newNode.Value = v
 		// 一直遍历到链表尾部
 		nowNode := queue.root
@@ -62,7 +57,7 @@ func (queue *LinkQueue) Push(v *TreeNode) {
 }
 
 // Pop 出队
-func (queue *LinkQueue) Pop() *TreeNode {
+func (queue *linkQueue) Pop() *TreeNode {
 	queue.lock.Lock()
 	defer queue.lock.Unlock()
 	// 队中元素已空
@@ -80,7 +75,7 @@ func (queue *LinkQueue) Pop() *TreeNode {
 }
 
 // Size 队列中元素数量
-func (queue *LinkQueue) Size() int {
+func (queue *linkQueue) Size() int {
 	return queue.size
 }
 
@@ -90,7 +85,7 @@ func LayerOrder(tree *TreeNode) {
 		return
 	}
 	// 新建队列
-	queue := new(LinkQueue)
+	queue := new(linkQueue)
 	// 根节点入队列
 	queue.Push(tree)
 	for queue.size > 0 {
@@ -108,16 +103,3 @@ func LayerOrder(tree *TreeNode) {
 		}
 	}
 }
-
-func main() {
-	// 测试
-	t := &TreeNode{Data: "A"}
-	t.Left = &TreeNode{Data: "B"}
-	t.Right = &TreeNode{Data: "C"}
-	t.Left.Left = &TreeNode{Data: "D"}
-	t.Left.Right = &TreeNode{Data: "E"}
-	t.Right.Left = &TreeNode{Data: "F"}
-
-	fmt.Println("\n层次排序")
-	LayerOrder(t)
-}
\ No newline at end of file
@@ -0,0 +1,5 @@
+module github.com/teng-tt/dataStructAlgorithmOfGo
+
+go 1.24
+
+require github.com/OneOfOne/xxhash v1.2.8